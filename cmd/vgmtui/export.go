@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+	"github.com/dewi-tim/vgmtui/internal/player"
+)
+
+// exportLibrary scans the library at root and writes it to out as JSON if
+// out ends in ".json", or as CSV otherwise. An out of "-" writes CSV to
+// standard output. It returns the process exit code.
+func exportLibrary(root, out string) int {
+	lib := library.New(root, player.DefaultMetadata)
+	n, err := lib.Scan()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "vgmtui: scanning %s: %v\n", root, err)
+		return 1
+	}
+
+	export := lib.ExportCSV
+	if strings.EqualFold(filepath.Ext(out), ".json") {
+		export = lib.ExportJSON
+	}
+
+	if out == "-" {
+		if err := export(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "vgmtui: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "vgmtui: %v\n", err)
+		return 1
+	}
+	if err := export(f); err != nil {
+		f.Close()
+		fmt.Fprintf(os.Stderr, "vgmtui: writing %s: %v\n", out, err)
+		return 1
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "vgmtui: writing %s: %v\n", out, err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d tracks from %s to %s\n", n, root, out)
+	return 0
+}
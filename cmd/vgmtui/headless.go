@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+	"github.com/dewi-tim/vgmtui/internal/player"
+	"github.com/dewi-tim/vgmtui/internal/ui/components"
+)
+
+// headlessPollInterval is how often headless playback checks progress.
+const headlessPollInterval = 250 * time.Millisecond
+
+// runHeadless plays the files and directories in paths one after another
+// without the TUI, printing progress to out. It returns when the last
+// track finishes or on an interrupt signal, with the process exit code.
+func runHeadless(ap *player.AudioPlayer, paths []string, out io.Writer) int {
+	files, err := expandPaths(paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "vgmtui: %v\n", err)
+		return 1
+	}
+	if len(files) == 0 {
+		fmt.Fprintln(os.Stderr, "vgmtui: no VGM files to play")
+		return 2
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	// Redraw the progress line in place only on a terminal
+	live := isTerminal(out)
+
+	failed := 0
+	for i, path := range files {
+		if err := ap.Load(path); err != nil {
+			fmt.Fprintf(os.Stderr, "vgmtui: %s: %v\n", path, err)
+			failed++
+			continue
+		}
+		if err := ap.Play(); err != nil {
+			fmt.Fprintf(os.Stderr, "vgmtui: %s: %v\n", path, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(out, "[%d/%d] %s\n", i+1, len(files), describeTrack(ap.Track(), path))
+
+		if interrupted := waitForTrack(ap, sigs, out, live); interrupted {
+			ap.Stop()
+			if live {
+				fmt.Fprintln(out)
+			}
+			return 130
+		}
+		if live {
+			fmt.Fprintln(out)
+		}
+	}
+
+	if failed == len(files) {
+		return 1
+	}
+	return 0
+}
+
+// waitForTrack blocks until the playing track stops, printing its
+// position when live is set. It reports whether a signal interrupted it.
+func waitForTrack(ap *player.AudioPlayer, sigs <-chan os.Signal, out io.Writer, live bool) bool {
+	ticker := time.NewTicker(headlessPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sigs:
+			return true
+		case <-ticker.C:
+			info := ap.Info()
+			if info.State == player.StateStopped {
+				return false
+			}
+			if live {
+				fmt.Fprintf(out, "\r  %s / %s", formatTime(info.Position), formatTime(info.Duration))
+			}
+		}
+	}
+}
+
+// expandPaths replaces each directory in paths with the VGM files found
+// beneath it, keeping the order in which the paths were given.
+func expandPaths(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		found, err := components.FindVGMFiles(path, false)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, found...)
+	}
+	return files, nil
+}
+
+// describeTrack returns "Title - Game (M:SS)", falling back to the file
+// name for untagged files.
+func describeTrack(t *metadata.Track, path string) string {
+	if t == nil {
+		return filepath.Base(path)
+	}
+	s := t.Title
+	if s == "" {
+		s = filepath.Base(path)
+	}
+	if t.Game != "" {
+		s += " - " + t.Game
+	}
+	if t.Duration > 0 {
+		s += " (" + formatTime(t.Duration) + ")"
+	}
+	return s
+}
+
+// formatTime formats a duration as M:SS.
+func formatTime(d time.Duration) string {
+	total := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
+
+// isTerminal reports whether w is a character device such as a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
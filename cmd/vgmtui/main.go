@@ -0,0 +1,148 @@
+// Command vgmtui is a terminal-based VGM (Video Game Music) player.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/config"
+	"github.com/dewi-tim/vgmtui/internal/library"
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+	"github.com/dewi-tim/vgmtui/internal/player"
+	"github.com/dewi-tim/vgmtui/internal/ui"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run parses flags, sets up the audio player and runs the TUI.
+// It returns the process exit code.
+func run() int {
+	themeName := flag.String("theme", "",
+		"color theme ("+strings.Join(ui.ThemeNames(), ", ")+")")
+	sessionFile := flag.String("session", "", "restore a session file exported with Ctrl+e")
+	ascii := flag.Bool("ascii", false, "draw the UI with ASCII characters only, for limited terminals")
+	noGUI := flag.Bool("nogui", false, "play the given files without the TUI and exit when done")
+	loops := flag.Int("loop", -1, "number of loops before fading out (with -nogui; 0 = forever)")
+	volume := flag.Float64("volume", 1.0, "playback volume, 1.0 = normal (with -nogui)")
+	speed := flag.Float64("speed", 1.0, "playback speed, 1.0 = normal (with -nogui)")
+	bufferMs := flag.Int("buffer-ms", 0, "length of one audio buffer in milliseconds (0 = config or default)")
+	bufferCount := flag.Int("buffers", 0, "number of audio buffers (0 = config or default)")
+	exportFile := flag.String("export-library", "",
+		"write the library (library_dir or ~/VGM, or the directory given) to a CSV or .json file and exit")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(),
+			"Usage: vgmtui [flags] [file or directory ...]\n\n"+
+				"Files and directories given are added to the playlist and played.\n"+
+				"With -nogui they are played in order without the TUI.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "vgmtui: %v\n", err)
+		return 1
+	}
+
+	// The file browser, library and headless player all use this list
+	library.SetExtensions(cfg.Extensions)
+	library.SetFollowSymlinks(cfg.FollowSymlinks)
+	metadata.SetPreferOriginal(cfg.OriginalTags)
+
+	// Command-line flags override the config file. They are kept out of
+	// cfg, which the UI saves back to the file, so they only apply to this run
+	theme, themeSet := ui.LookupTheme(*themeName)
+	if *themeName != "" && !themeSet {
+		fmt.Fprintf(os.Stderr, "vgmtui: unknown theme %q (available: %s)\n",
+			*themeName, strings.Join(ui.ThemeNames(), ", "))
+		return 2
+	}
+	buffers := ui.AudioBuffers(cfg)
+	if *bufferMs != 0 {
+		buffers.Time = time.Duration(*bufferMs) * time.Millisecond
+	}
+	if *bufferCount != 0 {
+		buffers.Count = *bufferCount
+	}
+	if err := buffers.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "vgmtui: %v\n", err)
+		return 2
+	}
+
+	if *exportFile != "" {
+		root := cfg.LibraryRoot()
+		if flag.NArg() > 0 {
+			root = flag.Arg(0)
+		}
+		return exportLibrary(root, *exportFile)
+	}
+
+	ap, audioErr := player.NewAudioPlayerWithBuffers(buffers)
+	if audioErr != nil {
+		if *noGUI {
+			fmt.Fprintf(os.Stderr, "vgmtui: %v\n", audioErr)
+			return 1
+		}
+		// Without audio the TUI still runs, display only, so the library
+		// can be browsed e.g. over SSH
+		ap = nil
+	} else {
+		defer ap.Close()
+		ap.SetFadeIn(time.Duration(cfg.FadeInMs) * time.Millisecond)
+		ap.SetPauseOnDeviceLoss(cfg.PauseOnDeviceLoss)
+	}
+
+	if *noGUI {
+		if flag.NArg() == 0 {
+			fmt.Fprintln(os.Stderr, "vgmtui: -nogui needs at least one file or directory")
+			return 2
+		}
+		if *loops >= 0 {
+			ap.SetLoopCount(*loops)
+		}
+		ap.SetVolume(*volume)
+		ap.SetSpeed(*speed)
+		return runHeadless(ap, flag.Args(), os.Stdout)
+	}
+
+	if ap != nil && cfg.Gapless {
+		if err := ap.SetGapless(true); err != nil {
+			fmt.Fprintf(os.Stderr, "vgmtui: gapless playback: %v\n", err)
+		}
+	}
+
+	m := ui.NewWithConfig(ap, cfg)
+	if themeSet {
+		m.SetTheme(theme)
+	}
+	if *ascii {
+		// Not saved to the config, so it only applies to this run
+		m.SetASCII(true)
+	}
+	if audioErr != nil {
+		m.SetAudioUnavailable(audioErr)
+	}
+	m.OpenPaths(flag.Args())
+	if *sessionFile != "" {
+		m.ImportSession(*sessionFile)
+	}
+	attach, cleanup := startMPRIS(&m)
+	defer cleanup()
+	stopScrobbling := startScrobbling(&m, cfg.LastFM)
+	defer stopScrobbling()
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	attach(p)
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "vgmtui: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,26 @@
+//go:build mpris
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/mpris"
+	"github.com/dewi-tim/vgmtui/internal/ui"
+)
+
+// startMPRIS registers vgmtui as an MPRIS2 player and wires it to the model.
+// The returned function attaches the running program and the cleanup
+// function releases the bus. Failing to reach the bus is not fatal.
+func startMPRIS(m *ui.Model) (attach func(*tea.Program), cleanup func()) {
+	srv, err := mpris.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "vgmtui: %v (media keys disabled)\n", err)
+		return func(*tea.Program) {}, func() {}
+	}
+	m.AddObserver(srv.Update)
+	return func(p *tea.Program) { srv.Attach(p.Send) }, func() { srv.Close() }
+}
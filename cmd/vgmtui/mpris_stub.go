@@ -0,0 +1,14 @@
+//go:build !mpris
+
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/ui"
+)
+
+// startMPRIS is a no-op in builds without the "mpris" tag.
+func startMPRIS(*ui.Model) (attach func(*tea.Program), cleanup func()) {
+	return func(*tea.Program) {}, func() {}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dewi-tim/vgmtui/internal/config"
+	"github.com/dewi-tim/vgmtui/internal/scrobble"
+	"github.com/dewi-tim/vgmtui/internal/ui"
+)
+
+// scrobbleStateFile holds scrobbles not yet delivered to Last.fm.
+const scrobbleStateFile = "scrobbles.json"
+
+// startScrobbling starts Last.fm scrobbling if configured and wires it to
+// the model. The returned function stops it and saves undelivered
+// scrobbles for the next run.
+func startScrobbling(m *ui.Model, cfg config.LastFM) (stop func()) {
+	if !cfg.Active() {
+		return func() {}
+	}
+
+	var pending []scrobble.Track
+	if err := config.LoadState(scrobbleStateFile, &pending); err != nil {
+		fmt.Fprintf(os.Stderr, "vgmtui: %s: %v\n", scrobbleStateFile, err)
+	}
+
+	s := scrobble.NewScrobbler(&scrobble.Client{
+		APIKey:     cfg.APIKey,
+		APISecret:  cfg.APISecret,
+		SessionKey: cfg.SessionKey,
+	}, pending)
+	tracker := scrobble.NewTracker(s)
+	m.AddObserver(func(np ui.NowPlaying) {
+		tracker.Update(scrobblePlayback(np))
+	})
+
+	return func() {
+		if err := config.SaveState(scrobbleStateFile, s.Close()); err != nil {
+			fmt.Fprintf(os.Stderr, "vgmtui: %s: %v\n", scrobbleStateFile, err)
+		}
+	}
+}
+
+// scrobblePlayback maps a playback snapshot to Last.fm terms: the
+// composer is the artist and the game is the album. Untagged tracks fall
+// back to the game as artist and the file name as title.
+func scrobblePlayback(np ui.NowPlaying) scrobble.Playback {
+	p := scrobble.Playback{
+		Playing:  np.State == ui.StatePlaying || np.State == ui.StateFading,
+		Position: np.Position,
+	}
+	t := np.Track
+	if t == nil {
+		return p
+	}
+	p.Path = t.Path
+	p.Track = scrobble.Track{
+		Artist:   t.Composer,
+		Album:    t.Game,
+		Title:    t.Title,
+		Duration: np.Duration,
+	}
+	if p.Track.Artist == "" {
+		p.Track.Artist = t.Game
+	}
+	if p.Track.Title == "" {
+		p.Track.Title = filepath.Base(t.Path)
+	}
+	return p
+}
@@ -0,0 +1,297 @@
+// Package config loads and saves the vgmtui user configuration.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Config holds user preferences read from the config file.
+// Zero values mean "use the built-in default".
+type Config struct {
+	// Theme is the name of the built-in color theme.
+	Theme string `json:"theme,omitempty"`
+
+	// RememberDirPrefs makes the file browser remember view preferences
+	// (such as showing hidden files) per directory.
+	RememberDirPrefs bool `json:"remember_dir_prefs,omitempty"`
+
+	// FadeInMs is how long each track fades in from silence, in
+	// milliseconds. Zero disables the fade-in.
+	FadeInMs int `json:"fade_in_ms,omitempty"`
+
+	// RemoveMovesUp moves the playlist cursor up to the previous track after
+	// removing one, instead of leaving it on the same row.
+	RemoveMovesUp bool `json:"remove_moves_up,omitempty"`
+
+	// FreshPlaylist starts each launch with an empty playlist instead of
+	// restoring the one from the last session.
+	FreshPlaylist bool `json:"fresh_playlist,omitempty"`
+
+	// PreviewMetadata shows the metadata of the file under the file
+	// browser cursor in the track info panel.
+	PreviewMetadata bool `json:"preview_metadata,omitempty"`
+
+	// TrackNotifications announces each new track: "desktop" for a desktop
+	// notification (via notify-send), "footer" for a banner in the footer,
+	// or empty for neither.
+	TrackNotifications string `json:"track_notifications,omitempty"`
+
+	// Gapless loads the next track on a second player while the current
+	// one plays, so auto-advance has no gap. It uses more memory.
+	Gapless bool `json:"gapless,omitempty"`
+
+	// PauseOnDeviceLoss pauses playback when the audio device goes away,
+	// instead of carrying on through the new default device once it has
+	// been reopened.
+	PauseOnDeviceLoss bool `json:"pause_on_device_loss,omitempty"`
+
+	// AudioBufferMs is the length of one audio output buffer in
+	// milliseconds, and AudioBufferCount how many are queued. Raising them
+	// stops stutter on slow systems at the cost of latency. Zero uses the
+	// default.
+	AudioBufferMs    int `json:"audio_buffer_ms,omitempty"`
+	AudioBufferCount int `json:"audio_buffer_count,omitempty"`
+
+	// SleepAction is what the sleep timer does when it runs out: "stop"
+	// playback (the default) or "quit".
+	SleepAction string `json:"sleep_action,omitempty"`
+
+	// LibraryWidthPercent is the share of the terminal width taken by the
+	// library pane. Zero uses the default of 30. Changed with < and >.
+	LibraryWidthPercent int `json:"library_width_percent,omitempty"`
+
+	// Extensions lists the file extensions shown in the file browser and
+	// indexed by the library, e.g. [".vgm", ".vgz"]. Empty uses the
+	// built-in list.
+	Extensions []string `json:"extensions,omitempty"`
+
+	// LibraryDir is the directory the library is read from. A leading ~
+	// stands for the home directory, and other relative paths start there.
+	// Empty uses ~/VGM.
+	LibraryDir string `json:"library_dir,omitempty"`
+
+	// BrowserStart is the directory the file browser starts in, read like
+	// LibraryDir. Empty uses the home directory.
+	BrowserStart string `json:"browser_start,omitempty"`
+
+	// FollowSymlinks lists symlinked directories in the file browser and
+	// indexes the files below them in the library. Changed with @ in the
+	// file browser.
+	FollowSymlinks bool `json:"follow_symlinks,omitempty"`
+
+	// OriginalTags shows titles, games, systems and composers in their
+	// original language (usually Japanese) rather than English, for files
+	// tagged in both. Changed from the command palette.
+	OriginalTags bool `json:"original_tags,omitempty"`
+
+	// WatchLibrary rescans the library automatically when files are
+	// added, removed or changed below the library directory.
+	WatchLibrary bool `json:"watch_library,omitempty"`
+
+	// SeekSeconds and FarSeekSeconds are how far the seek keys (f and b)
+	// and far seek keys (alt+f and alt+b) move playback, and
+	// VolumeStepPercent how much + and - change the volume. Zero uses the
+	// defaults of 5 and 30 seconds and 10%.
+	SeekSeconds       int `json:"seek_seconds,omitempty"`
+	FarSeekSeconds    int `json:"far_seek_seconds,omitempty"`
+	VolumeStepPercent int `json:"volume_step_percent,omitempty"`
+
+	// MinTrackSeconds makes auto-advance skip tracks shorter than this many
+	// seconds (such as short jingles). Zero plays every track.
+	MinTrackSeconds int `json:"min_track_seconds,omitempty"`
+
+	// SilentTrackMs is the duration in milliseconds below which a track is
+	// taken to be a broken rip: playing it warns, and stepping through the
+	// playlist always skips it. Zero uses the default of 500; a negative
+	// value turns the check off.
+	SilentTrackMs int `json:"silent_track_ms,omitempty"`
+
+	// LastFM configures scrobbling to Last.fm.
+	LastFM LastFM `json:"lastfm,omitzero"`
+
+	// PlaylistColumns lists the columns of the playlist table, in order:
+	// "duration", "title", "game", "composer" and "system". Empty shows
+	// duration, title and game.
+	PlaylistColumns []string `json:"playlist_columns,omitempty"`
+
+	// ProgressStyle is how the progress and volume bars are drawn:
+	// "blocks" (the default), "ascii" for "=" and "-", or "gradient" for
+	// blocks shading across the theme's colors.
+	ProgressStyle string `json:"progress_style,omitempty"`
+
+	// ASCII draws the UI with ASCII characters only: plain borders, "="
+	// and "-" bars and no braille or block indicators, for terminals and
+	// fonts without them.
+	ASCII bool `json:"ascii,omitempty"`
+
+	// LibrarySort is the order of tracks within each game in the library:
+	// "number" (the default), "title", "duration" or "path".
+	LibrarySort string `json:"library_sort,omitempty"`
+
+	// SmartPlaylists are saved library queries shown in the library browser.
+	SmartPlaylists []SmartPlaylist `json:"smart_playlists,omitempty"`
+
+	// Keys rebinds actions, mapping an action name (such as "play_pause"
+	// or "playlist.remove") to the keys that trigger it. Actions not
+	// listed keep their default keys. Changed with the key bindings popup.
+	Keys map[string][]string `json:"keys,omitempty"`
+}
+
+// SmartPlaylist is a named set of criteria matched against the library.
+// Empty criteria match everything; text matches ignore case.
+type SmartPlaylist struct {
+	Name       string `json:"name"`
+	System     string `json:"system,omitempty"`      // Exact system name
+	Game       string `json:"game,omitempty"`        // Game name contains
+	Composer   string `json:"composer,omitempty"`    // Composer contains
+	Title      string `json:"title,omitempty"`       // Title contains
+	MinSeconds int    `json:"min_seconds,omitempty"` // Minimum duration
+	MaxSeconds int    `json:"max_seconds,omitempty"` // Maximum duration
+}
+
+// LastFM holds Last.fm scrobbling settings. Scrobbling runs only when
+// enabled and all three credentials are set.
+type LastFM struct {
+	Enabled    bool   `json:"enabled,omitempty"`
+	APIKey     string `json:"api_key,omitempty"`
+	APISecret  string `json:"api_secret,omitempty"`
+	SessionKey string `json:"session_key,omitempty"`
+}
+
+// Active reports whether scrobbling is enabled and configured.
+func (l LastFM) Active() bool {
+	return l.Enabled && l.APIKey != "" && l.APISecret != "" && l.SessionKey != ""
+}
+
+// Default returns the default configuration.
+func Default() Config {
+	return Config{
+		Theme: "default",
+	}
+}
+
+// Dir returns the vgmtui config directory (e.g. ~/.config/vgmtui).
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "vgmtui"), nil
+}
+
+// Path returns the path of the config file.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// Load reads the config file, filling unset values with defaults.
+// A missing config file is not an error.
+func Load() (Config, error) {
+	cfg := Default()
+
+	path, err := Path()
+	if err != nil {
+		return cfg, nil // No config dir - use defaults
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Default(), fmt.Errorf("config: %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save writes the config to the config file, creating its directory.
+func (c Config) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// StateDir returns the directory for persisted runtime state such as
+// remembered browser preferences (e.g. ~/.local/state/vgmtui).
+func StateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "vgmtui"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "vgmtui"), nil
+}
+
+// LoadState decodes the named JSON file in the state directory into v.
+// A missing file is not an error and leaves v untouched.
+func LoadState(name string, v any) error {
+	dir, err := StateDir()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// SaveState encodes v as JSON into the named file in the state directory.
+// The file is replaced atomically so a crash never leaves it truncated.
+func SaveState(name string, v any) error {
+	dir, err := StateDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, name))
+}
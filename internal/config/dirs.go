@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultLibraryDir is the library directory below the home directory
+// used unless library_dir is set.
+const defaultLibraryDir = "VGM"
+
+// LibraryRoot returns the directory the library is read from: LibraryDir
+// if set, otherwise ~/VGM. It returns "" if the home directory is needed
+// but unknown.
+func (c Config) LibraryRoot() string {
+	dir := c.LibraryDir
+	if dir == "" {
+		dir = defaultLibraryDir
+	}
+	return homePath(dir)
+}
+
+// BrowserStartDir returns the directory the file browser starts in:
+// BrowserStart if set, otherwise "" for the home directory.
+func (c Config) BrowserStartDir() string {
+	if c.BrowserStart == "" {
+		return ""
+	}
+	return homePath(c.BrowserStart)
+}
+
+// homePath returns path made absolute: a leading "~" stands for the home
+// directory, and other relative paths are taken from it. It returns "" if
+// the home directory is needed but unknown.
+func homePath(path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return ""
+	}
+	if path == "~" {
+		return home
+	}
+	if rest, ok := strings.CutPrefix(path, "~"+string(filepath.Separator)); ok {
+		path = rest
+	} else if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		path = rest
+	}
+	return filepath.Join(home, path)
+}
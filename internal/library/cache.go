@@ -0,0 +1,67 @@
+package library
+
+import (
+	"sort"
+	"time"
+)
+
+// Cache is a persistable snapshot of a library scan.
+type Cache struct {
+	Root      string    `json:"root"`
+	ScannedAt time.Time `json:"scanned_at"`
+	Tracks    []Track   `json:"tracks"`
+}
+
+// Cache returns a snapshot of the current scan.
+func (l *Library) Cache() Cache {
+	return Cache{
+		Root:      l.Root(),
+		ScannedAt: time.Now(),
+		Tracks:    l.AllTracks(),
+	}
+}
+
+// Diff describes how a library changed between two scans.
+// Each list is sorted by path.
+type Diff struct {
+	Added    []Track
+	Removed  []Track
+	Modified []Track // Tracks whose file size or modification time changed
+}
+
+// Empty returns true if nothing changed.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// DiffTracks compares an older and a newer set of tracks by path.
+// Modified entries are taken from the newer set.
+func DiffTracks(older, newer []Track) Diff {
+	before := make(map[string]Track, len(older))
+	for _, t := range older {
+		before[t.Path] = t
+	}
+
+	var d Diff
+	for _, t := range newer {
+		old, ok := before[t.Path]
+		if !ok {
+			d.Added = append(d.Added, t)
+			continue
+		}
+		delete(before, t.Path)
+		if old.Size != t.Size || !old.ModTime.Equal(t.ModTime) {
+			d.Modified = append(d.Modified, t)
+		}
+	}
+	for _, t := range before {
+		d.Removed = append(d.Removed, t)
+	}
+
+	for _, tracks := range [][]Track{d.Added, d.Removed, d.Modified} {
+		sort.Slice(tracks, func(i, j int) bool {
+			return tracks[i].Path < tracks[j].Path
+		})
+	}
+	return d
+}
@@ -0,0 +1,100 @@
+package library
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDiffTracks(t *testing.T) {
+	scanned := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	track := func(path string, size int64, mod time.Time) Track {
+		return Track{Path: path, Title: path, Size: size, ModTime: mod}
+	}
+	paths := func(tracks []Track) []string {
+		var p []string
+		for _, t := range tracks {
+			p = append(p, t.Path)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name                     string
+		older, newer             []Track
+		added, removed, modified []string
+	}{
+		{name: "both empty"},
+		{
+			name:  "unchanged",
+			older: []Track{track("a.vgm", 10, scanned), track("b.vgm", 20, scanned)},
+			newer: []Track{track("b.vgm", 20, scanned), track("a.vgm", 10, scanned)},
+		},
+		{
+			name:  "first scan",
+			newer: []Track{track("c.vgm", 1, scanned), track("a.vgm", 1, scanned)},
+			added: []string{"a.vgm", "c.vgm"},
+		},
+		{
+			name:    "all removed",
+			older:   []Track{track("b.vgm", 1, scanned), track("a.vgm", 1, scanned)},
+			removed: []string{"a.vgm", "b.vgm"},
+		},
+		{
+			name:     "size changed",
+			older:    []Track{track("a.vgm", 10, scanned)},
+			newer:    []Track{track("a.vgm", 11, scanned)},
+			modified: []string{"a.vgm"},
+		},
+		{
+			name:     "modification time changed",
+			older:    []Track{track("a.vgm", 10, scanned)},
+			newer:    []Track{track("a.vgm", 10, scanned.Add(time.Second))},
+			modified: []string{"a.vgm"},
+		},
+		{
+			// The same instant read back from a cache in another zone
+			name:  "same time in another location",
+			older: []Track{track("a.vgm", 10, scanned.In(time.FixedZone("JST", 9*60*60)))},
+			newer: []Track{track("a.vgm", 10, scanned)},
+		},
+		{
+			name: "mixed",
+			older: []Track{
+				track("keep.vgm", 1, scanned), track("gone.vgm", 1, scanned),
+				track("edit.vgz", 1, scanned), track("also gone.vgm", 1, scanned),
+			},
+			newer: []Track{
+				track("new.vgm", 1, scanned), track("edit.vgz", 2, scanned),
+				track("keep.vgm", 1, scanned), track("another.vgm", 1, scanned),
+			},
+			added:    []string{"another.vgm", "new.vgm"},
+			removed:  []string{"also gone.vgm", "gone.vgm"},
+			modified: []string{"edit.vgz"},
+		},
+	}
+	for _, tt := range tests {
+		d := DiffTracks(tt.older, tt.newer)
+		if got := paths(d.Added); !slices.Equal(got, tt.added) {
+			t.Errorf("%s: added %q, want %q", tt.name, got, tt.added)
+		}
+		if got := paths(d.Removed); !slices.Equal(got, tt.removed) {
+			t.Errorf("%s: removed %q, want %q", tt.name, got, tt.removed)
+		}
+		if got := paths(d.Modified); !slices.Equal(got, tt.modified) {
+			t.Errorf("%s: modified %q, want %q", tt.name, got, tt.modified)
+		}
+		if empty := tt.added == nil && tt.removed == nil && tt.modified == nil; d.Empty() != empty {
+			t.Errorf("%s: Empty() = %v, want %v", tt.name, d.Empty(), empty)
+		}
+	}
+}
+
+func TestDiffTracksModifiedFromNewer(t *testing.T) {
+	older := []Track{{Path: "a.vgm", Title: "Old Title", Size: 1}}
+	newer := []Track{{Path: "a.vgm", Title: "New Title", Size: 2}}
+	d := DiffTracks(older, newer)
+	if len(d.Modified) != 1 || d.Modified[0].Title != "New Title" || d.Modified[0].Size != 2 {
+		t.Errorf("modified = %+v, want the newer track", d.Modified)
+	}
+}
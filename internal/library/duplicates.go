@@ -0,0 +1,135 @@
+package library
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DuplicateMode selects how FindDuplicates decides tracks are the same.
+type DuplicateMode int
+
+const (
+	DuplicatesByMetadata DuplicateMode = iota // Same title, game and duration
+	DuplicatesByContent                       // Byte-identical files
+)
+
+// String returns a short description of the mode.
+func (m DuplicateMode) String() string {
+	if m == DuplicatesByContent {
+		return "content"
+	}
+	return "metadata"
+}
+
+// Next returns the other mode.
+func (m DuplicateMode) Next() DuplicateMode {
+	if m == DuplicatesByContent {
+		return DuplicatesByMetadata
+	}
+	return DuplicatesByContent
+}
+
+// DuplicateGroup is a set of tracks considered the same, sorted by path.
+type DuplicateGroup struct {
+	Key    string // What the tracks share: "title - game (m:ss)" or a hash
+	Tracks []Track
+}
+
+// FindDuplicates returns the groups of two or more tracks that are
+// duplicates of each other, sorted by the path of their first track.
+//
+// By metadata, tracks match if their titles and games are equal ignoring
+// case and their durations agree to the second. By content, only files of
+// equal size are read, and those with equal SHA-256 hashes match.
+func (l *Library) FindDuplicates(mode DuplicateMode) ([]DuplicateGroup, error) {
+	tracks := l.AllTracks()
+
+	var buckets map[string][]Track
+	if mode == DuplicatesByContent {
+		var err error
+		if buckets, err = contentBuckets(tracks); err != nil {
+			return nil, err
+		}
+	} else {
+		buckets = metadataBuckets(tracks)
+	}
+
+	var groups []DuplicateGroup
+	for key, ts := range buckets {
+		if len(ts) < 2 {
+			continue
+		}
+		sort.Slice(ts, func(i, j int) bool { return ts[i].Path < ts[j].Path })
+		groups = append(groups, DuplicateGroup{Key: key, Tracks: ts})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Tracks[0].Path < groups[j].Tracks[0].Path
+	})
+	return groups, nil
+}
+
+// metadataBuckets groups tracks by normalized title, game and duration.
+// Tracks without a title are never grouped.
+func metadataBuckets(tracks []Track) map[string][]Track {
+	buckets := make(map[string][]Track)
+	keys := make(map[string]string) // normalized key -> display key
+	for _, t := range tracks {
+		if strings.TrimSpace(t.Title) == "" {
+			continue
+		}
+		secs := int(t.Duration.Round(time.Second).Seconds())
+		norm := fmt.Sprintf("%s\x00%s\x00%d",
+			strings.ToLower(strings.TrimSpace(t.Title)),
+			strings.ToLower(strings.TrimSpace(t.Game)), secs)
+		if _, ok := keys[norm]; !ok {
+			keys[norm] = fmt.Sprintf("%s - %s (%d:%02d)", t.Title, t.Game, secs/60, secs%60)
+		}
+		buckets[keys[norm]] = append(buckets[keys[norm]], t)
+	}
+	return buckets
+}
+
+// contentBuckets groups tracks by the SHA-256 hash of their files, only
+// hashing files whose size is shared with another track.
+func contentBuckets(tracks []Track) (map[string][]Track, error) {
+	bySize := make(map[int64][]Track)
+	for _, t := range tracks {
+		bySize[t.Size] = append(bySize[t.Size], t)
+	}
+
+	buckets := make(map[string][]Track)
+	for _, ts := range bySize {
+		if len(ts) < 2 {
+			continue
+		}
+		for _, t := range ts {
+			sum, err := hashFile(t.Path)
+			if err != nil {
+				return nil, err
+			}
+			buckets[sum] = append(buckets[sum], t)
+		}
+	}
+	return buckets, nil
+}
+
+// hashFile returns the hex SHA-256 hash of a file's contents.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
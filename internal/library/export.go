@@ -0,0 +1,79 @@
+package library
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// exportHeader is the CSV header row written by ExportCSV.
+var exportHeader = []string{
+	"path", "system", "game", "title", "composer",
+	"duration_seconds", "track_number", "chips",
+}
+
+// exportTrack is the JSON form of a track written by ExportJSON.
+type exportTrack struct {
+	Path        string   `json:"path"`
+	System      string   `json:"system"`
+	Game        string   `json:"game"`
+	Title       string   `json:"title"`
+	Composer    string   `json:"composer"`
+	Duration    float64  `json:"duration_seconds"`
+	TrackNumber int      `json:"track_number"`
+	Chips       []string `json:"chips"`
+}
+
+// ExportCSV writes every track in the library as CSV, one row per track
+// after a header row. Chips are joined with "; ".
+func (l *Library) ExportCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportHeader); err != nil {
+		return err
+	}
+	for _, t := range l.AllTracks() {
+		row := []string{
+			t.Path,
+			t.System,
+			t.Game,
+			t.Title,
+			t.Composer,
+			strconv.FormatFloat(t.Duration.Seconds(), 'f', 2, 64),
+			strconv.Itoa(t.TrackNumber),
+			strings.Join(t.Chips, "; "),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportJSON writes every track in the library as an indented JSON array
+// of objects with the same fields as ExportCSV.
+func (l *Library) ExportJSON(w io.Writer) error {
+	tracks := l.AllTracks()
+	out := make([]exportTrack, len(tracks))
+	for i, t := range tracks {
+		chips := t.Chips
+		if chips == nil {
+			chips = []string{}
+		}
+		out[i] = exportTrack{
+			Path:        t.Path,
+			System:      t.System,
+			Game:        t.Game,
+			Title:       t.Title,
+			Composer:    t.Composer,
+			Duration:    t.Duration.Seconds(),
+			TrackNumber: t.TrackNumber,
+			Chips:       chips,
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
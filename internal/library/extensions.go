@@ -0,0 +1,105 @@
+package library
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// defaultExtensions are the file extensions recognized unless configured
+// otherwise. libvgm reads files through zlib, so gzipped files of any
+// format load as they are.
+var defaultExtensions = []string{
+	".vgm.gz", ".s98.gz", ".dro.gz", ".gym.gz",
+	".vgm", ".vgz", ".s98", ".dro", ".gym",
+}
+
+// Recognized file extensions, shared by the library scan and the file
+// browser. Longer (compound) extensions come first so TrimVGMExt strips
+// them whole.
+var (
+	extMu      sync.RWMutex
+	extensions = defaultExtensions
+)
+
+// DefaultExtensions returns the file extensions recognized by default.
+func DefaultExtensions() []string {
+	return slices.Clone(defaultExtensions)
+}
+
+// SetExtensions sets the recognized file extensions. Extensions are
+// matched ignoring case, and a missing leading dot is added. An empty
+// list restores the defaults.
+func SetExtensions(exts []string) {
+	var clean []string
+	for _, ext := range exts {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" || ext == "." {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		if !slices.Contains(clean, ext) {
+			clean = append(clean, ext)
+		}
+	}
+	if len(clean) == 0 {
+		clean = defaultExtensions
+	}
+	slices.SortStableFunc(clean, func(a, b string) int {
+		return len(b) - len(a)
+	})
+
+	extMu.Lock()
+	defer extMu.Unlock()
+	extensions = clean
+}
+
+// Extensions returns the recognized file extensions.
+func Extensions() []string {
+	extMu.RLock()
+	defer extMu.RUnlock()
+	return slices.Clone(extensions)
+}
+
+// IsVGMFile checks if a filename has a recognized extension.
+func IsVGMFile(name string) bool {
+	return vgmExt(name) != ""
+}
+
+// TrimVGMExt returns a filename without its recognized extension,
+// including compound extensions such as ".vgm.gz".
+func TrimVGMExt(name string) string {
+	return name[:len(name)-len(vgmExt(name))]
+}
+
+// isSidecar returns true if name is the sidecar of a VGM file, whose tags
+// override the file's (see metadata.Sidecar).
+func isSidecar(name string) bool {
+	base, ok := strings.CutSuffix(name, metadata.SidecarExt)
+	return ok && IsVGMFile(base)
+}
+
+// hasSidecar returns true if the file at path has a sidecar.
+func hasSidecar(path string) bool {
+	_, err := os.Stat(metadata.SidecarPath(path))
+	return err == nil
+}
+
+// vgmExt returns the recognized extension name ends in, or "" if none.
+func vgmExt(name string) string {
+	lower := strings.ToLower(name)
+
+	extMu.RLock()
+	defer extMu.RUnlock()
+	for _, ext := range extensions {
+		if strings.HasSuffix(lower, ext) {
+			return ext
+		}
+	}
+	return ""
+}
@@ -0,0 +1,215 @@
+package library
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"unicode/utf16"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// ErrTagsUnsupported is returned when reading or writing the tags of a
+// file that isn't a VGM file, plain or gzipped.
+var ErrTagsUnsupported = errors.New("tags can only be edited in VGM and VGZ files")
+
+// VGM header and GD3 tag layout. Offsets in the header are relative to
+// where they are stored.
+const (
+	vgmEOFOffset = 0x04
+	vgmGD3Offset = 0x14
+	vgmMinHeader = 0x40
+
+	gd3Header  = 12 // "Gd3 ", version, length of the strings in bytes
+	gd3Version = 0x100
+
+	// A GD3 tag has eleven strings: title, game, system and author, each
+	// in English then Japanese, then the date, ripper and notes
+	gd3Fields = 11
+)
+
+// ReadTags returns the title, game, system and composer stored in the GD3
+// tag of the VGM or VGZ file at path, in the original-language fields if
+// original is set and the English ones otherwise. Unlike the tags read
+// for playback, a field empty in that language stays empty.
+func ReadTags(path string, original bool) (metadata.Tags, error) {
+	data, _, err := readVGM(path)
+	if err != nil {
+		return metadata.Tags{}, err
+	}
+	fields, _, _ := findGD3(data)
+	lang := gd3Lang(original)
+	return metadata.Tags{
+		Title:    fields[0+lang],
+		Game:     fields[2+lang],
+		System:   fields[4+lang],
+		Composer: fields[6+lang],
+	}, nil
+}
+
+// WriteTags stores tags in the GD3 tag of the VGM or VGZ file at path, in
+// the original-language fields if original is set and the English ones
+// otherwise. The other fields are kept. The file is replaced atomically,
+// so a failed write leaves it as it was.
+func WriteTags(path string, tags metadata.Tags, original bool) error {
+	data, gzipped, err := readVGM(path)
+	if err != nil {
+		return err
+	}
+
+	fields, start, found := findGD3(data)
+	end := len(data)
+	if found && start+gd3Size(data[start:]) == len(data) {
+		// Replace the tag at the end of the file; one elsewhere is left
+		// behind and no longer pointed to
+		end = start
+	}
+	lang := gd3Lang(original)
+	fields[0+lang] = tags.Title
+	fields[2+lang] = tags.Game
+	fields[4+lang] = tags.System
+	fields[6+lang] = tags.Composer
+
+	out := append(data[:end:end], encodeGD3(fields)...)
+	binary.LittleEndian.PutUint32(out[vgmGD3Offset:], uint32(end-vgmGD3Offset))
+	binary.LittleEndian.PutUint32(out[vgmEOFOffset:], uint32(len(out)-vgmEOFOffset))
+
+	if gzipped {
+		var buf bytes.Buffer
+		zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if _, err := zw.Write(out); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		out = buf.Bytes()
+	}
+	return replaceFile(path, out)
+}
+
+// readVGM reads the VGM file at path, decompressing it if gzipped.
+func readVGM(path string) (data []byte, gzipped bool, err error) {
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gzipped = true
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", path, err)
+		}
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, false, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if len(data) < vgmMinHeader || string(data[:4]) != "Vgm " {
+		return nil, false, fmt.Errorf("%s: %w", filepath.Base(path), ErrTagsUnsupported)
+	}
+	return data, gzipped, nil
+}
+
+// gd3Lang returns the offset of a language's field from the English one.
+func gd3Lang(original bool) int {
+	if original {
+		return 1
+	}
+	return 0
+}
+
+// findGD3 returns the fields of the GD3 tag the VGM header points to and
+// where the tag starts. A missing or malformed tag gives empty fields.
+func findGD3(data []byte) (fields []string, start int, found bool) {
+	fields = make([]string, gd3Fields)
+	off := binary.LittleEndian.Uint32(data[vgmGD3Offset:])
+	start = vgmGD3Offset + int(off)
+	if off == 0 || start >= len(data) {
+		return fields, 0, false
+	}
+	tag := data[start:]
+	size := gd3Size(tag)
+	if size == 0 {
+		return fields, 0, false
+	}
+
+	units := make([]uint16, 0, (size-gd3Header)/2)
+	for i := gd3Header; i+1 < size; i += 2 {
+		units = append(units, binary.LittleEndian.Uint16(tag[i:]))
+	}
+	for i := 0; i < gd3Fields && len(units) > 0; i++ {
+		n := 0
+		for n < len(units) && units[n] != 0 {
+			n++
+		}
+		fields[i] = string(utf16.Decode(units[:n]))
+		units = units[min(n+1, len(units)):]
+	}
+	return fields, start, true
+}
+
+// gd3Size returns the size of the GD3 tag at the start of tag, or 0 if
+// there isn't a whole one.
+func gd3Size(tag []byte) int {
+	if len(tag) < gd3Header || string(tag[:4]) != "Gd3 " {
+		return 0
+	}
+	size := gd3Header + int(binary.LittleEndian.Uint32(tag[8:]))
+	if size > len(tag) {
+		return 0
+	}
+	return size
+}
+
+// encodeGD3 returns a GD3 tag holding fields.
+func encodeGD3(fields []string) []byte {
+	var text []byte
+	for _, f := range fields {
+		for _, u := range utf16.Encode([]rune(f)) {
+			text = binary.LittleEndian.AppendUint16(text, u)
+		}
+		text = binary.LittleEndian.AppendUint16(text, 0)
+	}
+	tag := []byte("Gd3 ")
+	tag = binary.LittleEndian.AppendUint32(tag, gd3Version)
+	tag = binary.LittleEndian.AppendUint32(tag, uint32(len(text)))
+	return append(tag, text...)
+}
+
+// replaceFile atomically replaces the file at path with data, keeping its
+// permissions. A symlink is followed, so the file it points to is replaced
+// and the link kept.
+func replaceFile(path string, data []byte) error {
+	path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
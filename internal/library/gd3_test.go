@@ -0,0 +1,204 @@
+package library
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// testFields is a GD3 tag with every field set, English then Japanese.
+var testFields = []string{
+	"Green Hill Zone", "グリーンヒルゾーン",
+	"Sonic the Hedgehog", "ソニック・ザ・ヘッジホッグ",
+	"Sega Mega Drive", "セガメガドライブ",
+	"Masato Nakamura", "中村正人",
+	"1991/06/23", "ripper", "Notes\nover two lines",
+}
+
+// vgmData returns a minimal VGM file: a header, some commands and, unless
+// fields is nil, a GD3 tag holding them followed by trailing bytes.
+func vgmData(fields []string, trailing []byte) []byte {
+	data := make([]byte, vgmMinHeader)
+	copy(data, "Vgm ")
+	data = append(data, 0x50, 0x9f, 0x62, 0x01, 0x66) // Some commands
+	if fields != nil {
+		binary.LittleEndian.PutUint32(data[vgmGD3Offset:], uint32(len(data)-vgmGD3Offset))
+		data = append(data, encodeGD3(fields)...)
+	}
+	data = append(data, trailing...)
+	binary.LittleEndian.PutUint32(data[vgmEOFOffset:], uint32(len(data)-vgmEOFOffset))
+	return data
+}
+
+// writeVGM writes data to name below dir, gzipped if asked, and returns its
+// path.
+func writeVGM(t *testing.T, dir, name string, data []byte, gzipped bool) string {
+	t.Helper()
+	if gzipped {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write(data)
+		zw.Close()
+		data = buf.Bytes()
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// readWritten reads back a file written by WriteTags, checking that it is
+// still gzipped if it was and that the header offsets point to the end of
+// the file and to a tag ending there.
+func readWritten(t *testing.T, path string, gzipped bool) []byte {
+	t.Helper()
+	data, wasGzipped, err := readVGM(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wasGzipped != gzipped {
+		t.Errorf("%s: gzipped %v after writing, want %v", filepath.Base(path), wasGzipped, gzipped)
+	}
+	if eof := vgmEOFOffset + int(binary.LittleEndian.Uint32(data[vgmEOFOffset:])); eof != len(data) {
+		t.Errorf("%s: EOF offset points to %#x, want the end of the file at %#x", filepath.Base(path), eof, len(data))
+	}
+	_, start, found := findGD3(data)
+	if !found || start+gd3Size(data[start:]) != len(data) {
+		t.Errorf("%s: GD3 offset points to %#x, not a tag ending the file", filepath.Base(path), start)
+	}
+	return data
+}
+
+func TestWriteTagsRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	tags := metadata.Tags{Title: "Marble Zone", Game: "Sonic 1", System: "Genesis", Composer: "Nakamura"}
+	for _, gzipped := range []bool{false, true} {
+		for _, original := range []bool{false, true} {
+			name := "track.vgm"
+			if gzipped {
+				name = "track.vgz"
+			}
+			path := writeVGM(t, dir, name, vgmData(testFields, nil), gzipped)
+
+			if err := WriteTags(path, tags, original); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if got, err := ReadTags(path, original); err != nil || got != tags {
+				t.Errorf("%s: read back %+v, %v; want %+v", name, got, err, tags)
+			}
+
+			// The other language and the date, ripper and notes are kept
+			want := slices.Clone(testFields)
+			lang := gd3Lang(original)
+			want[0+lang], want[2+lang], want[4+lang], want[6+lang] = tags.Title, tags.Game, tags.System, tags.Composer
+			data := readWritten(t, path, gzipped)
+			if fields, _, _ := findGD3(data); !slices.Equal(fields, want) {
+				t.Errorf("%s: fields after writing (original %v):\n%q\nwant\n%q", name, original, fields, want)
+			}
+			if commands := vgmData(nil, nil)[vgmMinHeader:]; !bytes.Equal(data[vgmMinHeader:vgmMinHeader+len(commands)], commands) {
+				t.Errorf("%s: commands changed by writing the tags", name)
+			}
+		}
+	}
+}
+
+func TestWriteTagsWithoutTag(t *testing.T) {
+	original := vgmData(nil, nil)
+	path := writeVGM(t, t.TempDir(), "untagged.vgm", original, false)
+	if got, err := ReadTags(path, false); err != nil || got != (metadata.Tags{}) {
+		t.Fatalf("tags of an untagged file = %+v, %v; want none", got, err)
+	}
+
+	tags := metadata.Tags{Title: "Title", Composer: "Composer"}
+	if err := WriteTags(path, tags, false); err != nil {
+		t.Fatal(err)
+	}
+	data := readWritten(t, path, false)
+	if got, err := ReadTags(path, false); err != nil || got != tags {
+		t.Errorf("read back %+v, %v; want %+v", got, err, tags)
+	}
+	// The tag is added after the commands
+	if _, start, _ := findGD3(data); start != len(original) || !bytes.Equal(data[vgmGD3Offset+4:start], original[vgmGD3Offset+4:]) {
+		t.Errorf("tag added at %#x, want %#x after the unchanged file", start, len(original))
+	}
+}
+
+func TestWriteTagsNotAtEnd(t *testing.T) {
+	// A tag followed by other data is left in place, so that data stays
+	trailing := []byte("trailing data")
+	original := vgmData(testFields, trailing)
+	path := writeVGM(t, t.TempDir(), "track.vgm", original, false)
+
+	tags := metadata.Tags{Title: "New Title"}
+	if err := WriteTags(path, tags, false); err != nil {
+		t.Fatal(err)
+	}
+	data := readWritten(t, path, false)
+	_, start, _ := findGD3(data)
+	if start != len(original) || !bytes.Equal(data[vgmGD3Offset+4:start], original[vgmGD3Offset+4:]) {
+		t.Errorf("tag written at %#x, want %#x after the unchanged file", start, len(original))
+	}
+	if !bytes.HasSuffix(data[:start], trailing) {
+		t.Error("data after the old tag was lost")
+	}
+	if got, err := ReadTags(path, false); err != nil || got != tags {
+		t.Errorf("read back %+v, %v; want %+v", got, err, tags)
+	}
+	if got, _ := ReadTags(path, true); got.Title != testFields[1] {
+		t.Errorf("original-language title = %q, want %q kept", got.Title, testFields[1])
+	}
+}
+
+func TestWriteTagsSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := writeVGM(t, dir, "target.vgm", vgmData(testFields, nil), false)
+	link := filepath.Join(dir, "link.vgm")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skip("symlinks not supported:", err)
+	}
+
+	tags := metadata.Tags{Title: "Through the Link"}
+	if err := WriteTags(link, tags, false); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("link replaced by a regular file: %v, %v", info, err)
+	}
+	if got, err := ReadTags(target, false); err != nil || got != tags {
+		t.Errorf("target read back %+v, %v; want %+v", got, err, tags)
+	}
+	if info, err := os.Stat(target); err != nil || info.Mode().Perm() != 0o640 {
+		t.Errorf("target mode after writing: %v, %v; want 0640 kept", info, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("%d files in the directory after writing, want the link and target only", len(entries))
+	}
+}
+
+func TestTagsUnsupported(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{
+		"short.vgm": []byte("Vgm "),
+		"s98.s98":   append([]byte("S98"), make([]byte, vgmMinHeader)...),
+		"empty.vgz": nil,
+	} {
+		path := writeVGM(t, dir, name, data, false)
+		if _, err := ReadTags(path, false); !errors.Is(err, ErrTagsUnsupported) {
+			t.Errorf("ReadTags(%s) error = %v, want ErrTagsUnsupported", name, err)
+		}
+		if err := WriteTags(path, metadata.Tags{Title: "x"}, false); !errors.Is(err, ErrTagsUnsupported) {
+			t.Errorf("WriteTags(%s) error = %v, want ErrTagsUnsupported", name, err)
+		}
+		if got, _ := os.ReadFile(path); !bytes.Equal(got, data) {
+			t.Errorf("%s changed by a failed write", name)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package library
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ignoreFile is the name of the files listing what the library scan skips,
+// one gitignore-style pattern per line, relative to the file's directory.
+const ignoreFile = ".vgmignore"
+
+// ignoreRule is a pattern from an ignore file.
+type ignoreRule struct {
+	base     string // Directory of the ignore file
+	pattern  string // filepath.Match pattern, with OS separators
+	anchored bool   // Matched against the path below base, not just the name
+	dirOnly  bool   // Only matches directories (the line ended in "/")
+	negate   bool   // Un-ignores what earlier rules ignored (the line began with "!")
+}
+
+// readIgnoreFile returns the rules of the ignore file in dir, or none if
+// there is no such file. Blank lines and lines starting with "#" are
+// skipped.
+func readIgnoreFile(dir string) []ignoreRule {
+	f, err := os.Open(filepath.Join(dir, ignoreFile))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{base: dir}
+		if rest, ok := strings.CutPrefix(line, "!"); ok {
+			rule.negate = true
+			line = rest
+		}
+		if rest, ok := strings.CutSuffix(line, "/"); ok {
+			rule.dirOnly = true
+			line = rest
+		}
+		// A slash anywhere else ties the pattern to the ignore file's directory
+		rule.anchored = strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		if line == "" {
+			continue
+		}
+		rule.pattern = filepath.FromSlash(line)
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// matches reports whether the rule applies to path.
+func (r ignoreRule) matches(path string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	name := filepath.Base(path)
+	if r.anchored {
+		rel, err := filepath.Rel(r.base, path)
+		if err != nil {
+			return false
+		}
+		name = rel
+	}
+	ok, _ := filepath.Match(r.pattern, name)
+	return ok
+}
+
+// ignored reports whether the rules ignore path. As in gitignore, the last
+// rule matching it decides.
+func ignored(rules []ignoreRule, path string, isDir bool) bool {
+	ignore := false
+	for _, r := range rules {
+		if r.matches(path, isDir) {
+			ignore = !r.negate
+		}
+	}
+	return ignore
+}
@@ -6,18 +6,16 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/dewi-tim/vgmtui/internal/player"
+	"github.com/dewi-tim/vgmtui/internal/metadata"
 )
 
-// VGM-compatible file extensions.
-var vgmExtensions = []string{".vgm", ".vgz", ".s98", ".dro", ".gym"}
-
 // trackNumberPatterns matches common track number formats in filenames.
 var trackNumberPatterns = []*regexp.Regexp{
 	regexp.MustCompile(`^(\d{1,3})\s*[-._)\]]\s*`),     // "01 - Title", "01_Title", "01.Title", "01) Title"
@@ -28,15 +26,162 @@ var trackNumberPatterns = []*regexp.Regexp{
 	regexp.MustCompile(`^(\d{1,3})\s`),                  // "01 Title" (number followed by space)
 }
 
+// discTrackPattern matches a disc and track number at the start of a
+// filename, e.g. "1-05 Title" or "2-11.Title".
+var discTrackPattern = regexp.MustCompile(`^(\d{1,2})-(\d{2,3})(?:[\s._)\]-]|$)`)
+
+// discPattern matches a disc number anywhere in a filename, e.g.
+// "05. Title (Disc 2)", "[CD1] 03 Title" or "Disk 2 - 01 Title".
+var discPattern = regexp.MustCompile(`(?i)[(\[]?\b(?:disc|disk|cd)\s*(\d{1,2})\b[)\]]?(?:\s*[-._]\s*)?`)
+
 // Track represents a track in the library with full metadata.
 type Track struct {
 	Path        string
 	Title       string
-	Game        string
+	Game        string // GD3 game name, or DirGame if the tag is empty
+	DirGame     string // Name of the directory containing the file
 	System      string
 	Composer    string
 	Duration    time.Duration
-	TrackNumber int // 1-indexed track number, 0 if unknown
+	TrackNumber int      // 1-indexed track number, 0 if unknown
+	Disc        int      // Disc number from the filename, 0 if unknown
+	Chips       []string // Names of the sound chips used, kept in the cached index
+
+	// File state at scan time, used to detect changes between scans
+	Size    int64
+	ModTime time.Time
+}
+
+// GameLabel selects where displayed game names come from.
+type GameLabel int
+
+const (
+	GameLabelGD3 GameLabel = iota // GD3 game tag (directory name if missing)
+	GameLabelDir                  // Directory containing the files
+)
+
+// String returns a short description of the label source.
+func (l GameLabel) String() string {
+	if l == GameLabelDir {
+		return "directory"
+	}
+	return "GD3"
+}
+
+// Pick returns the game name to show for a track whose GD3 game name is
+// game and whose directory is named dir. Untagged tracks already have the
+// directory name as their game, so only a missing dir needs a fallback.
+func (l GameLabel) Pick(game, dir string) string {
+	if l == GameLabelDir && dir != "" {
+		return dir
+	}
+	return game
+}
+
+// Next returns the other label source.
+func (l GameLabel) Next() GameLabel {
+	if l == GameLabelDir {
+		return GameLabelGD3
+	}
+	return GameLabelDir
+}
+
+// DirGameName returns the directory-derived game name for a file path.
+func DirGameName(path string) string {
+	return metadata.CleanTag(filepath.Base(filepath.Dir(path)))
+}
+
+// GameName returns the track's game name from the given label source.
+func (t Track) GameName(label GameLabel) string {
+	return label.Pick(t.Game, t.DirGame)
+}
+
+// TrackSort selects the order of tracks within a game.
+type TrackSort int
+
+const (
+	SortByNumber   TrackSort = iota // M3U or filename track number, then path
+	SortByTitle                     // Title, in natural order
+	SortByDuration                  // Shortest first
+	SortByPath                      // File path, in natural order
+)
+
+// trackSortNames are the names of the sort orders, as used in the config.
+var trackSortNames = []string{"number", "title", "duration", "path"}
+
+// String returns the name of the sort order.
+func (s TrackSort) String() string {
+	if s < 0 || int(s) >= len(trackSortNames) {
+		return trackSortNames[0]
+	}
+	return trackSortNames[s]
+}
+
+// Next returns the following sort order, wrapping around.
+func (s TrackSort) Next() TrackSort {
+	return (s + 1) % TrackSort(len(trackSortNames))
+}
+
+// ParseTrackSort returns the sort order with the given name.
+func ParseTrackSort(name string) (TrackSort, bool) {
+	for i, n := range trackSortNames {
+		if strings.EqualFold(n, name) {
+			return TrackSort(i), true
+		}
+	}
+	return SortByNumber, false
+}
+
+// Less reports whether a sorts before b. Tracks with equal keys compare
+// equal, except by number, where ties and missing numbers fall back to path.
+// Titles and paths compare in natural order (see NaturalCompare).
+func (s TrackSort) Less(a, b Track) bool {
+	switch s {
+	case SortByTitle:
+		return NaturalCompare(a.Title, b.Title) < 0
+	case SortByDuration:
+		return a.Duration < b.Duration
+	case SortByPath:
+		return NaturalLess(a.Path, b.Path)
+	}
+
+	ta, tb := a.sortNumber(), b.sortNumber()
+	// Both have track numbers: sort by number
+	if ta > 0 && tb > 0 && ta != tb {
+		return ta < tb
+	}
+	// Only one has a track number: it comes first
+	if ta > 0 && tb <= 0 {
+		return true
+	}
+	if tb > 0 && ta <= 0 {
+		return false
+	}
+	// Neither has a track number (or they tie): sort by path
+	return NaturalLess(a.Path, b.Path)
+}
+
+// sortNumber returns the number a track sorts by when sorting by number:
+// its track number after those of earlier discs, or 0 if it has none.
+// Tracks go up to 999, so each disc takes a thousand numbers.
+func (t Track) sortNumber() int {
+	if t.TrackNumber <= 0 {
+		return 0
+	}
+	return t.Disc*1000 + t.TrackNumber
+}
+
+// SortTracks sorts tracks in the given order. Tracks with equal keys keep
+// the natural order of their paths.
+func SortTracks(tracks []Track, order TrackSort) {
+	sort.SliceStable(tracks, func(i, j int) bool {
+		return NaturalLess(tracks[i].Path, tracks[j].Path)
+	})
+	if order != SortByPath {
+		sort.SliceStable(tracks, func(i, j int) bool {
+			return order.Less(tracks[i], tracks[j])
+		})
+	}
 }
 
 // Game represents a game/album containing tracks.
@@ -54,21 +199,36 @@ type System struct {
 
 // Library represents an indexed VGM music library.
 type Library struct {
-	mu      sync.RWMutex
-	root    string
-	systems map[string]*System
-	tracks  []Track // Flat list for quick access
+	mu        sync.RWMutex
+	root      string
+	systems   map[string]*System
+	tracks    []Track          // Flat list for quick access
+	files     map[string]Track // Tracks as read from their files, before M3U playlists apply
+	trackSort TrackSort        // Order of tracks within each game
+	original  bool             // Whether files were read with tags in their original language
+	reader    metadata.Reader // Where scans read track metadata from
 }
 
-// New creates a new library rooted at the given directory.
-func New(root string) *Library {
+// New creates a new library rooted at the given directory, whose scans
+// read track metadata through reader.
+func New(root string, reader metadata.Reader) *Library {
 	return &Library{
 		root:    root,
 		systems: make(map[string]*System),
 		tracks:  make([]Track, 0),
+		reader:  reader,
 	}
 }
 
+// SetMetadataReader sets where scans read track metadata from. Files
+// already scanned are read again by the next scan.
+func (l *Library) SetMetadataReader(r metadata.Reader) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reader = r
+	l.files = nil
+}
+
 // Root returns the library root directory.
 func (l *Library) Root() string {
 	return l.root
@@ -76,61 +236,75 @@ func (l *Library) Root() string {
 
 // Scan scans the library directory and indexes all VGM files.
 // Returns the number of tracks found.
+//
+// Files unchanged in size and modification time since the previous scan
+// keep their metadata instead of being read again. The previous index
+// stays readable until the new one is complete.
 func (l *Library) Scan() (int, error) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	// Clear existing data
-	l.systems = make(map[string]*System)
-	l.tracks = make([]Track, 0)
+	original := metadata.PreferOriginal()
+	l.mu.RLock()
+	prev := l.files
+	order := l.trackSort
+	reader := l.reader
+	if l.original != original {
+		// Tags were read in the other language, so every file is read again
+		prev = nil
+	}
+	l.mu.RUnlock()
 
-	// Walk the directory tree
-	err := filepath.Walk(l.root, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Skip files we can't access
-		}
+	systems := make(map[string]*System)
+	tracks := make([]Track, 0)
+	files := make(map[string]Track)
 
-		// Skip directories and hidden files
-		if info.IsDir() {
-			if strings.HasPrefix(info.Name(), ".") {
-				return filepath.SkipDir
-			}
-			return nil
+	// Walk the directory tree, skipping hidden and ignored directories
+	err := walkLibrary(l.root, func(path string, info os.FileInfo) {
+		// Check if it's a VGM file
+		if !IsVGMFile(info.Name()) {
+			return
 		}
 
-		// Check if it's a VGM file
-		if !isVGMFile(info.Name()) {
-			return nil
+		// Reuse the previous scan if the file is unchanged. Files with a
+		// sidecar are always read again, since it may have been edited
+		if old, ok := prev[path]; ok && old.Size == info.Size() && old.ModTime.Equal(info.ModTime()) && !hasSidecar(path) {
+			tracks = append(tracks, old)
+			files[path] = old
+			addTrack(systems, old)
+			return
 		}
 
 		// Read metadata
-		track, err := player.ReadTrackMetadata(path)
+		track, err := reader.ReadTrackMetadata(path)
 		if err != nil {
-			return nil // Skip files we can't read
+			return // Skip files we can't read
 		}
 
 		// Extract track number from filename
-		trackNum := extractTrackNumber(info.Name())
+		trackNum, disc := extractTrackNumber(info.Name())
 
 		// Create library track
 		libTrack := Track{
 			Path:        path,
 			Title:       track.Title,
 			Game:        track.Game,
+			DirGame:     DirGameName(path),
 			System:      track.System,
 			Composer:    track.Composer,
 			Duration:    track.Duration,
 			TrackNumber: trackNum,
+			Disc:        disc,
+			Chips:       chipNames(track.Chips),
+			Size:        info.Size(),
+			ModTime:     info.ModTime(),
 		}
 
 		// Use filename as title if empty
 		if libTrack.Title == "" {
-			libTrack.Title = strings.TrimSuffix(info.Name(), filepath.Ext(info.Name()))
+			libTrack.Title = metadata.CleanTag(TrimVGMExt(info.Name()))
 		}
 
 		// Use parent directory as game if empty
 		if libTrack.Game == "" {
-			libTrack.Game = filepath.Base(filepath.Dir(path))
+			libTrack.Game = libTrack.DirGame
 		}
 
 		// Use "Unknown" as system if empty
@@ -139,12 +313,11 @@ func (l *Library) Scan() (int, error) {
 		}
 
 		// Add to flat list
-		l.tracks = append(l.tracks, libTrack)
+		tracks = append(tracks, libTrack)
+		files[path] = libTrack
 
 		// Add to hierarchy
-		l.addTrack(libTrack)
-
-		return nil
+		addTrack(systems, libTrack)
 	})
 
 	if err != nil {
@@ -152,45 +325,85 @@ func (l *Library) Scan() (int, error) {
 	}
 
 	// Sort tracks within each game
-	// Priority: M3U playlist order > filename track numbers > path (alphabetical)
-	for _, system := range l.systems {
+	// Track numbers come from the M3U playlist if there is one, else filenames
+	byPath := make(map[string]Track, len(tracks))
+	for _, system := range systems {
 		for _, game := range system.Games {
 			// Try to get track order from M3U file in game directory
 			applyM3UOrder(game)
+			SortTracks(game.Tracks, order)
+			for _, t := range game.Tracks {
+				byPath[t.Path] = t
+			}
+		}
+	}
 
-			// Sort by track number, falling back to path for ties or missing numbers
-			sort.SliceStable(game.Tracks, func(i, j int) bool {
-				ti, tj := game.Tracks[i].TrackNumber, game.Tracks[j].TrackNumber
-				// Both have track numbers: sort by number
-				if ti > 0 && tj > 0 {
-					return ti < tj
-				}
-				// Only one has a track number: it comes first
-				if ti > 0 {
-					return true
-				}
-				if tj > 0 {
-					return false
-				}
-				// Neither has a track number: sort by path
-				return game.Tracks[i].Path < game.Tracks[j].Path
-			})
+	// Keep the flat list in step with what the playlists filled in
+	for i := range tracks {
+		tracks[i] = byPath[tracks[i].Path]
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.systems = systems
+	l.tracks = tracks
+	l.files = files
+	l.original = original
+	if l.trackSort != order {
+		// The order changed while scanning
+		for _, system := range systems {
+			for _, game := range system.Games {
+				SortTracks(game.Tracks, l.trackSort)
+			}
 		}
 	}
 
 	return len(l.tracks), nil
 }
 
+// SetTrackSort sets the order of tracks within each game and re-sorts the
+// scanned games in place, without rescanning.
+func (l *Library) SetTrackSort(order TrackSort) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.trackSort = order
+	for _, system := range l.systems {
+		for _, game := range system.Games {
+			SortTracks(game.Tracks, order)
+		}
+	}
+}
+
+// TrackSort returns the order of tracks within each game.
+func (l *Library) TrackSort() TrackSort {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.trackSort
+}
+
+// chipNames returns the names of the chips, without duplicates.
+func chipNames(chips []metadata.ChipInfo) []string {
+	var names []string
+	for _, c := range chips {
+		if !slices.Contains(names, c.Name) {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
 // addTrack adds a track to the library hierarchy.
-func (l *Library) addTrack(track Track) {
+func addTrack(systems map[string]*System, track Track) {
 	// Get or create system
-	system, ok := l.systems[track.System]
+	system, ok := systems[track.System]
 	if !ok {
 		system = &System{
 			Name:  track.System,
 			Games: make(map[string]*Game),
 		}
-		l.systems[track.System] = system
+		systems[track.System] = system
 	}
 
 	// Get or create game
@@ -259,6 +472,52 @@ func (l *Library) GetGame(systemName, gameName string) *Game {
 	return system.Games[gameName]
 }
 
+// UnknownComposer is the composer name given to tracks without one.
+const UnknownComposer = "(Unknown composer)"
+
+// ComposerName returns the track's composer, or UnknownComposer if untagged.
+func (t Track) ComposerName() string {
+	if t.Composer == "" {
+		return UnknownComposer
+	}
+	return t.Composer
+}
+
+// Composers returns a sorted list of composer names. Tracks without a
+// composer are listed under UnknownComposer.
+func (l *Library) Composers() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, t := range l.tracks {
+		name := t.ComposerName()
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TracksByComposer returns the tracks of a composer (UnknownComposer for
+// untagged tracks), ordered by system, then game, then track order.
+func (l *Library) TracksByComposer(name string) []Track {
+	var tracks []Track
+	for _, sysName := range l.Systems() {
+		for _, gameName := range l.Games(sysName) {
+			for _, t := range l.Tracks(sysName, gameName) {
+				if t.ComposerName() == name {
+					tracks = append(tracks, t)
+				}
+			}
+		}
+	}
+	return tracks
+}
+
 // Tracks returns a sorted list of tracks for a game.
 func (l *Library) Tracks(systemName, gameName string) []Track {
 	l.mu.RLock()
@@ -293,45 +552,44 @@ func (l *Library) TrackCount() int {
 	return len(l.tracks)
 }
 
-// isVGMFile checks if a filename has a VGM-compatible extension.
-func isVGMFile(name string) bool {
-	lower := strings.ToLower(name)
-	for _, ext := range vgmExtensions {
-		if strings.HasSuffix(lower, ext) {
-			return true
+// extractTrackNumber extracts a track number and disc number from a
+// filename. Either is 0 if not found.
+// Examples: "01 - Title.vgm" -> 1, "Track01.vgm" -> 1, "(02) Song.vgm" -> 2,
+// "1-05 Title.vgm" -> 5 on disc 1, "05. Title (Disc 2).vgm" -> 5 on disc 2
+func extractTrackNumber(filename string) (track, disc int) {
+	// Remove extension first
+	name := TrimVGMExt(filename)
+
+	if m := discTrackPattern.FindStringSubmatch(name); m != nil {
+		d, _ := strconv.Atoi(m[1])
+		t, _ := strconv.Atoi(m[2])
+		if d > 0 && t > 0 {
+			return t, d
 		}
 	}
-	return false
-}
 
-// extractTrackNumber extracts a track number from a filename.
-// Returns 0 if no track number is found.
-// Examples: "01 - Title.vgm" -> 1, "Track01.vgm" -> 1, "(02) Song.vgm" -> 2
-func extractTrackNumber(filename string) int {
-	// Remove extension first
-	name := filename
-	for _, ext := range vgmExtensions {
-		if strings.HasSuffix(strings.ToLower(name), ext) {
-			name = name[:len(name)-len(ext)]
-			break
-		}
+	// Take out a disc tag so a leading one does not hide the track number
+	if loc := discPattern.FindStringSubmatchIndex(name); loc != nil {
+		disc, _ = strconv.Atoi(name[loc[2]:loc[3]])
+		name = strings.TrimSpace(name[:loc[0]] + name[loc[1]:])
 	}
 
 	// Try each pattern
 	for _, pattern := range trackNumberPatterns {
 		if matches := pattern.FindStringSubmatch(name); matches != nil {
 			if num, err := strconv.Atoi(matches[1]); err == nil && num > 0 && num <= 999 {
-				return num
+				return num, disc
 			}
 		}
 	}
 
-	return 0
+	return 0, disc
 }
 
 // applyM3UOrder looks for an M3U playlist file in the game's directory
 // and assigns track numbers based on the playlist order.
 // M3U order takes priority over filename-extracted numbers.
+// #EXTINF durations and titles fill in what the files' tags lack.
 func applyM3UOrder(game *Game) {
 	if len(game.Tracks) == 0 {
 		return
@@ -362,38 +620,62 @@ func applyM3UOrder(game *Game) {
 		return
 	}
 
-	// Parse M3U and build filename -> position map
-	m3uOrder := parseM3U(m3uPath)
-	if len(m3uOrder) == 0 {
+	// Parse M3U and build filename -> entry map
+	m3uEntries := parseM3U(m3uPath)
+	if len(m3uEntries) == 0 {
 		return
 	}
 
 	// Assign track numbers from M3U order
 	for i := range game.Tracks {
-		filename := strings.ToLower(filepath.Base(game.Tracks[i].Path))
-		if pos, ok := m3uOrder[filename]; ok {
-			game.Tracks[i].TrackNumber = pos
+		track := &game.Tracks[i]
+		filename := filepath.Base(track.Path)
+		entry, ok := m3uEntries[strings.ToLower(filename)]
+		if !ok {
+			continue
+		}
+		track.TrackNumber = entry.Position
+		track.Disc = 0 // The playlist order spans discs
+		if track.Duration == 0 && entry.Duration > 0 {
+			track.Duration = entry.Duration
+		}
+		// A title equal to the filename means the file has no title tag
+		if entry.Title != "" && track.Title == metadata.CleanTag(TrimVGMExt(filename)) {
+			track.Title = entry.Title
 		}
 	}
 }
 
+// m3uEntry is a VGM file listed in an M3U playlist.
+type m3uEntry struct {
+	Position int           // 1-indexed position among the VGM files
+	Duration time.Duration // From the preceding #EXTINF line, 0 if none
+	Title    string        // From the preceding #EXTINF line, "" if none
+}
+
 // parseM3U reads an M3U playlist file and returns a map of
-// lowercase filename -> 1-indexed position.
-func parseM3U(path string) map[string]int {
+// lowercase filename -> entry.
+func parseM3U(path string) map[string]m3uEntry {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil
 	}
 	defer file.Close()
 
-	order := make(map[string]int)
+	entries := make(map[string]m3uEntry)
 	position := 0
+	var info m3uEntry // From the last #EXTINF line, applied to the next file
 
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 
-		// Skip empty lines and comments (including extended M3U tags like #EXTINF)
+		if rest, ok := strings.CutPrefix(line, "#EXTINF:"); ok {
+			info = parseEXTINF(rest)
+			continue
+		}
+
+		// Skip empty lines and other comments and extended M3U tags
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
@@ -405,11 +687,29 @@ func parseM3U(path string) map[string]int {
 		filename := filepath.Base(line)
 
 		// Only count VGM files
-		if isVGMFile(filename) {
+		if IsVGMFile(filename) {
 			position++
-			order[strings.ToLower(filename)] = position
+			info.Position = position
+			entries[strings.ToLower(filename)] = info
 		}
+		info = m3uEntry{}
 	}
 
-	return order
+	return entries
+}
+
+// parseEXTINF parses the part of an "#EXTINF:seconds,Title" line after the
+// colon. Attributes after the seconds are ignored, and a negative or
+// unparseable duration is treated as unknown.
+func parseEXTINF(s string) m3uEntry {
+	var e m3uEntry
+	length, title, _ := strings.Cut(s, ",")
+	e.Title = metadata.CleanTag(title)
+
+	if fields := strings.Fields(length); len(fields) > 0 {
+		if secs, err := strconv.ParseFloat(fields[0], 64); err == nil && secs > 0 {
+			e.Duration = time.Duration(secs * float64(time.Second))
+		}
+	}
+	return e
 }
@@ -0,0 +1,207 @@
+package library
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// fakeReader serves metadata from a map keyed by file name, counting the
+// files read. Files missing from the map fail to read.
+type fakeReader struct {
+	tracks map[string]metadata.Track
+	reads  int
+}
+
+func (r *fakeReader) ReadTrackMetadata(path string) (metadata.Track, error) {
+	r.reads++
+	track, ok := r.tracks[filepath.Base(path)]
+	if !ok {
+		return metadata.Track{}, errors.New("unreadable")
+	}
+	track.Path = path
+	return track, nil
+}
+
+// writeFiles creates empty files at the given paths below dir.
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root,
+		"Sonic/02 Marble Zone.vgm",
+		"Sonic/01 Green Hill Zone.vgz",
+		"Sonic/broken.vgm",
+		"Sonic/notes.txt",
+		"Untagged Game/song.vgm",
+	)
+	reader := &fakeReader{tracks: map[string]metadata.Track{
+		"01 Green Hill Zone.vgz": {
+			Title: "Green Hill Zone", Game: "Sonic the Hedgehog",
+			System: "Sega Mega Drive", Composer: "Masato Nakamura",
+			Duration: 90 * time.Second,
+			Chips:    []metadata.ChipInfo{{Name: "YM2612"}, {Name: "SN76489"}, {Name: "YM2612"}},
+		},
+		"02 Marble Zone.vgm": {
+			Title: "Marble Zone", Game: "Sonic the Hedgehog", System: "Sega Mega Drive",
+		},
+		"song.vgm": {},
+	}}
+
+	lib := New(root, reader)
+	n, err := lib.Scan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("Scan() = %d tracks, want 3", n)
+	}
+	if reader.reads != 4 {
+		t.Errorf("read %d files, want 4 (the VGM files only)", reader.reads)
+	}
+
+	game := lib.GetGame("Sega Mega Drive", "Sonic the Hedgehog")
+	if game == nil {
+		t.Fatalf("game missing; systems %v", lib.Systems())
+	}
+	var titles []string
+	for _, track := range game.Tracks {
+		titles = append(titles, track.Title)
+	}
+	if len(titles) != 2 || titles[0] != "Green Hill Zone" || titles[1] != "Marble Zone" {
+		t.Errorf("game tracks = %q, want Green Hill Zone then Marble Zone", titles)
+	}
+	first := game.Tracks[0]
+	if first.TrackNumber != 1 || first.DirGame != "Sonic" || first.Duration != 90*time.Second {
+		t.Errorf("first track = %+v", first)
+	}
+	if len(first.Chips) != 2 || first.Chips[0] != "YM2612" || first.Chips[1] != "SN76489" {
+		t.Errorf("chips = %q, want YM2612 and SN76489 once each", first.Chips)
+	}
+
+	// A file without tags is named after itself and its directory
+	untagged := lib.GetGame("Unknown", "Untagged Game")
+	if untagged == nil || len(untagged.Tracks) != 1 || untagged.Tracks[0].Title != "song" {
+		t.Errorf("untagged game = %+v, want one track titled %q", untagged, "song")
+	}
+
+	// Unchanged files keep their metadata; only the unreadable one is
+	// tried again
+	reader.reads = 0
+	if n, err := lib.Scan(); err != nil || n != 3 {
+		t.Fatalf("second Scan() = %d, %v; want 3, nil", n, err)
+	}
+	if reader.reads != 1 {
+		t.Errorf("second scan read %d files, want 1", reader.reads)
+	}
+
+	// A new reader reads every file again
+	lib.SetMetadataReader(reader)
+	reader.reads = 0
+	if _, err := lib.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	if reader.reads != 4 {
+		t.Errorf("scan after SetMetadataReader read %d files, want 4", reader.reads)
+	}
+}
+
+func TestExtractTrackNumber(t *testing.T) {
+	tests := []struct {
+		filename    string
+		track, disc int
+	}{
+		{"01 - Title.vgm", 1, 0},
+		{"Track01.vgm", 1, 0},
+		{"(02) Song.vgm", 2, 0},
+		{"[03] Song.vgz", 3, 0},
+		{"12.vgm", 12, 0},
+		{"1-05 Title.vgm", 5, 1},
+		{"2-11.Title.vgm", 11, 2},
+		{"05. Title (Disc 2).vgm", 5, 2},
+		{"[CD1] 03 Title.vgm", 3, 1},
+		{"Disk 2 - 01 Title.vgm", 1, 2},
+		{"Title.vgm", 0, 0},
+		{"Title (Disc 3).vgm", 0, 3},
+	}
+	for _, tt := range tests {
+		track, disc := extractTrackNumber(tt.filename)
+		if track != tt.track || disc != tt.disc {
+			t.Errorf("extractTrackNumber(%q) = %d, %d; want %d, %d",
+				tt.filename, track, disc, tt.track, tt.disc)
+		}
+	}
+}
+
+func TestTrackSortLessAcrossDiscs(t *testing.T) {
+	track := func(name string) Track {
+		num, disc := extractTrackNumber(name)
+		return Track{Path: "/music/game/" + name, TrackNumber: num, Disc: disc}
+	}
+	// In order: disc 1 before disc 2 whatever the track numbers, then
+	// tracks without a number by path
+	want := []Track{
+		track("1-01 Opening.vgm"),
+		track("1-12 Boss.vgm"),
+		track("2-01 Title.vgm"),
+		track("2-03 Ending.vgm"),
+		track("jingle.vgm"),
+	}
+	for i := range want {
+		for j := range want {
+			if got := SortByNumber.Less(want[i], want[j]); got != (i < j) {
+				t.Errorf("Less(%s, %s) = %v, want %v",
+					filepath.Base(want[i].Path), filepath.Base(want[j].Path), got, i < j)
+			}
+		}
+	}
+
+	tracks := []Track{want[3], want[4], want[0], want[2], want[1]}
+	SortTracks(tracks, SortByNumber)
+	for i := range tracks {
+		if tracks[i].Path != want[i].Path {
+			t.Errorf("SortTracks position %d = %s, want %s", i, tracks[i].Path, want[i].Path)
+		}
+	}
+}
+
+func TestGameName(t *testing.T) {
+	tests := []struct {
+		name     string
+		track    Track
+		gd3, dir string
+	}{
+		{"tagged", Track{Game: "Sonic the Hedgehog", DirGame: "sonic1"}, "Sonic the Hedgehog", "sonic1"},
+		{"untagged", Track{Game: "sonic1", DirGame: "sonic1"}, "sonic1", "sonic1"},
+		{"no directory", Track{Game: "Sonic the Hedgehog"}, "Sonic the Hedgehog", "Sonic the Hedgehog"},
+	}
+	for _, tt := range tests {
+		if got := tt.track.GameName(GameLabelGD3); got != tt.gd3 {
+			t.Errorf("%s: GD3 label = %q, want %q", tt.name, got, tt.gd3)
+		}
+		if got := tt.track.GameName(GameLabelDir); got != tt.dir {
+			t.Errorf("%s: directory label = %q, want %q", tt.name, got, tt.dir)
+		}
+		if got := tt.track.GameName(GameLabelGD3.Next()); got != tt.dir {
+			t.Errorf("%s: label after GD3 = %q, want the directory's %q", tt.name, got, tt.dir)
+		}
+		if got := tt.track.GameName(GameLabelDir.Next()); got != tt.gd3 {
+			t.Errorf("%s: label after directory = %q, want the GD3 %q", tt.name, got, tt.gd3)
+		}
+	}
+}
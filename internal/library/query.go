@@ -0,0 +1,63 @@
+package library
+
+import (
+	"strings"
+	"time"
+)
+
+// Query selects tracks by their metadata. Empty fields match everything;
+// all set fields must match. Text comparisons ignore case.
+type Query struct {
+	System      string        // Equal to the track's system
+	Game        string        // Contained in the GD3 or directory game name
+	Composer    string        // Contained in the composer
+	Title       string        // Contained in the title
+	MinDuration time.Duration // At least this long (0 for no minimum)
+	MaxDuration time.Duration // At most this long (0 for no maximum)
+}
+
+// Match reports whether t satisfies every criterion of q.
+func (q Query) Match(t Track) bool {
+	if q.System != "" && !strings.EqualFold(t.System, q.System) {
+		return false
+	}
+	if q.Game != "" && !containsFold(t.Game, q.Game) && !containsFold(t.DirGame, q.Game) {
+		return false
+	}
+	if q.Composer != "" && !containsFold(t.Composer, q.Composer) {
+		return false
+	}
+	if q.Title != "" && !containsFold(t.Title, q.Title) {
+		return false
+	}
+	if q.MinDuration > 0 && t.Duration < q.MinDuration {
+		return false
+	}
+	if q.MaxDuration > 0 && t.Duration > q.MaxDuration {
+		return false
+	}
+	return true
+}
+
+// containsFold reports whether substr is within s, ignoring case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// Query returns the tracks matching q, in library order.
+func (l *Library) Query(q Query) []Track {
+	var matches []Track
+	for _, t := range l.AllTracks() {
+		if q.Match(t) {
+			matches = append(matches, t)
+		}
+	}
+	return matches
+}
+
+// SmartPlaylist is a named query. Its tracks are looked up each time it
+// is used, so it follows the library as it changes.
+type SmartPlaylist struct {
+	Name  string
+	Query Query
+}
@@ -0,0 +1,88 @@
+package library
+
+import (
+	"sort"
+	"time"
+)
+
+// Count is a name and how many tracks it applies to.
+type Count struct {
+	Name  string
+	Count int
+}
+
+// Stats summarizes a set of tracks.
+type Stats struct {
+	Tracks    int
+	Games     int
+	Playtime  time.Duration // Sum of the track durations
+	Systems   []Count       // Tracks per system, most first
+	Chips     []Count       // Tracks using each chip type, most first
+	Composers []Count       // Tracks per composer, most first (tagged only)
+	Played    []Count       // Full plays per track, most first (played only)
+}
+
+// ComputeStats summarizes tracks.
+func ComputeStats(tracks []Track) Stats {
+	st := Stats{Tracks: len(tracks)}
+	systems := make(map[string]int)
+	chips := make(map[string]int)
+	composers := make(map[string]int)
+	games := make(map[string]bool)
+
+	for _, t := range tracks {
+		st.Playtime += t.Duration
+		systems[t.System]++
+		games[t.System+"\x00"+t.Game] = true
+		for _, chip := range t.Chips {
+			chips[chip]++
+		}
+		if t.Composer != "" {
+			composers[t.Composer]++
+		}
+	}
+
+	st.Games = len(games)
+	st.Systems = sortedCounts(systems)
+	st.Chips = sortedCounts(chips)
+	st.Composers = sortedCounts(composers)
+	return st
+}
+
+// Stats summarizes the whole library.
+func (l *Library) Stats() Stats {
+	return ComputeStats(l.AllTracks())
+}
+
+// MostPlayed returns the tracks with a play count, named "title - game",
+// ordered like sortedCounts. plays holds the count by track path.
+func MostPlayed(tracks []Track, plays map[string]int) []Count {
+	var counts []Count
+	for _, t := range tracks {
+		if n := plays[t.Path]; n > 0 {
+			counts = append(counts, Count{Name: t.Title + " - " + t.Game, Count: n})
+		}
+	}
+	sortCounts(counts)
+	return counts
+}
+
+// sortedCounts returns the counts ordered by count (highest first), then name.
+func sortedCounts(m map[string]int) []Count {
+	counts := make([]Count, 0, len(m))
+	for name, n := range m {
+		counts = append(counts, Count{Name: name, Count: n})
+	}
+	sortCounts(counts)
+	return counts
+}
+
+// sortCounts orders counts by count (highest first), then name.
+func sortCounts(counts []Count) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Name < counts[j].Name
+	})
+}
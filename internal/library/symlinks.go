@@ -0,0 +1,110 @@
+package library
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// followSymlinks makes walks below a directory descend into symlinked
+// directories, shared by the library scan and the file browser.
+var followSymlinks atomic.Bool
+
+// SetFollowSymlinks sets whether symlinked directories are followed. It is
+// off by default, since a link can lead far outside the tree.
+func SetFollowSymlinks(follow bool) {
+	followSymlinks.Store(follow)
+}
+
+// FollowSymlinks returns whether symlinked directories are followed.
+func FollowSymlinks() bool {
+	return followSymlinks.Load()
+}
+
+// WalkFiles calls fn for every file below root in lexical order, skipping
+// hidden directories unless hiddenDirs is set. Entries that cannot be read
+// are skipped; only an unreadable root is an error.
+//
+// While symlinks are followed, symlinked directories are walked too and fn
+// gets the target's info for symlinked files. Each real directory is
+// walked once, so links pointing back up the tree do not loop.
+func WalkFiles(root string, hiddenDirs bool, fn func(path string, info fs.FileInfo)) error {
+	w := walker{
+		hiddenDirs: hiddenDirs,
+		follow:     FollowSymlinks(),
+		seen:       make(map[string]bool),
+		fn:         fn,
+	}
+	return w.walk(root, nil)
+}
+
+// walkLibrary walks root as WalkFiles does for the library scan: hidden
+// directories are skipped, and so is everything an ignore file
+// (ignoreFile) in a directory on the way matches.
+func walkLibrary(root string, fn func(path string, info fs.FileInfo)) error {
+	w := walker{
+		follow: FollowSymlinks(),
+		ignore: true,
+		seen:   make(map[string]bool),
+		fn:     fn,
+	}
+	return w.walk(root, nil)
+}
+
+// walker holds the state of a WalkFiles call.
+type walker struct {
+	hiddenDirs bool
+	follow     bool
+	ignore     bool            // Read ignore files and skip what they match
+	seen       map[string]bool // Real paths of the directories walked
+	fn         func(path string, info fs.FileInfo)
+}
+
+// walk calls fn for the files below dir. rules are the ignore rules from
+// the directories above it.
+func (w *walker) walk(dir string, rules []ignoreRule) error {
+	if w.follow {
+		real, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			return err
+		}
+		if w.seen[real] {
+			return nil
+		}
+		w.seen[real] = true
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if w.ignore {
+		rules = append(slices.Clip(rules), readIgnoreFile(dir)...)
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if w.follow && info.Mode()&fs.ModeSymlink != 0 {
+			if target, err := os.Stat(path); err == nil {
+				info = target
+			}
+		}
+		if w.ignore && ignored(rules, path, info.IsDir()) {
+			continue
+		}
+		if info.IsDir() {
+			if w.hiddenDirs || !strings.HasPrefix(e.Name(), ".") {
+				w.walk(path, rules) // Skip directories we can't read
+			}
+			continue
+		}
+		w.fn(path, info)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package library
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"sync"
+	"time"
+)
+
+// Watcher polls a library directory for added, removed and changed VGM
+// files. It compares the names, sizes and modification times of the files
+// rather than reading them, so polling is cheap even for large libraries.
+type Watcher struct {
+	root     string
+	interval time.Duration
+	settle   time.Duration
+
+	changes chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewWatcher starts watching root, checking every interval. A change is
+// reported once the files have stayed the same for settle, so copying a
+// whole album in produces one change rather than one per file.
+func NewWatcher(root string, interval, settle time.Duration) *Watcher {
+	w := &Watcher{
+		root:     root,
+		interval: interval,
+		settle:   settle,
+		changes:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Changes returns the channel a value is sent on when the library changed.
+// It is closed when the watcher is closed.
+func (w *Watcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+// Close stops watching. It is safe to call more than once.
+func (w *Watcher) Close() {
+	w.once.Do(func() { close(w.done) })
+}
+
+// run polls until the watcher is closed.
+func (w *Watcher) run() {
+	defer close(w.changes)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	last := snapshot(w.root)
+	var changedAt time.Time
+	pending := false
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+		}
+
+		if sig := snapshot(w.root); sig != last {
+			last = sig
+			changedAt = time.Now()
+			pending = true
+			continue
+		}
+		if pending && time.Since(changedAt) >= w.settle {
+			pending = false
+			select {
+			case w.changes <- struct{}{}:
+			default: // A change is already waiting to be received
+			}
+		}
+	}
+}
+
+// snapshot returns a hash of the paths, sizes and modification times of
+// the VGM and ignore files below root, walked as Scan does.
+func snapshot(root string) uint64 {
+	h := fnv.New64a()
+	walkLibrary(root, func(path string, info fs.FileInfo) {
+		// A changed ignore file changes what the scan finds, and a changed
+		// sidecar the tags it reads
+		if IsVGMFile(info.Name()) || info.Name() == ignoreFile || isSidecar(info.Name()) {
+			fmt.Fprintf(h, "%s\x00%d\x00%d\x00", path, info.Size(), info.ModTime().UnixNano())
+		}
+	})
+	return h.Sum64()
+}
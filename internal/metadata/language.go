@@ -0,0 +1,58 @@
+package metadata
+
+import (
+	"cmp"
+	"sync/atomic"
+)
+
+// preferOriginal makes tags read from files use their original-language
+// (usually Japanese) GD3 fields rather than the English ones.
+var preferOriginal atomic.Bool
+
+// SetPreferOriginal sets whether tags are shown in their original language
+// rather than English. Either way a tag missing in one language falls back
+// to the other, so files tagged in only one still show it.
+func SetPreferOriginal(original bool) {
+	preferOriginal.Store(original)
+}
+
+// PreferOriginal returns whether tags are shown in their original language.
+func PreferOriginal() bool {
+	return preferOriginal.Load()
+}
+
+// Tags holds the GD3 tags that a file can have in two languages.
+type Tags struct {
+	Title    string
+	Game     string
+	System   string
+	Composer string
+}
+
+// clean returns the tags made safe to show on one line (see CleanTag).
+func (t Tags) clean() Tags {
+	return Tags{
+		Title:    CleanTag(t.Title),
+		Game:     CleanTag(t.Game),
+		System:   CleanTag(t.System),
+		Composer: CleanTag(t.Composer),
+	}
+}
+
+// UseLanguage sets the title, game, system and composer from the English
+// or original tags, whichever is preferred, taking each from the other
+// language where the preferred one is empty. Tracks without tags in either
+// language are left alone.
+func (t *Track) UseLanguage() {
+	if t.English == (Tags{}) && t.Original == (Tags{}) {
+		return
+	}
+	first, second := t.English, t.Original
+	if PreferOriginal() {
+		first, second = second, first
+	}
+	t.Title = cmp.Or(first.Title, second.Title)
+	t.Game = cmp.Or(first.Game, second.Game)
+	t.System = cmp.Or(first.System, second.System)
+	t.Composer = cmp.Or(first.Composer, second.Composer)
+}
@@ -0,0 +1,18 @@
+package metadata
+
+// Reader reads the metadata of a track from its file. The library scan and
+// the UI read tracks through one, so another source such as a cached
+// index, sidecar files or a fake in tests can stand in for libvgm.
+// Readers are called from background commands, possibly several at once,
+// and must not touch the playing player.
+type Reader interface {
+	ReadTrackMetadata(path string) (Track, error)
+}
+
+// ReaderFunc adapts a function to a Reader.
+type ReaderFunc func(path string) (Track, error)
+
+// ReadTrackMetadata calls f(path).
+func (f ReaderFunc) ReadTrackMetadata(path string) (Track, error) {
+	return f(path)
+}
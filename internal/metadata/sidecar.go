@@ -0,0 +1,76 @@
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// SidecarExt is appended to a file's name to find its sidecar, e.g.
+// "song.vgm.json" for "song.vgm".
+const SidecarExt = ".json"
+
+// Sidecar holds tags that override those read from a file, for files with
+// wrong or missing tags that shouldn't be modified. Empty fields are left
+// as read.
+type Sidecar struct {
+	Title    string `json:"title,omitempty"`
+	Game     string `json:"game,omitempty"`
+	System   string `json:"system,omitempty"`
+	Composer string `json:"composer,omitempty"`
+}
+
+// SidecarPath returns the path of the sidecar for the file at path.
+func SidecarPath(path string) string {
+	return path + SidecarExt
+}
+
+// ReadSidecar reads the sidecar for the file at path. A missing sidecar
+// is not an error, and returns ok false.
+func ReadSidecar(path string) (s Sidecar, ok bool, err error) {
+	data, err := os.ReadFile(SidecarPath(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, false, nil
+	}
+	if err != nil {
+		return s, false, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, false, fmt.Errorf("%s: %w", SidecarPath(path), err)
+	}
+	return s, true, nil
+}
+
+// apply sets the track's tags from the sidecar's non-empty fields, in both
+// languages so the override holds whichever is preferred.
+func (s Sidecar) apply(t *Track) {
+	set := func(value string, fields ...*string) {
+		if value = CleanTag(value); value != "" {
+			for _, f := range fields {
+				*f = value
+			}
+		}
+	}
+	set(s.Title, &t.Title, &t.English.Title, &t.Original.Title)
+	set(s.Game, &t.Game, &t.English.Game, &t.Original.Game)
+	set(s.System, &t.System, &t.English.System, &t.Original.System)
+	set(s.Composer, &t.Composer, &t.English.Composer, &t.Original.Composer)
+}
+
+// WithSidecars returns a reader that reads through r, then applies the
+// file's sidecar if it has one. A sidecar that can't be read or parsed is
+// passed over, keeping the file's own tags, since the file still plays.
+func WithSidecars(r Reader) Reader {
+	return ReaderFunc(func(path string) (Track, error) {
+		track, err := r.ReadTrackMetadata(path)
+		if err != nil {
+			return track, err
+		}
+		if s, ok, err := ReadSidecar(path); ok && err == nil {
+			s.apply(&track)
+		}
+		return track, nil
+	})
+}
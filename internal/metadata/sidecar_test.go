@@ -0,0 +1,62 @@
+package metadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWithSidecars(t *testing.T) {
+	dir := t.TempDir()
+	tagged := filepath.Join(dir, "tagged.vgm")
+	plain := filepath.Join(dir, "plain.vgm")
+	broken := filepath.Join(dir, "broken.vgm")
+	sidecars := map[string]string{
+		tagged: `{"title": "Sidecar Title", "composer": " Someone\n"}`,
+		broken: `{"title": `,
+	}
+	for path, data := range sidecars {
+		if err := os.WriteFile(SidecarPath(path), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	chips := []ChipInfo{{Name: "YM2612", Core: "GPGX", Clock: 7670453}}
+	file := ReaderFunc(func(path string) (Track, error) {
+		return Track{
+			Path:    path,
+			Title:   "File Title",
+			Game:    "File Game",
+			English: Tags{Title: "File Title", Game: "File Game"},
+			Chips:   chips,
+		}, nil
+	})
+	r := WithSidecars(file)
+
+	tests := []struct {
+		path            string
+		title, composer string
+	}{
+		{tagged, "Sidecar Title", "Someone"},
+		{plain, "File Title", ""},
+		{broken, "File Title", ""}, // Unparseable sidecars are passed over
+	}
+	for _, tt := range tests {
+		track, err := r.ReadTrackMetadata(tt.path)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if track.Title != tt.title || track.Composer != tt.composer || track.Game != "File Game" {
+			t.Errorf("%s: title %q, composer %q, game %q; want %q, %q, %q",
+				filepath.Base(tt.path), track.Title, track.Composer, track.Game,
+				tt.title, tt.composer, "File Game")
+		}
+		if track.English.Title != tt.title {
+			t.Errorf("%s: English title %q, want %q", filepath.Base(tt.path), track.English.Title, tt.title)
+		}
+		// Chips, with their clocks, come through from the file
+		if len(track.Chips) != 1 || track.Chips[0] != chips[0] {
+			t.Errorf("%s: chips %+v, want %+v", filepath.Base(tt.path), track.Chips, chips)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package metadata
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CleanTag returns a tag value made safe to show on one line: line breaks,
+// tabs and other control characters become spaces, runs of spaces are
+// collapsed and the ends are trimmed. Malformed GD3 tags can otherwise
+// break the layout or send escape sequences to the terminal.
+func CleanTag(s string) string {
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}), " ")
+}
+
+// cleanNotes returns free-form notes with their line breaks kept but
+// other control characters removed.
+func cleanNotes(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n':
+			return r
+		case r == '\t' || r == '\r':
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+// CleanTags cleans the text read from a file's tags.
+func (t *Track) CleanTags() {
+	t.Title = CleanTag(t.Title)
+	t.Game = CleanTag(t.Game)
+	t.System = CleanTag(t.System)
+	t.Composer = CleanTag(t.Composer)
+	t.English = t.English.clean()
+	t.Original = t.Original.clean()
+	t.Date = CleanTag(t.Date)
+	t.VGMBy = CleanTag(t.VGMBy)
+	t.Notes = cleanNotes(t.Notes)
+}
@@ -0,0 +1,48 @@
+// Package metadata describes VGM tracks and their tags. It doesn't use
+// cgo, so code that only reads metadata, such as the library scan, can be
+// built and tested without libvgm.
+package metadata
+
+import (
+	"time"
+)
+
+// Track represents metadata about a VGM track.
+type Track struct {
+	// File path
+	Path string
+
+	// GD3 tag information. The title, game, system and composer are in
+	// the preferred language (see UseLanguage)
+	Title    string // Track title (TITLE)
+	Game     string // Game/album name (GAME)
+	System   string // System/platform (SYSTEM)
+	Composer string // Composer/artist (ARTIST)
+	Date     string // Release date (DATE)
+	VGMBy    string // VGM author (ENCODED_BY)
+	Notes    string // Comments (COMMENT)
+
+	// GD3 tags kept in both languages: English (TITLE, ...) and the
+	// original, usually Japanese (TITLE-JPN, ...)
+	English  Tags
+	Original Tags
+
+	// Format information
+	Format string // e.g., "VGM 1.71", "S98 v3"
+
+	// Timing information
+	Duration   time.Duration // Total duration including loops
+	LoopPoint  time.Duration // Position where loop begins (0 if no loop)
+	LoopLength time.Duration // Length of one pass through the loop (0 if no loop)
+	HasLoop    bool          // Whether the track loops
+
+	// Sound chip information
+	Chips []ChipInfo
+}
+
+// ChipInfo represents a sound chip used in a track.
+type ChipInfo struct {
+	Name  string // Chip name, e.g., "YM2612"
+	Core  string // Emulation core, e.g., "GPGX"
+	Clock uint32 // Clock rate in Hz (0 if unknown)
+}
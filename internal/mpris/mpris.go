@@ -0,0 +1,354 @@
+//go:build mpris
+
+// Package mpris exposes vgmtui on the D-Bus session bus as an MPRIS2 media
+// player, so desktop media keys and applets can control playback.
+//
+// It is only built with the "mpris" build tag, which keeps the D-Bus
+// dependency out of default builds.
+package mpris
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/godbus/dbus/v5/prop"
+
+	"github.com/dewi-tim/vgmtui/internal/ui"
+)
+
+const (
+	busName     = "org.mpris.MediaPlayer2.vgmtui"
+	objectPath  = dbus.ObjectPath("/org/mpris/MediaPlayer2")
+	rootIface   = "org.mpris.MediaPlayer2"
+	playerIface = "org.mpris.MediaPlayer2.Player"
+
+	// noTrack is the track id MPRIS reserves for "no track".
+	noTrack = dbus.ObjectPath("/org/mpris/MediaPlayer2/TrackList/NoTrack")
+)
+
+// playerMethods maps playerObject methods to their D-Bus names where the
+// two differ. Seek can't be the Go name: vet expects it to be io.Seeker's.
+var playerMethods = map[string]string{"SeekBy": "Seek"}
+
+// Server is an MPRIS2 player on the session bus. Method calls from the bus
+// are forwarded to the TUI as messages; Update publishes playback state.
+type Server struct {
+	conn  *dbus.Conn
+	props *prop.Properties
+
+	mu      sync.Mutex
+	send    func(tea.Msg) // nil until Attach
+	state   ui.PlayState
+	trackID dbus.ObjectPath
+	pos     time.Duration
+}
+
+// New connects to the session bus and registers the player.
+func New() (*Server, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("mpris: connect session bus: %w", err)
+	}
+
+	s := &Server{conn: conn, trackID: noTrack}
+	if err := s.export(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	reply, err := conn.RequestName(busName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("mpris: request name: %w", err)
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		conn.Close()
+		return nil, fmt.Errorf("mpris: name %s already taken", busName)
+	}
+	return s, nil
+}
+
+// export registers the root and player interfaces and their properties.
+func (s *Server) export() error {
+	root := rootObject{s}
+	player := playerObject{s}
+	if err := s.conn.Export(root, objectPath, rootIface); err != nil {
+		return fmt.Errorf("mpris: export %s: %w", rootIface, err)
+	}
+	if err := s.conn.ExportWithMap(player, playerMethods, objectPath, playerIface); err != nil {
+		return fmt.Errorf("mpris: export %s: %w", playerIface, err)
+	}
+
+	props, err := prop.Export(s.conn, objectPath, prop.Map{
+		rootIface: {
+			"CanQuit":             constProp(true),
+			"CanRaise":            constProp(false),
+			"HasTrackList":        constProp(false),
+			"Identity":            constProp("vgmtui"),
+			"SupportedUriSchemes": constProp([]string{}),
+			"SupportedMimeTypes":  constProp([]string{}),
+		},
+		playerIface: {
+			"PlaybackStatus": {Value: "Stopped", Emit: prop.EmitTrue},
+			"Metadata":       {Value: map[string]dbus.Variant{"mpris:trackid": dbus.MakeVariant(noTrack)}, Emit: prop.EmitTrue},
+			"Position":       {Value: int64(0), Emit: prop.EmitFalse},
+			"Volume":         {Value: 1.0, Emit: prop.EmitTrue},
+			"Rate":           constProp(1.0),
+			"MinimumRate":    constProp(1.0),
+			"MaximumRate":    constProp(1.0),
+			"CanGoNext":      constProp(true),
+			"CanGoPrevious":  constProp(true),
+			"CanPlay":        constProp(true),
+			"CanPause":       constProp(true),
+			"CanSeek":        constProp(true),
+			"CanControl":     constProp(true),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("mpris: export properties: %w", err)
+	}
+	s.props = props
+
+	node := &introspect.Node{
+		Name: string(objectPath),
+		Interfaces: []introspect.Interface{
+			introspect.IntrospectData,
+			prop.IntrospectData,
+			{
+				Name:       rootIface,
+				Methods:    introspect.Methods(root),
+				Properties: props.Introspection(rootIface),
+			},
+			{
+				Name:       playerIface,
+				Methods:    renameMethods(introspect.Methods(player), playerMethods),
+				Properties: props.Introspection(playerIface),
+				Signals: []introspect.Signal{{
+					Name: "Seeked",
+					Args: []introspect.Arg{{Name: "Position", Type: "x"}},
+				}},
+			},
+		},
+	}
+	if err := s.conn.Export(introspect.NewIntrospectable(node), objectPath,
+		"org.freedesktop.DBus.Introspectable"); err != nil {
+		return fmt.Errorf("mpris: export introspection: %w", err)
+	}
+	return nil
+}
+
+// renameMethods returns methods with their names mapped as by
+// ExportWithMap, so introspection matches what is exported.
+func renameMethods(methods []introspect.Method, names map[string]string) []introspect.Method {
+	for i, m := range methods {
+		if name, ok := names[m.Name]; ok {
+			methods[i].Name = name
+		}
+	}
+	return methods
+}
+
+// constProp returns a read-only property that never changes.
+func constProp(v any) *prop.Prop {
+	return &prop.Prop{Value: v, Emit: prop.EmitConst}
+}
+
+// Attach sets the function used to deliver bus requests to the TUI,
+// typically (*tea.Program).Send.
+func (s *Server) Attach(send func(tea.Msg)) {
+	s.mu.Lock()
+	s.send = send
+	s.mu.Unlock()
+}
+
+// Update publishes a playback snapshot. It matches ui.Observer and only
+// emits change signals for properties that actually changed.
+func (s *Server) Update(np ui.NowPlaying) {
+	s.mu.Lock()
+	s.state = np.State
+	s.pos = np.Position
+	id := trackID(np.Track)
+	trackChanged := id != s.trackID
+	s.trackID = id
+	s.mu.Unlock()
+
+	s.setIfChanged(playerIface, "PlaybackStatus", playbackStatus(np.State))
+	s.setIfChanged(playerIface, "Volume", np.Volume)
+	if trackChanged {
+		s.props.SetMust(playerIface, "Metadata", metadata(id, np))
+	}
+	s.props.SetMust(playerIface, "Position", np.Position.Microseconds())
+}
+
+// setIfChanged sets a property only when its value differs, so clients
+// are not flooded with PropertiesChanged signals on every tick.
+func (s *Server) setIfChanged(iface, name string, v any) {
+	if cur, err := s.props.Get(iface, name); err == nil && cur.Value() == v {
+		return
+	}
+	s.props.SetMust(iface, name, v)
+}
+
+// Close releases the bus name and closes the connection.
+func (s *Server) Close() error {
+	s.conn.ReleaseName(busName)
+	return s.conn.Close()
+}
+
+// dispatch forwards a message to the TUI if one is attached.
+func (s *Server) dispatch(msg tea.Msg) {
+	s.mu.Lock()
+	send := s.send
+	s.mu.Unlock()
+	if send != nil {
+		send(msg)
+	}
+}
+
+// playbackStatus maps a play state to its MPRIS name.
+func playbackStatus(state ui.PlayState) string {
+	switch state {
+	case ui.StatePlaying, ui.StateFading:
+		return "Playing"
+	case ui.StatePaused:
+		return "Paused"
+	default:
+		return "Stopped"
+	}
+}
+
+// trackID derives a stable object path for a track from its file path.
+func trackID(t *ui.Track) dbus.ObjectPath {
+	if t == nil {
+		return noTrack
+	}
+	sum := sha1.Sum([]byte(t.Path))
+	return dbus.ObjectPath("/org/vgmtui/track/" + hex.EncodeToString(sum[:8]))
+}
+
+// metadata builds the MPRIS metadata map for the current track.
+func metadata(id dbus.ObjectPath, np ui.NowPlaying) map[string]dbus.Variant {
+	md := map[string]dbus.Variant{"mpris:trackid": dbus.MakeVariant(id)}
+	t := np.Track
+	if t == nil {
+		return md
+	}
+	if np.Duration > 0 {
+		md["mpris:length"] = dbus.MakeVariant(np.Duration.Microseconds())
+	}
+	if t.Title != "" {
+		md["xesam:title"] = dbus.MakeVariant(t.Title)
+	}
+	if t.Game != "" {
+		md["xesam:album"] = dbus.MakeVariant(t.Game)
+	}
+	if t.Composer != "" {
+		md["xesam:artist"] = dbus.MakeVariant([]string{t.Composer})
+	}
+	if t.TrackNumber > 0 {
+		md["xesam:trackNumber"] = dbus.MakeVariant(int32(t.TrackNumber))
+	}
+	md["xesam:url"] = dbus.MakeVariant((&url.URL{Scheme: "file", Path: t.Path}).String())
+	return md
+}
+
+// rootObject implements org.mpris.MediaPlayer2.
+type rootObject struct{ s *Server }
+
+// Raise is a no-op: a terminal application cannot raise its window.
+func (r rootObject) Raise() *dbus.Error { return nil }
+
+// Quit exits vgmtui.
+func (r rootObject) Quit() *dbus.Error {
+	r.s.dispatch(tea.QuitMsg{})
+	return nil
+}
+
+// playerObject implements org.mpris.MediaPlayer2.Player.
+type playerObject struct{ s *Server }
+
+func (p playerObject) Next() *dbus.Error {
+	p.s.dispatch(ui.NextTrackMsg{})
+	return nil
+}
+
+func (p playerObject) Previous() *dbus.Error {
+	p.s.dispatch(ui.PrevTrackMsg{})
+	return nil
+}
+
+func (p playerObject) PlayPause() *dbus.Error {
+	p.s.dispatch(ui.PlayPauseMsg{})
+	return nil
+}
+
+// Play starts or resumes playback; it does nothing while already playing.
+func (p playerObject) Play() *dbus.Error {
+	if p.state() != ui.StatePlaying {
+		p.s.dispatch(ui.PlayPauseMsg{})
+	}
+	return nil
+}
+
+// Pause pauses playback; it does nothing unless playing.
+func (p playerObject) Pause() *dbus.Error {
+	if p.state() == ui.StatePlaying {
+		p.s.dispatch(ui.PlayPauseMsg{})
+	}
+	return nil
+}
+
+func (p playerObject) Stop() *dbus.Error {
+	p.s.dispatch(ui.StopMsg{})
+	return nil
+}
+
+// SeekBy moves by offset microseconds relative to the current position.
+// It is exported on the bus as Seek.
+func (p playerObject) SeekBy(offset int64) *dbus.Error {
+	delta := time.Duration(offset) * time.Microsecond
+	p.s.dispatch(ui.SeekMsg{Delta: delta})
+	p.emitSeeked(delta)
+	return nil
+}
+
+// SetPosition seeks to an absolute position, ignoring stale track ids.
+func (p playerObject) SetPosition(id dbus.ObjectPath, position int64) *dbus.Error {
+	p.s.mu.Lock()
+	current, pos := p.s.trackID, p.s.pos
+	p.s.mu.Unlock()
+	if id != current || position < 0 {
+		return nil
+	}
+	delta := time.Duration(position)*time.Microsecond - pos
+	p.s.dispatch(ui.SeekMsg{Delta: delta})
+	p.emitSeeked(delta)
+	return nil
+}
+
+// OpenUri is unsupported; SupportedUriSchemes is empty.
+func (p playerObject) OpenUri(uri string) *dbus.Error {
+	return dbus.MakeFailedError(fmt.Errorf("opening URIs is not supported"))
+}
+
+// state returns the last published play state.
+func (p playerObject) state() ui.PlayState {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+	return p.s.state
+}
+
+// emitSeeked signals the expected position after a seek.
+func (p playerObject) emitSeeked(delta time.Duration) {
+	p.s.mu.Lock()
+	pos := max(p.s.pos+delta, 0)
+	p.s.mu.Unlock()
+	p.s.conn.Emit(objectPath, playerIface+".Seeked", pos.Microseconds())
+}
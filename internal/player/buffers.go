@@ -0,0 +1,70 @@
+package player
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Limits for the audio output buffers.
+const (
+	MinBufferTime  = time.Millisecond
+	MaxBufferTime  = 200 * time.Millisecond
+	MinBufferCount = 2
+	MaxBufferCount = 32
+)
+
+// Buffers sizes the audio output buffers. The driver keeps Count buffers
+// of Time each queued, so larger or more buffers ride out a slow system
+// without stutter at the cost of latency (Time * Count).
+type Buffers struct {
+	Time  time.Duration // Length of one buffer
+	Count int
+}
+
+// DefaultBuffers returns the default buffers, tuned for low latency.
+func DefaultBuffers() Buffers {
+	return Buffers{
+		Time:  AudioBufferTimeUsec * time.Microsecond,
+		Count: AudioBufferCount,
+	}
+}
+
+// Validate returns an error if the buffers are outside the supported
+// limits.
+func (b Buffers) Validate() error {
+	if b.Time < MinBufferTime || b.Time > MaxBufferTime {
+		return fmt.Errorf("audio buffer time %v is outside %v-%v", b.Time, MinBufferTime, MaxBufferTime)
+	}
+	if b.Count < MinBufferCount || b.Count > MaxBufferCount {
+		return fmt.Errorf("audio buffer count %d is outside %d-%d", b.Count, MinBufferCount, MaxBufferCount)
+	}
+	return nil
+}
+
+// String returns the buffers as e.g. "8 x 10ms".
+func (b Buffers) String() string {
+	return fmt.Sprintf("%d x %v", b.Count, b.Time)
+}
+
+// SetBuffers resizes the audio output buffers, reopening the audio device.
+// Playback carries on from where it was.
+func (p *AudioPlayer) SetBuffers(b Buffers) error {
+	if err := b.Validate(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.audioDriver.SetBufferTime(uint32(b.Time / time.Microsecond))
+	p.audioDriver.SetBufferCount(uint32(b.Count))
+	if err := p.audioDriver.Restart(); err != nil {
+		return fmt.Errorf("failed to restart audio driver: %w", err)
+	}
+	// Starting the driver unpauses it
+	if atomic.LoadUint32(&p.playingAtomic) == 0 || atomic.LoadUint32(&p.pausedAtomic) == 1 {
+		p.audioDriver.Pause()
+	}
+	return nil
+}
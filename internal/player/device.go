@@ -0,0 +1,68 @@
+package player
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// How long the audio device may go without asking for audio while playing
+// before it is taken to be lost, and how often to try reopening it while
+// that fails.
+const (
+	deviceStallTimeout  = time.Second
+	deviceRetryInterval = 2 * time.Second
+)
+
+// deviceWatch notices when the audio device stops asking for audio, as
+// when it is unplugged or the sound server restarts. It is owned by
+// tickLoop.
+type deviceWatch struct {
+	fills     uint32    // Buffer fills last seen
+	lastFill  time.Time // When the fill count last moved
+	lastRetry time.Time // When reopening the device was last tried
+}
+
+// SetPauseOnDeviceLoss sets whether playback pauses when the audio device
+// is lost and reopened, instead of carrying on through whichever device is
+// the default now (such as the laptop speakers after headphones are
+// unplugged).
+func (p *AudioPlayer) SetPauseOnDeviceLoss(on bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pauseOnLoss = on
+}
+
+// checkDevice reopens the audio device if it has stopped asking for audio
+// while playing. The bound player is kept, so playback carries on from
+// where it was, or is paused there if SetPauseOnDeviceLoss is on.
+func (p *AudioPlayer) checkDevice(w *deviceWatch, now time.Time) {
+	fills := p.audioDriver.Fills()
+	if fills != w.fills || w.lastFill.IsZero() || atomic.LoadUint32(&p.pausedAtomic) == 1 {
+		// Still running, or paused, which stops the device on purpose
+		w.fills = fills
+		w.lastFill = now
+		return
+	}
+	if now.Sub(w.lastFill) < deviceStallTimeout || now.Sub(w.lastRetry) < deviceRetryInterval {
+		return
+	}
+	w.lastRetry = now
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if atomic.LoadUint32(&p.playingAtomic) == 0 {
+		return
+	}
+	if err := p.audioDriver.Restart(); err != nil {
+		atomic.StoreUint32(&p.deviceLost, 1)
+		return
+	}
+	if p.pauseOnLoss {
+		p.pauseLocked()
+	}
+	atomic.StoreUint32(&p.deviceLost, 0)
+	atomic.AddUint32(&p.deviceRestarts, 1)
+	w.lastFill = now
+}
@@ -0,0 +1,32 @@
+package player
+
+import "time"
+
+// fadeInGain returns the gain (0.0 - 1.0) at playback time t into a linear
+// fade-in lasting fadeIn. A zero fade-in always has full gain.
+func fadeInGain(t, fadeIn time.Duration) float64 {
+	if fadeIn <= 0 || t >= fadeIn {
+		return 1
+	}
+	if t <= 0 {
+		return 0
+	}
+	return float64(t) / float64(fadeIn)
+}
+
+// applyFadeIn scales rendered stereo frames (L, R, L, R, ...) by the fade-in
+// gain. start is the playback time of the first frame.
+func applyFadeIn(buffer []int16, frames uint32, sampleRate uint32, start, fadeIn time.Duration) {
+	if sampleRate == 0 {
+		return
+	}
+	for i := uint32(0); i < frames; i++ {
+		t := start + time.Duration(i)*time.Second/time.Duration(sampleRate)
+		gain := fadeInGain(t, fadeIn)
+		if gain >= 1 {
+			return // The rest of the buffer is past the fade-in
+		}
+		buffer[i*2] = int16(float64(buffer[i*2]) * gain)
+		buffer[i*2+1] = int16(float64(buffer[i*2+1]) * gain)
+	}
+}
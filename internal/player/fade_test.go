@@ -0,0 +1,88 @@
+package player
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFadeInGain(t *testing.T) {
+	const fadeIn = time.Second
+	tests := []struct {
+		t, fadeIn time.Duration
+		want      float64
+	}{
+		{0, 0, 1}, // No fade-in
+		{500 * time.Millisecond, 0, 1},
+		{-time.Second, -time.Second, 1},
+		{0, fadeIn, 0}, // Track start
+		{-time.Millisecond, fadeIn, 0},
+		{250 * time.Millisecond, fadeIn, 0.25},
+		{500 * time.Millisecond, fadeIn, 0.5},
+		{999 * time.Millisecond, fadeIn, 0.999},
+		{fadeIn, fadeIn, 1}, // End of the window
+		{fadeIn + time.Millisecond, fadeIn, 1},
+		{time.Minute, fadeIn, 1},
+	}
+	for _, tt := range tests {
+		if got := fadeInGain(tt.t, tt.fadeIn); got != tt.want {
+			t.Errorf("fadeInGain(%v, %v) = %v, want %v", tt.t, tt.fadeIn, got, tt.want)
+		}
+	}
+}
+
+func TestApplyFadeIn(t *testing.T) {
+	// At 4 frames per second each frame is 250ms further into the fade-in
+	const sampleRate = 4
+	tests := []struct {
+		start, fadeIn time.Duration
+		want          []int16 // Left channel; the right is its negative
+	}{
+		{0, 0, []int16{1000, 1000, 1000, 1000}},
+		{0, time.Second, []int16{0, 250, 500, 750}},
+		{500 * time.Millisecond, time.Second, []int16{500, 750, 1000, 1000}},
+		{0, 2 * time.Second, []int16{0, 125, 250, 375}},
+		{time.Second, time.Second, []int16{1000, 1000, 1000, 1000}},
+	}
+	for _, tt := range tests {
+		buffer := make([]int16, 0, 8)
+		for range 4 {
+			buffer = append(buffer, 1000, -1000)
+		}
+		applyFadeIn(buffer, 4, sampleRate, tt.start, tt.fadeIn)
+
+		var left []int16
+		for i := 0; i < len(buffer); i += 2 {
+			left = append(left, buffer[i])
+			if buffer[i+1] != -buffer[i] {
+				t.Errorf("start %v, fade-in %v: frame %d is %d, %d; channels scaled apart",
+					tt.start, tt.fadeIn, i/2, buffer[i], buffer[i+1])
+			}
+		}
+		if !slices.Equal(left, tt.want) {
+			t.Errorf("start %v, fade-in %v: frames %v, want %v", tt.start, tt.fadeIn, left, tt.want)
+		}
+	}
+}
+
+func TestPlaybackTime(t *testing.T) {
+	tests := []struct {
+		speed     float64
+		pos, want time.Duration
+	}{
+		{1, time.Second, time.Second},
+		{2, time.Second, 500 * time.Millisecond}, // File time runs ahead
+		{0.5, time.Second, 2 * time.Second},
+		{0, time.Second, 2 * time.Second}, // Ignored: the last speed stays
+	}
+	var p LibvgmPlayer
+	if got := p.playbackTime(time.Second); got != time.Second {
+		t.Errorf("playback time of 1s before any speed is set = %v, want 1s", got)
+	}
+	for _, tt := range tests {
+		p.SetSpeed(tt.speed)
+		if got := p.playbackTime(tt.pos); got != tt.want {
+			t.Errorf("at speed %v: playback time of %v = %v, want %v", tt.speed, tt.pos, got, tt.want)
+		}
+	}
+}
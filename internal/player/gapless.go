@@ -0,0 +1,117 @@
+package player
+
+import "fmt"
+
+// SetGapless turns gapless playback on or off. When on, a second libvgm
+// player is kept so the next track can be loaded and started with Queue
+// while the current one plays; the audio driver switches to it as soon as
+// the current track finishes. This roughly doubles the memory used for
+// emulation and loaded files.
+func (p *AudioPlayer) SetGapless(on bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !on {
+		if p.next != nil {
+			p.clearQueueLocked()
+			p.next.Close()
+			p.next = nil
+		}
+		return nil
+	}
+	if p.next != nil {
+		return nil
+	}
+
+	vgm, err := NewLibvgmPlayer()
+	if err != nil {
+		return fmt.Errorf("failed to create libvgm player: %w", err)
+	}
+	vgm.SetSampleRate(uint32(p.sampleRate))
+	vgm.SetLoopCount(uint32(p.loopCount))
+	vgm.SetFadeTime(p.endFadeTime())
+	vgm.SetEndSilence(DefaultEndSilence)
+	vgm.SetVolume(p.volume)
+	vgm.SetSpeed(p.speed)
+	vgm.SetFadeIn(p.fadeIn)
+	p.next = vgm
+	return nil
+}
+
+// Gapless returns true if gapless playback is on.
+func (p *AudioPlayer) Gapless() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.next != nil
+}
+
+// Queue loads and starts the track to play after the current one, so
+// playback moves on to it without reloading. PlaybackInfo.TrackChanges
+// counts each time that happens. A track queued earlier is replaced.
+func (p *AudioPlayer) Queue(path string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.next == nil {
+		return fmt.Errorf("gapless playback is off")
+	}
+	p.clearQueueLocked()
+
+	if err := p.next.Load(path); err != nil {
+		return err
+	}
+	if err := p.next.Start(); err != nil {
+		p.next.Unload()
+		return err
+	}
+
+	// Chip cores are only known once started
+	track := p.next.GetTrack(path)
+	p.nextTrack = &track
+	p.nextPath = path
+	p.audioDriver.QueuePlayer(p.next)
+	return nil
+}
+
+// Dequeue drops the queued track, so playback stops when the current
+// track finishes.
+func (p *AudioPlayer) Dequeue() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clearQueueLocked()
+}
+
+// clearQueueLocked drops the queued track (must be called with mu held).
+func (p *AudioPlayer) clearQueueLocked() {
+	if p.next == nil {
+		return
+	}
+	// Take the track off the driver first; it may have switched already
+	p.audioDriver.QueuePlayer(nil)
+	p.followSwitchLocked()
+	p.next.Unload()
+	p.nextTrack = nil
+	p.nextPath = ""
+}
+
+// followSwitchLocked makes the queued player current if the audio driver
+// has switched to it, and unloads the finished one (must be called with mu
+// held). It returns true if there was a switch.
+func (p *AudioPlayer) followSwitchLocked() bool {
+	n := p.audioDriver.Switches()
+	if n == p.switches || p.next == nil {
+		return false
+	}
+	p.switches = n
+
+	finished := p.vgm.Swap(p.next)
+	finished.Unload()
+	p.next = finished
+	p.track = p.nextTrack
+	p.trackPath = p.nextPath
+	p.nextTrack = nil
+	p.nextPath = ""
+	return true
+}
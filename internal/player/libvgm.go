@@ -10,9 +10,13 @@ package player
 import "C"
 import (
 	"errors"
+	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unsafe"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
 )
 
 // Error codes returned by libvgm
@@ -48,6 +52,10 @@ func codeToError(code C.int) error {
 type LibvgmPlayer struct {
 	handle *C.VgmPlayer
 	mu     sync.Mutex
+
+	// Fade-in at track start and playback speed, read lock-free by Render
+	fadeIn atomic.Int64  // time.Duration
+	speed  atomic.Uint64 // math.Float64bits of the speed
 }
 
 // NewLibvgmPlayer creates a new libvgm player instance.
@@ -100,6 +108,65 @@ func (p *LibvgmPlayer) SetFadeTime(ms uint32) {
 	}
 }
 
+// SetFadeIn sets the fade-in time at the start of each track (0 = none).
+// It applies to Render and to playback through a bound audio driver.
+func (p *LibvgmPlayer) SetFadeIn(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if d < 0 {
+		d = 0
+	}
+	p.fadeIn.Store(int64(d))
+	if p.handle != nil {
+		C.vgm_player_set_fade_in(p.handle, C.uint32_t(d.Milliseconds()))
+	}
+}
+
+// LoopCount returns the configured number of loops (0 = infinite).
+func (p *LibvgmPlayer) LoopCount() uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.handle == nil {
+		return 0
+	}
+	return uint32(C.vgm_player_get_loop_count(p.handle))
+}
+
+// FadeTime returns the configured fade-out time in milliseconds.
+func (p *LibvgmPlayer) FadeTime() uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.handle == nil {
+		return 0
+	}
+	return uint32(C.vgm_player_get_fade_time(p.handle))
+}
+
+// FadeIn returns the configured fade-in time at track start.
+func (p *LibvgmPlayer) FadeIn() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.handle == nil {
+		return 0
+	}
+	return time.Duration(C.vgm_player_get_fade_in(p.handle)) * time.Millisecond
+}
+
+// EndSilence returns the configured end silence time in milliseconds.
+func (p *LibvgmPlayer) EndSilence() uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.handle == nil {
+		return 0
+	}
+	return uint32(C.vgm_player_get_end_silence(p.handle))
+}
+
 // SetEndSilence sets the end silence time in milliseconds.
 func (p *LibvgmPlayer) SetEndSilence(ms uint32) {
 	p.mu.Lock()
@@ -125,12 +192,16 @@ func (p *LibvgmPlayer) SetSpeed(speed float64) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if speed > 0 {
+		p.speed.Store(math.Float64bits(speed))
+	}
 	if p.handle != nil {
 		C.vgm_player_set_speed(p.handle, C.double(speed))
 	}
 }
 
-// Load loads a VGM/VGZ/S98/DRO/GYM file.
+// Load loads a VGM/VGZ/S98/DRO/GYM file. Gzipped files (such as .vgm.gz)
+// are decompressed by libvgm's file loader as they are read.
 func (p *LibvgmPlayer) Load(path string) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -213,8 +284,26 @@ func (p *LibvgmPlayer) Render(frames uint32, buffer []int16) uint32 {
 		return 0
 	}
 
-	rendered := C.vgm_player_render(p.handle, C.uint32_t(frames), (*C.int16_t)(unsafe.Pointer(&buffer[0])))
-	return uint32(rendered)
+	// Past the fade-in nothing needs scaling
+	fadeIn := time.Duration(p.fadeIn.Load())
+	start := p.playbackTime(p.Position())
+	if fadeIn <= 0 || start >= fadeIn {
+		return p.RenderDirect(frames, buffer)
+	}
+
+	rendered := p.RenderDirect(frames, buffer)
+	applyFadeIn(buffer, rendered, p.SampleRate(), start, fadeIn)
+	return rendered
+}
+
+// playbackTime converts a position in the file to the time it takes to
+// play there, which is shorter or longer when not at normal speed.
+func (p *LibvgmPlayer) playbackTime(pos time.Duration) time.Duration {
+	speed := math.Float64frombits(p.speed.Load())
+	if speed <= 0 {
+		return pos
+	}
+	return time.Duration(float64(pos) / speed)
 }
 
 // RenderDirect renders audio samples directly to a buffer without any Go-side processing.
@@ -227,6 +316,21 @@ func (p *LibvgmPlayer) RenderDirect(frames uint32, buffer []int16) uint32 {
 	return uint32(C.vgm_player_render(p.handle, C.uint32_t(frames), (*C.int16_t)(unsafe.Pointer(&buffer[0]))))
 }
 
+// ScopeFrames is the number of stereo frames kept by the scope tap.
+const ScopeFrames = C.VGM_SCOPE_FRAMES
+
+// Scope copies the most recently rendered stereo frames into buffer
+// (L, R, L, R, ..., oldest first) and returns the number of frames copied.
+// This is a lock-free query - the render path publishes into a ring buffer
+// without ever waiting on readers.
+func (p *LibvgmPlayer) Scope(buffer []int16) int {
+	frames := len(buffer) / 2
+	if p.handle == nil || frames == 0 {
+		return 0
+	}
+	return int(C.vgm_player_get_scope(p.handle, (*C.int16_t)(unsafe.Pointer(&buffer[0])), C.uint32_t(frames)))
+}
+
 // IsPlaying returns true if playback is active.
 // This is a lock-free query - libvgm's state is internally consistent.
 func (p *LibvgmPlayer) IsPlaying() bool {
@@ -436,25 +540,71 @@ func (p *LibvgmPlayer) ChipCore(index uint32) string {
 	return C.GoString(C.vgm_player_get_chip_core(p.handle, C.uint32_t(index)))
 }
 
+// ChipClock returns the clock rate in Hz for a chip (0 if unknown).
+func (p *LibvgmPlayer) ChipClock(index uint32) uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.handle == nil {
+		return 0
+	}
+	return uint32(C.vgm_player_get_chip_clock(p.handle, C.uint32_t(index)))
+}
+
+// ChipMuted returns true if a chip is muted.
+func (p *LibvgmPlayer) ChipMuted(index uint32) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.handle == nil {
+		return false
+	}
+	return C.vgm_player_get_chip_muted(p.handle, C.uint32_t(index)) != 0
+}
+
+// gd3Tag returns the tag with the given libvgm name from a loaded file,
+// or "" if the file doesn't have it.
+func gd3Tag(handle *C.VgmPlayer, name string) string {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return C.GoString(C.vgm_player_get_tag(handle, cname))
+}
+
+// readTags fills in track's tags from a loaded file, with the title, game,
+// system and composer in the preferred language.
+func readTags(handle *C.VgmPlayer, track *metadata.Track) {
+	track.English = metadata.Tags{
+		Title:    gd3Tag(handle, "TITLE"),
+		Game:     gd3Tag(handle, "GAME"),
+		System:   gd3Tag(handle, "SYSTEM"),
+		Composer: gd3Tag(handle, "ARTIST"),
+	}
+	track.Original = metadata.Tags{
+		Title:    gd3Tag(handle, "TITLE-JPN"),
+		Game:     gd3Tag(handle, "GAME-JPN"),
+		System:   gd3Tag(handle, "SYSTEM-JPN"),
+		Composer: gd3Tag(handle, "ARTIST-JPN"),
+	}
+	track.Date = C.GoString(C.vgm_player_get_date(handle))
+	track.VGMBy = C.GoString(C.vgm_player_get_vgm_by(handle))
+	track.Notes = C.GoString(C.vgm_player_get_notes(handle))
+	track.Format = C.GoString(C.vgm_player_get_format(handle))
+	track.CleanTags()
+	track.UseLanguage()
+}
+
 // GetTrack returns a Track struct with all metadata.
-func (p *LibvgmPlayer) GetTrack(path string) Track {
+func (p *LibvgmPlayer) GetTrack(path string) metadata.Track {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	track := Track{Path: path}
+	track := metadata.Track{Path: path}
 
 	if p.handle == nil {
 		return track
 	}
 
-	track.Title = C.GoString(C.vgm_player_get_title(p.handle))
-	track.Game = C.GoString(C.vgm_player_get_game(p.handle))
-	track.System = C.GoString(C.vgm_player_get_system(p.handle))
-	track.Composer = C.GoString(C.vgm_player_get_composer(p.handle))
-	track.Date = C.GoString(C.vgm_player_get_date(p.handle))
-	track.VGMBy = C.GoString(C.vgm_player_get_vgm_by(p.handle))
-	track.Notes = C.GoString(C.vgm_player_get_notes(p.handle))
-	track.Format = C.GoString(C.vgm_player_get_format(p.handle))
+	readTags(p.handle, &track)
 
 	// Duration
 	seconds := float64(C.vgm_player_get_duration(p.handle))
@@ -465,15 +615,18 @@ func (p *LibvgmPlayer) GetTrack(path string) Track {
 	if track.HasLoop {
 		loopSeconds := float64(C.vgm_player_get_loop_point(p.handle))
 		track.LoopPoint = time.Duration(loopSeconds * float64(time.Second))
+		lengthSeconds := float64(C.vgm_player_get_loop_length(p.handle))
+		track.LoopLength = time.Duration(lengthSeconds * float64(time.Second))
 	}
 
 	// Chips
 	chipCount := uint32(C.vgm_player_get_chip_count(p.handle))
-	track.Chips = make([]ChipInfo, chipCount)
+	track.Chips = make([]metadata.ChipInfo, chipCount)
 	for i := uint32(0); i < chipCount; i++ {
-		track.Chips[i] = ChipInfo{
-			Name: C.GoString(C.vgm_player_get_chip_name(p.handle, C.uint32_t(i))),
-			Core: C.GoString(C.vgm_player_get_chip_core(p.handle, C.uint32_t(i))),
+		track.Chips[i] = metadata.ChipInfo{
+			Name:  C.GoString(C.vgm_player_get_chip_name(p.handle, C.uint32_t(i))),
+			Core:  C.GoString(C.vgm_player_get_chip_core(p.handle, C.uint32_t(i))),
+			Clock: uint32(C.vgm_player_get_chip_clock(p.handle, C.uint32_t(i))),
 		}
 	}
 
@@ -522,8 +675,8 @@ func (p *LibvgmPlayer) GetPlaybackInfo() PlaybackInfo {
 // ReadTrackMetadata reads track metadata from a file without affecting any
 // existing player state. This creates a temporary player instance just for
 // reading metadata, so it can be used while playback is active.
-func ReadTrackMetadata(path string) (Track, error) {
-	track := Track{Path: path}
+func ReadTrackMetadata(path string) (metadata.Track, error) {
+	track := metadata.Track{Path: path}
 
 	// Create a temporary player
 	handle := C.vgm_player_create()
@@ -541,14 +694,7 @@ func ReadTrackMetadata(path string) (Track, error) {
 	}
 
 	// Read metadata
-	track.Title = C.GoString(C.vgm_player_get_title(handle))
-	track.Game = C.GoString(C.vgm_player_get_game(handle))
-	track.System = C.GoString(C.vgm_player_get_system(handle))
-	track.Composer = C.GoString(C.vgm_player_get_composer(handle))
-	track.Date = C.GoString(C.vgm_player_get_date(handle))
-	track.VGMBy = C.GoString(C.vgm_player_get_vgm_by(handle))
-	track.Notes = C.GoString(C.vgm_player_get_notes(handle))
-	track.Format = C.GoString(C.vgm_player_get_format(handle))
+	readTags(handle, &track)
 
 	// Duration
 	seconds := float64(C.vgm_player_get_duration(handle))
@@ -559,15 +705,18 @@ func ReadTrackMetadata(path string) (Track, error) {
 	if track.HasLoop {
 		loopSeconds := float64(C.vgm_player_get_loop_point(handle))
 		track.LoopPoint = time.Duration(loopSeconds * float64(time.Second))
+		lengthSeconds := float64(C.vgm_player_get_loop_length(handle))
+		track.LoopLength = time.Duration(lengthSeconds * float64(time.Second))
 	}
 
 	// Chip info (now available after load)
 	chipCount := uint32(C.vgm_player_get_chip_count(handle))
-	track.Chips = make([]ChipInfo, chipCount)
+	track.Chips = make([]metadata.ChipInfo, chipCount)
 	for i := uint32(0); i < chipCount; i++ {
-		track.Chips[i] = ChipInfo{
-			Name: C.GoString(C.vgm_player_get_chip_name(handle, C.uint32_t(i))),
-			Core: C.GoString(C.vgm_player_get_chip_core(handle, C.uint32_t(i))),
+		track.Chips[i] = metadata.ChipInfo{
+			Name:  C.GoString(C.vgm_player_get_chip_name(handle, C.uint32_t(i))),
+			Core:  C.GoString(C.vgm_player_get_chip_core(handle, C.uint32_t(i))),
+			Clock: uint32(C.vgm_player_get_chip_clock(handle, C.uint32_t(i))),
 		}
 	}
 
@@ -793,6 +942,73 @@ func (d *AudioDriver) Resume() error {
 	return audioCodeToError(ret)
 }
 
+// Name returns the name of the driver, e.g. "PulseAudio".
+func (d *AudioDriver) Name() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.handle == nil {
+		return ""
+	}
+	id := C.vgm_audio_driver_get_id(d.handle)
+	return C.GoString(C.vgm_audio_get_driver_name(id))
+}
+
+// SampleRate returns the configured output sample rate in Hz.
+func (d *AudioDriver) SampleRate() uint32 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.handle == nil {
+		return 0
+	}
+	return uint32(C.vgm_audio_driver_get_sample_rate(d.handle))
+}
+
+// Channels returns the configured number of output channels.
+func (d *AudioDriver) Channels() uint8 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.handle == nil {
+		return 0
+	}
+	return uint8(C.vgm_audio_driver_get_channels(d.handle))
+}
+
+// Bits returns the configured bits per sample.
+func (d *AudioDriver) Bits() uint8 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.handle == nil {
+		return 0
+	}
+	return uint8(C.vgm_audio_driver_get_bits(d.handle))
+}
+
+// BufferTime returns the configured buffer time in microseconds.
+func (d *AudioDriver) BufferTime() uint32 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.handle == nil {
+		return 0
+	}
+	return uint32(C.vgm_audio_driver_get_buffer_time(d.handle))
+}
+
+// BufferCount returns the configured number of buffers.
+func (d *AudioDriver) BufferCount() uint32 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.handle == nil {
+		return 0
+	}
+	return uint32(C.vgm_audio_driver_get_buffer_count(d.handle))
+}
+
 // GetLatency returns the current latency in milliseconds.
 func (d *AudioDriver) GetLatency() uint32 {
 	d.mu.Lock()
@@ -829,6 +1045,50 @@ func (d *AudioDriver) UnbindPlayer() {
 	}
 }
 
+// QueuePlayer queues a started player to take over from the bound player
+// as soon as it finishes, without a gap. The queued player then becomes the
+// bound player. A nil player clears the queue.
+func (d *AudioDriver) QueuePlayer(player *LibvgmPlayer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.handle == nil {
+		return
+	}
+	var h *C.VgmPlayer
+	if player != nil {
+		h = player.handle
+	}
+	C.vgm_audio_driver_queue_player(d.handle, h)
+}
+
+// Switches returns how many times the driver has switched to a queued
+// player. This is a lock-free query.
+func (d *AudioDriver) Switches() uint32 {
+	if d.handle == nil {
+		return 0
+	}
+	return uint32(C.vgm_audio_driver_get_switches(d.handle))
+}
+
+// Fills returns how many buffers the audio device has asked for. It stops
+// advancing if the device goes away while playing. This is a lock-free
+// query.
+func (d *AudioDriver) Fills() uint32 {
+	if d.handle == nil {
+		return 0
+	}
+	return uint32(C.vgm_audio_driver_get_fills(d.handle))
+}
+
+// Restart closes and reopens the default audio device, keeping the bound
+// player, e.g. after the device was lost.
+func (d *AudioDriver) Restart() error {
+	// Stopping a device that has gone away may fail; open it again anyway
+	d.Stop()
+	return d.Start(0)
+}
+
 // SafeSeek seeks to a position (thread-safe, acquires render mutex).
 func (d *AudioDriver) SafeSeek(pos time.Duration) {
 	d.mu.Lock()
@@ -859,3 +1119,17 @@ func (d *AudioDriver) SafeFadeOut() {
 		C.vgm_audio_safe_fade_out(d.handle)
 	}
 }
+
+// SafeSetChipMuted mutes or unmutes a chip (thread-safe, acquires render mutex).
+func (d *AudioDriver) SafeSetChipMuted(index uint32, muted bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.handle != nil {
+		var m C.int
+		if muted {
+			m = 1
+		}
+		C.vgm_audio_safe_set_chip_muted(d.handle, C.uint32_t(index), m)
+	}
+}
@@ -0,0 +1,33 @@
+package player
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetFadeIn(t *testing.T) {
+	tests := []struct {
+		set, want time.Duration
+	}{
+		{500 * time.Millisecond, 500 * time.Millisecond},
+		{0, 0},
+		{-time.Second, 0}, // Negative means none
+		{1500 * time.Microsecond, time.Millisecond}, // libvgm keeps milliseconds
+	}
+	p, err := NewLibvgmPlayer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	for _, tt := range tests {
+		p.SetFadeIn(tt.set)
+		if got := p.FadeIn(); got != tt.want {
+			t.Errorf("SetFadeIn(%v): FadeIn() = %v, want %v", tt.set, got, tt.want)
+		}
+	}
+
+	p.Close()
+	if got := p.FadeIn(); got != 0 {
+		t.Errorf("FadeIn() after Close = %v, want 0", got)
+	}
+}
@@ -0,0 +1,11 @@
+package player
+
+import "github.com/dewi-tim/vgmtui/internal/metadata"
+
+// LibvgmMetadata reads metadata with libvgm, using a temporary player so
+// playback is not affected (see ReadTrackMetadata).
+var LibvgmMetadata metadata.Reader = metadata.ReaderFunc(ReadTrackMetadata)
+
+// DefaultMetadata is the reader used unless another is set: libvgm, with
+// tags overridden by sidecar files.
+var DefaultMetadata = metadata.WithSidecars(LibvgmMetadata)
@@ -0,0 +1,65 @@
+package player
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// writeTestVGM writes a one second VGM 1.50 file using an SN76489 and a
+// YM2612 at the given clocks, holding no sound data.
+func writeTestVGM(t *testing.T, snClock, ymClock uint32) string {
+	t.Helper()
+	data := make([]byte, 0x40)
+	copy(data, "Vgm ")
+	binary.LittleEndian.PutUint32(data[0x08:], 0x150)     // Version
+	binary.LittleEndian.PutUint32(data[0x0C:], snClock)   // SN76489 clock
+	binary.LittleEndian.PutUint32(data[0x18:], 44100)     // Total samples
+	binary.LittleEndian.PutUint16(data[0x28:], 0x0009)    // SN76489 noise feedback
+	data[0x2A] = 16                                       // SN76489 shift register width
+	binary.LittleEndian.PutUint32(data[0x2C:], ymClock)   // YM2612 clock
+	binary.LittleEndian.PutUint32(data[0x34:], 0x40-0x34) // Data offset
+	data = append(data, 0x61)                             // Wait 44100 samples
+	data = binary.LittleEndian.AppendUint16(data, 44100)
+	data = append(data, 0x66) // End of sound data
+	binary.LittleEndian.PutUint32(data[0x04:], uint32(len(data)-0x04))
+
+	path := filepath.Join(t.TempDir(), "clocks.vgm")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadTrackMetadataChipClocks(t *testing.T) {
+	path := writeTestVGM(t, 3579545, 7670453)
+	want := map[string]uint32{"SN76489": 3579545, "YM2612": 7670453}
+
+	readers := map[string]metadata.Reader{
+		"LibvgmMetadata":  LibvgmMetadata,
+		"DefaultMetadata": DefaultMetadata,
+	}
+	for name, r := range readers {
+		track, err := r.ReadTrackMetadata(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for chip, clock := range want {
+			i := slices.IndexFunc(track.Chips, func(c metadata.ChipInfo) bool {
+				return strings.Contains(c.Name, chip)
+			})
+			if i < 0 {
+				t.Errorf("%s: no %s among %+v", name, chip, track.Chips)
+				continue
+			}
+			if got := track.Chips[i].Clock; got != clock {
+				t.Errorf("%s: %s clock = %d, want %d", name, chip, got, clock)
+			}
+		}
+	}
+}
@@ -3,9 +3,12 @@ package player
 import (
 	"context"
 	"fmt"
+	"math"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
 )
 
 const (
@@ -52,13 +55,15 @@ type Player interface {
 
 	// SetVolume sets the volume (0.0 - 1.0+).
 	SetVolume(vol float64)
+	// SetFadeIn sets the fade-in time at the start of each track.
+	SetFadeIn(d time.Duration)
 	// SetSpeed sets the playback speed (0.5 - 2.0).
 	SetSpeed(speed float64)
 	// SetLoopCount sets the number of loops.
 	SetLoopCount(count int)
 
 	// Track returns metadata about the current track.
-	Track() *Track
+	Track() *metadata.Track
 	// Info returns current playback information.
 	Info() PlaybackInfo
 	// IsLoaded returns true if a track is loaded.
@@ -79,24 +84,39 @@ type AudioPlayer struct {
 	playingAtomic uint32 // 1 = playing, 0 = not
 	pausedAtomic  uint32 // 1 = paused, 0 = not
 
+	// Audio device recovery (see checkDevice)
+	deviceLost     uint32 // 1 = device lost and not reopened yet
+	deviceRestarts uint32 // Times the device has been reopened
+	pauseOnLoss    bool   // Pause after reopening a lost device (protected by mu)
+
 	// Mutex for non-hot-path operations (track loading, config changes)
 	mu sync.Mutex
 
-	// libvgm player
-	vgm *LibvgmPlayer
+	// libvgm player bound to the audio driver. Swapped when gapless
+	// playback moves on to the queued player, so it is read atomically.
+	vgm atomic.Pointer[LibvgmPlayer]
 
 	// libvgm audio driver (replaces oto)
 	audioDriver *AudioDriver
 
 	// Current track info
-	track     *Track
+	track     *metadata.Track
 	trackPath string
 
+	// Gapless playback (protected by mu): a second libvgm player the next
+	// track is loaded and started on ahead of time, queued on the driver
+	next      *LibvgmPlayer
+	nextTrack *metadata.Track
+	nextPath  string
+	switches  uint32 // Driver switches to the queued player seen so far
+
 	// Playback config (protected by mu)
 	volume    float64
 	speed     float64
 	loopCount int
 	sampleRate int
+	fadeIn    time.Duration
+	endFade   bool // Fade out after the last loop instead of cutting
 
 	// Render goroutine control
 	ctx    context.Context
@@ -108,6 +128,10 @@ type AudioPlayer struct {
 
 	// WaitGroup to track tickLoop goroutine
 	tickWg sync.WaitGroup
+
+	// Level meters: reused to read the scope tap on every poll
+	meterBuf []int16
+	meterMu  sync.Mutex
 }
 
 // selectAudioDriver finds the best available audio driver.
@@ -136,8 +160,18 @@ func selectAudioDriver() (uint32, error) {
 	return drivers[0].ID, nil
 }
 
-// NewAudioPlayer creates a new audio player.
+// NewAudioPlayer creates a new audio player with the default buffers.
 func NewAudioPlayer() (*AudioPlayer, error) {
+	return NewAudioPlayerWithBuffers(DefaultBuffers())
+}
+
+// NewAudioPlayerWithBuffers creates a new audio player whose output uses
+// the given buffers.
+func NewAudioPlayerWithBuffers(buffers Buffers) (*AudioPlayer, error) {
+	if err := buffers.Validate(); err != nil {
+		return nil, err
+	}
+
 	// Initialize libvgm audio system
 	if err := InitAudioSystem(); err != nil {
 		return nil, fmt.Errorf("failed to initialize audio system: %w", err)
@@ -161,8 +195,8 @@ func NewAudioPlayer() (*AudioPlayer, error) {
 	audioDriver.SetSampleRate(DefaultSampleRate)
 	audioDriver.SetChannels(DefaultChannels)
 	audioDriver.SetBits(DefaultBitDepth)
-	audioDriver.SetBufferTime(AudioBufferTimeUsec)
-	audioDriver.SetBufferCount(AudioBufferCount)
+	audioDriver.SetBufferTime(uint32(buffers.Time / time.Microsecond))
+	audioDriver.SetBufferCount(uint32(buffers.Count))
 
 	// Create libvgm player
 	vgm, err := NewLibvgmPlayer()
@@ -191,16 +225,17 @@ func NewAudioPlayer() (*AudioPlayer, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	p := &AudioPlayer{
-		vgm:         vgm,
 		audioDriver: audioDriver,
 		sampleRate:  DefaultSampleRate,
 		volume:      1.0,
 		speed:       1.0,
 		loopCount:   DefaultLoopCount,
+		endFade:     true,
 		ctx:         ctx,
 		cancel:      cancel,
 		subscribers: make(map[chan PlaybackInfo]struct{}),
 	}
+	p.vgm.Store(vgm)
 
 	// Configure libvgm
 	vgm.SetSampleRate(uint32(DefaultSampleRate))
@@ -223,15 +258,17 @@ func (p *AudioPlayer) Load(path string) error {
 	p.stopLocked()
 
 	// Unload previous track
-	p.vgm.Unload()
+	p.vgm.Load().Unload()
 
 	// Load new file
-	if err := p.vgm.Load(path); err != nil {
+	if err := p.vgm.Load().Load(path); err != nil {
 		return err
 	}
+	// A fade-out on the previous track may have set the fade time
+	p.vgm.Load().SetFadeTime(p.endFadeTime())
 
 	// Get track metadata
-	track := p.vgm.GetTrack(path)
+	track := p.vgm.Load().GetTrack(path)
 	p.track = &track
 	p.trackPath = path
 
@@ -244,7 +281,7 @@ func (p *AudioPlayer) Unload() {
 	defer p.mu.Unlock()
 
 	p.stopLocked()
-	p.vgm.Unload()
+	p.vgm.Load().Unload()
 	p.track = nil
 	p.trackPath = ""
 }
@@ -274,13 +311,15 @@ func (p *AudioPlayer) Stop() {
 }
 
 func (p *AudioPlayer) stopLocked() {
+	p.clearQueueLocked()
+
 	if atomic.LoadUint32(&p.playingAtomic) == 1 {
 		// Set atomic flags first
 		atomic.StoreUint32(&p.playingAtomic, 0)
 		atomic.StoreUint32(&p.pausedAtomic, 0)
 
 		// Stop libvgm (thread-safe via audio driver's mutex)
-		p.vgm.Stop()
+		p.vgm.Load().Stop()
 
 		// Pause audio output
 		p.audioDriver.Pause()
@@ -333,12 +372,12 @@ func (p *AudioPlayer) playLocked() error {
 	}
 
 	// Start libvgm playback
-	if err := p.vgm.Start(); err != nil {
+	if err := p.vgm.Load().Start(); err != nil {
 		return err
 	}
 
 	// Update track info with chip info (available after start)
-	track := p.vgm.GetTrack(p.trackPath)
+	track := p.vgm.Load().GetTrack(p.trackPath)
 	p.track = &track
 
 	// Set atomic state flags
@@ -366,7 +405,7 @@ func (p *AudioPlayer) Seek(pos time.Duration) {
 
 // SeekRelative seeks relative to current position.
 func (p *AudioPlayer) SeekRelative(delta time.Duration) {
-	current := p.vgm.Position()
+	current := p.vgm.Load().Position()
 	newPos := current + delta
 	if newPos < 0 {
 		newPos = 0
@@ -376,6 +415,8 @@ func (p *AudioPlayer) SeekRelative(delta time.Duration) {
 
 // FadeOut triggers a fade-out.
 func (p *AudioPlayer) FadeOut() {
+	// Fade even when tracks are set to cut at the end
+	p.vgm.Load().SetFadeTime(DefaultFadeTime)
 	p.audioDriver.SafeFadeOut()
 }
 
@@ -384,6 +425,23 @@ func (p *AudioPlayer) Reset() {
 	p.audioDriver.SafeReset()
 }
 
+// SetChipMuted mutes or unmutes a sound chip of the current track by index.
+// Every chip starts unmuted when a track is loaded.
+func (p *AudioPlayer) SetChipMuted(index int, muted bool) {
+	if index < 0 {
+		return
+	}
+	p.audioDriver.SafeSetChipMuted(uint32(index), muted)
+}
+
+// ChipMuted returns true if a sound chip of the current track is muted.
+func (p *AudioPlayer) ChipMuted(index int) bool {
+	if index < 0 {
+		return false
+	}
+	return p.vgm.Load().ChipMuted(uint32(index))
+}
+
 // SetVolume sets the volume (0.0 - 1.0+).
 func (p *AudioPlayer) SetVolume(vol float64) {
 	p.mu.Lock()
@@ -393,7 +451,26 @@ func (p *AudioPlayer) SetVolume(vol float64) {
 		vol = 0
 	}
 	p.volume = vol
-	p.vgm.SetVolume(vol)
+	p.vgm.Load().SetVolume(vol)
+	if p.next != nil {
+		p.next.SetVolume(vol)
+	}
+}
+
+// SetFadeIn sets how long the volume ramps up from silence at the start
+// of each track (0 disables the fade-in).
+func (p *AudioPlayer) SetFadeIn(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if d < 0 {
+		d = 0
+	}
+	p.fadeIn = d
+	p.vgm.Load().SetFadeIn(d)
+	if p.next != nil {
+		p.next.SetFadeIn(d)
+	}
 }
 
 // SetSpeed sets the playback speed (0.5 - 2.0).
@@ -408,7 +485,10 @@ func (p *AudioPlayer) SetSpeed(speed float64) {
 		speed = 8.0
 	}
 	p.speed = speed
-	p.vgm.SetSpeed(speed)
+	p.vgm.Load().SetSpeed(speed)
+	if p.next != nil {
+		p.next.SetSpeed(speed)
+	}
 }
 
 // SetLoopCount sets the number of loops.
@@ -420,11 +500,70 @@ func (p *AudioPlayer) SetLoopCount(count int) {
 		count = 0
 	}
 	p.loopCount = count
-	p.vgm.SetLoopCount(uint32(count))
+	p.vgm.Load().SetLoopCount(uint32(count))
+	if p.next != nil {
+		p.next.SetLoopCount(uint32(count))
+	}
+}
+
+// SetEndFade sets whether looping tracks fade out after their last loop
+// (the default) or stop dead at the end of it. It applies to the playing
+// track too.
+func (p *AudioPlayer) SetEndFade(fade bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.endFade = fade
+	p.vgm.Load().SetFadeTime(p.endFadeTime())
+	if p.next != nil {
+		p.next.SetFadeTime(p.endFadeTime())
+	}
+}
+
+// EndFade returns true if looping tracks fade out after their last loop.
+func (p *AudioPlayer) EndFade() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.endFade
+}
+
+// endFadeTime returns the fade-out time in milliseconds for the end of a
+// track. Caller must hold mu.
+func (p *AudioPlayer) endFadeTime() uint32 {
+	if p.endFade {
+		return DefaultFadeTime
+	}
+	return 0
+}
+
+// AudioConfig returns the audio settings currently in effect, read back
+// from the driver and libvgm rather than from the requested values.
+func (p *AudioPlayer) AudioConfig() AudioConfig {
+	p.mu.Lock()
+	volume, speed := p.volume, p.speed
+	p.mu.Unlock()
+
+	d := p.audioDriver
+	return AudioConfig{
+		Driver:      d.Name(),
+		SampleRate:  d.SampleRate(),
+		Channels:    d.Channels(),
+		Bits:        d.Bits(),
+		BufferTime:  time.Duration(d.BufferTime()) * time.Microsecond,
+		BufferCount: d.BufferCount(),
+		Latency:     time.Duration(d.GetLatency()) * time.Millisecond,
+		Volume:      volume,
+		Speed:       speed,
+		LoopCount:   p.vgm.Load().LoopCount(),
+		FadeTime:    time.Duration(p.vgm.Load().FadeTime()) * time.Millisecond,
+		FadeIn:      p.vgm.Load().FadeIn(),
+		EndSilence:  time.Duration(p.vgm.Load().EndSilence()) * time.Millisecond,
+	}
 }
 
 // Track returns metadata about the current track.
-func (p *AudioPlayer) Track() *Track {
+func (p *AudioPlayer) Track() *metadata.Track {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -434,7 +573,7 @@ func (p *AudioPlayer) Track() *Track {
 // Info returns current playback information.
 func (p *AudioPlayer) Info() PlaybackInfo {
 	// Get libvgm info - these CGO calls are safe without mutex
-	info := p.vgm.GetPlaybackInfo()
+	info := p.vgm.Load().GetPlaybackInfo()
 
 	// Lock-free atomic state checks
 	paused := atomic.LoadUint32(&p.pausedAtomic) == 1
@@ -452,11 +591,80 @@ func (p *AudioPlayer) Info() PlaybackInfo {
 	info.Volume = p.volume
 	info.Speed = p.speed
 	info.TotalLoops = p.loopCount
+	info.TrackChanges = p.switches
 	p.mu.Unlock()
 
+	info.DeviceLost = atomic.LoadUint32(&p.deviceLost) == 1
+	info.DeviceRestarts = atomic.LoadUint32(&p.deviceRestarts)
+
 	return info
 }
 
+// Scope copies the most recently played stereo frames into buffer
+// and returns the number of frames copied. It is safe to call from the
+// UI at any time and never blocks the audio thread.
+func (p *AudioPlayer) Scope(buffer []int16) int {
+	return p.vgm.Load().Scope(buffer)
+}
+
+// Levels returns the RMS level (0.0 - 1.0) of each channel over the audio
+// played during the last tick. It is cheap enough to poll on every tick
+// and, like Scope, never blocks the audio thread.
+func (p *AudioPlayer) Levels() (left, right float64) {
+	_, _, left, right = p.meter()
+	return left, right
+}
+
+// Peaks returns the sample peak (0.0 - 1.0) of each channel over the audio
+// played during the last tick.
+func (p *AudioPlayer) Peaks() (left, right float64) {
+	left, right, _, _ = p.meter()
+	return left, right
+}
+
+// meter measures the last tick's worth of frames from the scope tap.
+func (p *AudioPlayer) meter() (peakL, peakR, rmsL, rmsR float64) {
+	p.mu.Lock()
+	rate := p.sampleRate
+	p.mu.Unlock()
+
+	frames := int(int64(rate) * int64(DefaultTickInterval) / int64(time.Second))
+	if frames > ScopeFrames {
+		frames = ScopeFrames
+	}
+	if frames <= 0 {
+		return 0, 0, 0, 0
+	}
+
+	p.meterMu.Lock()
+	defer p.meterMu.Unlock()
+	if len(p.meterBuf) < frames*2 {
+		p.meterBuf = make([]int16, frames*2)
+	}
+	n := p.Scope(p.meterBuf[:frames*2])
+	return measureLevels(p.meterBuf[:n*2])
+}
+
+// measureLevels returns the sample peak and RMS level (0.0 - 1.0) of each
+// channel of stereo frames (L, R, L, R, ...).
+func measureLevels(frames []int16) (peakL, peakR, rmsL, rmsR float64) {
+	n := len(frames) / 2
+	if n == 0 {
+		return 0, 0, 0, 0
+	}
+
+	var sumL, sumR float64
+	for i := 0; i < n; i++ {
+		l := float64(frames[i*2]) / 32768
+		r := float64(frames[i*2+1]) / 32768
+		peakL = math.Max(peakL, math.Abs(l))
+		peakR = math.Max(peakR, math.Abs(r))
+		sumL += l * l
+		sumR += r * r
+	}
+	return peakL, peakR, math.Sqrt(sumL / float64(n)), math.Sqrt(sumR / float64(n))
+}
+
 // IsLoaded returns true if a track is loaded.
 func (p *AudioPlayer) IsLoaded() bool {
 	p.mu.Lock()
@@ -481,7 +689,7 @@ func (p *AudioPlayer) State() PlayState {
 
 	// Check if libvgm reports track ended (even if our flags say playing)
 	// This handles the case where track naturally ended but Stop() wasn't called yet
-	info := p.vgm.GetPlaybackInfo()
+	info := p.vgm.Load().GetPlaybackInfo()
 	if info.State == StateStopped {
 		return StateStopped
 	}
@@ -521,17 +729,30 @@ func (p *AudioPlayer) tickLoop() {
 	ticker := time.NewTicker(DefaultTickInterval)
 	defer ticker.Stop()
 
+	var watch deviceWatch
 	for {
 		select {
 		case <-p.ctx.Done():
 			return
-		case <-ticker.C:
+		case now := <-ticker.C:
 			// Lock-free state check
 			if atomic.LoadUint32(&p.playingAtomic) == 0 {
 				return
 			}
+			p.checkDevice(&watch, now)
+
+			// Take over from the driver if it moved on to the queued track
+			p.mu.Lock()
+			p.followSwitchLocked()
+			queued := p.nextPath != ""
+			p.mu.Unlock()
 
 			info := p.Info()
+			if info.State == StateStopped && queued {
+				// The driver switches to the queued track on its next
+				// buffer; don't report the gap in between as the end
+				continue
+			}
 
 			// Send to all subscribers (non-blocking)
 			p.subMu.RLock()
@@ -586,10 +807,13 @@ func (p *AudioPlayer) Close() error {
 	p.subscribers = nil
 	p.subMu.Unlock()
 
-	// Close libvgm player
-	if p.vgm != nil {
-		p.vgm.Close()
-		p.vgm = nil
+	// Close libvgm players
+	if vgm := p.vgm.Swap(nil); vgm != nil {
+		vgm.Close()
+	}
+	if p.next != nil {
+		p.next.Close()
+		p.next = nil
 	}
 
 	// Deinitialize audio system
@@ -0,0 +1,87 @@
+package player
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAudioConfig(t *testing.T) {
+	if err := InitAudioSystem(); err != nil {
+		t.Fatal(err)
+	}
+	defer DeinitAudioSystem()
+	drivers := GetAudioDrivers()
+	if len(drivers) == 0 {
+		t.Skip("no audio drivers compiled in")
+	}
+
+	// The driver is configured but never started, so no device is needed
+	d, err := NewAudioDriver(drivers[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	d.SetSampleRate(48000)
+	d.SetChannels(2)
+	d.SetBits(16)
+	d.SetBufferTime(20000)
+	d.SetBufferCount(6)
+
+	vgm, err := NewLibvgmPlayer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer vgm.Close()
+	vgm.SetLoopCount(3)
+	vgm.SetFadeTime(2000)
+	vgm.SetFadeIn(250 * time.Millisecond)
+	vgm.SetEndSilence(500)
+
+	p := &AudioPlayer{audioDriver: d, volume: 0.8, speed: 1.5}
+	p.vgm.Store(vgm)
+	got := p.AudioConfig()
+	want := AudioConfig{
+		Driver:      drivers[0].Name,
+		SampleRate:  48000,
+		Channels:    2,
+		Bits:        16,
+		BufferTime:  20 * time.Millisecond,
+		BufferCount: 6,
+		Latency:     got.Latency, // Only known once the device is open
+		Volume:      0.8,
+		Speed:       1.5,
+		LoopCount:   3,
+		FadeTime:    2 * time.Second,
+		FadeIn:      250 * time.Millisecond,
+		EndSilence:  500 * time.Millisecond,
+	}
+	if got != want {
+		t.Errorf("AudioConfig() = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestMeasureLevels(t *testing.T) {
+	tests := []struct {
+		frames                   []int16
+		peakL, peakR, rmsL, rmsR float64
+	}{
+		{nil, 0, 0, 0, 0},
+		{[]int16{0, 0, 0, 0}, 0, 0, 0, 0},
+		{[]int16{16384, -32768}, 0.5, 1, 0.5, 1}, // One frame: RMS is the level
+		// A square wave's RMS equals its peak; silence halves the power
+		{[]int16{16384, 16384, -16384, 0}, 0.5, 0.5, 0.5, math.Sqrt(0.125)},
+		{[]int16{8192, 0, -16384, 0, 8192, 0, 0}, 0.5, 0, math.Sqrt(0.375 / 3), 0}, // A trailing half frame is ignored
+	}
+	for _, tt := range tests {
+		peakL, peakR, rmsL, rmsR := measureLevels(tt.frames)
+		got := []float64{peakL, peakR, rmsL, rmsR}
+		want := []float64{tt.peakL, tt.peakR, tt.rmsL, tt.rmsR}
+		for i := range got {
+			if math.Abs(got[i]-want[i]) > 1e-9 {
+				t.Errorf("measureLevels(%v) = %v, want %v", tt.frames, got, want)
+				break
+			}
+		}
+	}
+}
@@ -5,38 +5,6 @@ import (
 	"time"
 )
 
-// Track represents metadata about a VGM track.
-type Track struct {
-	// File path
-	Path string
-
-	// GD3 tag information
-	Title    string // Track title (TITLE)
-	Game     string // Game/album name (GAME)
-	System   string // System/platform (SYSTEM)
-	Composer string // Composer/artist (ARTIST)
-	Date     string // Release date (DATE)
-	VGMBy    string // VGM author (ENCODED_BY)
-	Notes    string // Comments (COMMENT)
-
-	// Format information
-	Format string // e.g., "VGM 1.71", "S98 v3"
-
-	// Timing information
-	Duration  time.Duration // Total duration including loops
-	LoopPoint time.Duration // Position where loop begins (0 if no loop)
-	HasLoop   bool          // Whether the track loops
-
-	// Sound chip information
-	Chips []ChipInfo
-}
-
-// ChipInfo represents a sound chip used in a track.
-type ChipInfo struct {
-	Name string // Chip name, e.g., "YM2612"
-	Core string // Emulation core, e.g., "GPGX"
-}
-
 // PlayState represents the current playback state.
 type PlayState int
 
@@ -84,6 +52,15 @@ type PlaybackInfo struct {
 	// Playback settings
 	Volume float64 // Volume (0.0 - 1.0+)
 	Speed  float64 // Playback speed (1.0 = normal)
+
+	// Number of times playback has moved on to a queued track without a
+	// gap (see AudioPlayer.Queue)
+	TrackChanges uint32
+
+	// Audio device state: whether the device stopped taking audio and
+	// could not be reopened yet, and how many times it has been reopened
+	DeviceLost     bool
+	DeviceRestarts uint32
 }
 
 // Progress returns the playback progress as a value between 0.0 and 1.0.
@@ -109,3 +86,23 @@ func (p *PlaybackInfo) Remaining() time.Duration {
 	}
 	return remaining
 }
+
+// AudioConfig describes the audio settings currently in effect.
+type AudioConfig struct {
+	// Output driver
+	Driver      string
+	SampleRate  uint32        // Hz
+	Channels    uint8         // 1 = mono, 2 = stereo
+	Bits        uint8         // Bits per sample
+	BufferTime  time.Duration // Length of one buffer
+	BufferCount uint32
+	Latency     time.Duration // As reported by the driver
+
+	// Playback
+	Volume     float64
+	Speed      float64
+	LoopCount  uint32 // 0 = infinite
+	FadeTime   time.Duration
+	FadeIn     time.Duration
+	EndSilence time.Duration
+}
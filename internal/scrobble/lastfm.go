@@ -0,0 +1,172 @@
+// Package scrobble submits played tracks to Last.fm.
+//
+// A Tracker watches playback and decides when a track counts as played;
+// a Scrobbler delivers now-playing updates and scrobbles in the background,
+// retrying failed scrobbles so the network never holds up playback.
+package scrobble
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultEndpoint is the Last.fm API root.
+const DefaultEndpoint = "https://ws.audioscrobbler.com/2.0/"
+
+// Track is a play to submit to Last.fm.
+type Track struct {
+	Artist    string        `json:"artist"`
+	Album     string        `json:"album,omitempty"`
+	Title     string        `json:"title"`
+	Duration  time.Duration `json:"duration,omitempty"` // 0 if unknown
+	Timestamp time.Time     `json:"timestamp,omitzero"` // When playback started (scrobbles only)
+}
+
+// Client calls the Last.fm API with an authenticated session.
+type Client struct {
+	APIKey     string
+	APISecret  string
+	SessionKey string
+
+	Endpoint string       // Defaults to DefaultEndpoint
+	HTTP     *http.Client // Defaults to a client with a short timeout
+}
+
+// APIError is an error returned by the Last.fm API.
+type APIError struct {
+	Code    int    `json:"error"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("last.fm: %s (error %d)", e.Message, e.Code)
+}
+
+// Temporary reports whether the request may succeed if retried later:
+// the service is offline, temporarily unavailable or rate limiting.
+func (e *APIError) Temporary() bool {
+	switch e.Code {
+	case 11, 16, 29:
+		return true
+	}
+	return false
+}
+
+// retryable reports whether a failed request should be queued for retry.
+// Network failures, server errors and temporary API errors are; rejected
+// requests (bad credentials, invalid parameters) are not.
+func retryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var statusErr httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr >= 500
+	}
+	return true
+}
+
+// httpStatusError is an unexpected HTTP status without an API error body.
+type httpStatusError int
+
+func (e httpStatusError) Error() string {
+	return fmt.Sprintf("last.fm: HTTP %d", int(e))
+}
+
+// UpdateNowPlaying tells Last.fm the track has started playing.
+func (c *Client) UpdateNowPlaying(t Track) error {
+	params := trackParams(t)
+	params.Set("method", "track.updateNowPlaying")
+	return c.call(params)
+}
+
+// Scrobble records a play of the track.
+func (c *Client) Scrobble(t Track) error {
+	params := trackParams(t)
+	params.Set("method", "track.scrobble")
+	params.Set("timestamp", strconv.FormatInt(t.Timestamp.Unix(), 10))
+	return c.call(params)
+}
+
+// trackParams returns the request parameters describing t.
+func trackParams(t Track) url.Values {
+	params := url.Values{}
+	params.Set("artist", t.Artist)
+	params.Set("track", t.Title)
+	if t.Album != "" {
+		params.Set("album", t.Album)
+	}
+	if t.Duration > 0 {
+		params.Set("duration", strconv.Itoa(int(t.Duration.Seconds())))
+	}
+	return params
+}
+
+// call signs and posts a write request.
+func (c *Client) call(params url.Values) error {
+	params.Set("api_key", c.APIKey)
+	params.Set("sk", c.SessionKey)
+	params.Set("api_sig", c.sign(params))
+	params.Set("format", "json")
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := httpClient.PostForm(endpoint, params)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var apiErr APIError
+	if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Code != 0 {
+		return &apiErr
+	}
+	if resp.StatusCode != http.StatusOK {
+		return httpStatusError(resp.StatusCode)
+	}
+	return nil
+}
+
+// sign computes the api_sig for params: the MD5 of every name and value
+// concatenated in name order, followed by the shared secret. The format
+// parameter is excluded as the API requires.
+func (c *Client) sign(params url.Values) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		if name != "format" && name != "api_sig" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteString(params.Get(name))
+	}
+	b.WriteString(c.APISecret)
+	sum := md5.Sum([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,182 @@
+package scrobble
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// queueSize is how many submissions may wait for the worker.
+	queueSize = 64
+
+	// retryInterval is how often queued scrobbles are retried.
+	retryInterval = time.Minute
+
+	// maxPending caps the scrobbles kept for retry; the oldest are dropped.
+	maxPending = 1000
+)
+
+// submission is a now-playing update or a scrobble for the worker.
+type submission struct {
+	track      Track
+	nowPlaying bool
+}
+
+// Scrobbler submits tracks in the background. Submitting never blocks;
+// scrobbles that fail with a temporary error are kept and retried, while
+// failed now-playing updates are dropped as they are only of use live.
+type Scrobbler struct {
+	client *Client
+
+	queue chan submission
+	done  chan struct{}
+	wg    sync.WaitGroup
+
+	mu      sync.Mutex
+	pending []Track // Scrobbles waiting to be retried, oldest first
+	lastErr error
+}
+
+// NewScrobbler starts a scrobbler for client. pending holds scrobbles
+// left over from a previous run, which are retried first.
+func NewScrobbler(client *Client, pending []Track) *Scrobbler {
+	s := &Scrobbler{
+		client:  client,
+		queue:   make(chan submission, queueSize),
+		done:    make(chan struct{}),
+		pending: pending,
+	}
+	s.wg.Add(1)
+	go s.run()
+	return s
+}
+
+// NowPlaying queues a now-playing update for t.
+func (s *Scrobbler) NowPlaying(t Track) {
+	s.submit(submission{track: t, nowPlaying: true})
+}
+
+// Scrobble queues a scrobble of t.
+func (s *Scrobbler) Scrobble(t Track) {
+	s.submit(submission{track: t})
+}
+
+// submit hands sub to the worker without blocking. If the queue is full,
+// scrobbles go straight to the retry list and now-playing updates are dropped.
+func (s *Scrobbler) submit(sub submission) {
+	select {
+	case s.queue <- sub:
+	default:
+		if !sub.nowPlaying {
+			s.addPending(sub.track)
+		}
+	}
+}
+
+// LastError returns the most recent submission error, or nil.
+func (s *Scrobbler) LastError() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastErr
+}
+
+// Close stops the worker and returns the scrobbles that were never
+// delivered, so they can be saved and retried on the next run.
+func (s *Scrobbler) Close() []Track {
+	close(s.done)
+	s.wg.Wait()
+
+	// Anything still queued is kept for next time
+	for {
+		select {
+		case sub := <-s.queue:
+			if !sub.nowPlaying {
+				s.addPending(sub.track)
+			}
+		default:
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return s.pending
+		}
+	}
+}
+
+// run delivers submissions and periodically retries pending scrobbles.
+func (s *Scrobbler) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+
+	s.retry()
+	for {
+		select {
+		case <-s.done:
+			return
+		case sub := <-s.queue:
+			s.deliver(sub)
+		case <-ticker.C:
+			s.retry()
+		}
+	}
+}
+
+// deliver sends one submission, keeping the scrobble if it should be retried.
+func (s *Scrobbler) deliver(sub submission) {
+	var err error
+	if sub.nowPlaying {
+		err = s.client.UpdateNowPlaying(sub.track)
+	} else {
+		err = s.client.Scrobble(sub.track)
+	}
+	s.setErr(err)
+	if err != nil && !sub.nowPlaying && retryable(err) {
+		s.addPending(sub.track)
+	}
+}
+
+// retry resubmits pending scrobbles in order, stopping at the first one
+// that fails again so the order is kept and a dead network isn't hammered.
+func (s *Scrobbler) retry() {
+	for {
+		s.mu.Lock()
+		if len(s.pending) == 0 {
+			s.mu.Unlock()
+			return
+		}
+		t := s.pending[0]
+		s.mu.Unlock()
+
+		err := s.client.Scrobble(t)
+		s.setErr(err)
+		if err != nil && retryable(err) {
+			return
+		}
+
+		s.mu.Lock()
+		s.pending = s.pending[1:]
+		s.mu.Unlock()
+
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+	}
+}
+
+// addPending keeps t for retry, dropping the oldest scrobble when full.
+func (s *Scrobbler) addPending(t Track) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending) >= maxPending {
+		s.pending = s.pending[1:]
+	}
+	s.pending = append(s.pending, t)
+}
+
+func (s *Scrobbler) setErr(err error) {
+	s.mu.Lock()
+	s.lastErr = err
+	s.mu.Unlock()
+}
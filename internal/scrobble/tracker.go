@@ -0,0 +1,83 @@
+package scrobble
+
+import "time"
+
+const (
+	// minDuration is the shortest track Last.fm accepts a scrobble for.
+	minDuration = 30 * time.Second
+
+	// maxThreshold is how long a track must play to count as played
+	// regardless of its length.
+	maxThreshold = 4 * time.Minute
+)
+
+// Playback is a snapshot of the player for the Tracker.
+type Playback struct {
+	Playing  bool
+	Path     string // Identifies the track; empty when nothing is loaded
+	Track    Track
+	Position time.Duration
+}
+
+// Sink receives the submissions decided by a Tracker.
+type Sink interface {
+	NowPlaying(Track)
+	Scrobble(Track)
+}
+
+// Tracker applies Last.fm's scrobbling rules to a stream of playback
+// snapshots: a track is announced as now playing when it starts, and
+// scrobbled once it has played for half its length or four minutes,
+// whichever comes first. Tracks of 30 seconds or less are never scrobbled.
+type Tracker struct {
+	sink Sink
+
+	path      string
+	started   time.Time
+	announced bool
+	scrobbled bool
+}
+
+// NewTracker returns a tracker that submits to sink.
+func NewTracker(sink Sink) *Tracker {
+	return &Tracker{sink: sink}
+}
+
+// Update processes a playback snapshot.
+func (t *Tracker) Update(p Playback) {
+	if p.Path != t.path {
+		// A new track (or none): start over
+		t.path = p.Path
+		t.started = time.Now()
+		t.announced = false
+		t.scrobbled = false
+	}
+	if !p.Playing || p.Path == "" || p.Track.Artist == "" || p.Track.Title == "" {
+		return
+	}
+
+	if !t.announced {
+		t.announced = true
+		t.sink.NowPlaying(p.Track)
+	}
+	if !t.scrobbled && Eligible(p.Track.Duration, p.Position) {
+		t.scrobbled = true
+		track := p.Track
+		track.Timestamp = t.started
+		t.sink.Scrobble(track)
+	}
+}
+
+// Eligible reports whether a track of the given duration has played long
+// enough at position to be scrobbled. A duration of 0 (unknown, such as
+// an endlessly looping track) requires the full four minutes.
+func Eligible(duration, position time.Duration) bool {
+	if duration > 0 && duration <= minDuration {
+		return false
+	}
+	threshold := maxThreshold
+	if duration > 0 && duration/2 < threshold {
+		threshold = duration / 2
+	}
+	return position >= threshold
+}
@@ -0,0 +1,322 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/config"
+	"github.com/dewi-tim/vgmtui/internal/ui/components"
+)
+
+// keyMaps holds the key maps of the model and its components, so they can
+// be listed and changed together.
+type keyMaps struct {
+	global   KeyMap
+	library  components.LibBrowserKeyMap
+	files    components.BrowserKeyMap
+	playlist components.PlaylistKeyMap
+}
+
+// keyAction is a rebindable action: the help section it is listed under,
+// its name in the config file's "keys" table and its binding.
+type keyAction struct {
+	section string
+	name    string
+	binding *key.Binding
+}
+
+// defaultKeyMaps returns the built-in key maps.
+func defaultKeyMaps() keyMaps {
+	return keyMaps{
+		global:   DefaultKeyMap(),
+		library:  components.DefaultLibBrowserKeyMap(),
+		files:    components.DefaultBrowserKeyMap(),
+		playlist: components.DefaultPlaylistKeyMap(),
+	}
+}
+
+// actions lists every rebindable action, in help order.
+func (k *keyMaps) actions() []keyAction {
+	g, lib, files, pl := &k.global, &k.library, &k.files, &k.playlist
+	return []keyAction{
+		{"Global", "help", &g.Help},
+		{"Global", "quit", &g.Quit},
+		{"Global", "palette", &g.Palette},
+		{"Global", "key_bindings", &g.KeyBindings},
+		{"Global", "tab_focus", &g.TabFocus},
+		{"Global", "chip_info", &g.ChipInfo},
+		{"Global", "compact_chips", &g.CompactChips},
+		{"Global", "cycle_theme", &g.CycleTheme},
+		{"Global", "scope", &g.Scope},
+		{"Global", "meters", &g.Meters},
+		{"Global", "diagnostics", &g.Diagnostics},
+		{"Global", "game_label", &g.GameLabel},
+		{"Global", "audio_config", &g.AudioConfig},
+		{"Global", "full_info", &g.FullInfo},
+		{"Global", "export_session", &g.ExportSession},
+		{"Global", "import_session", &g.ImportSession},
+		{"Global", "history", &g.History},
+		{"Global", "radio", &g.Radio},
+		{"Global", "shuffle_play", &g.ShufflePlay},
+		{"Global", "rescan", &g.Rescan},
+		{"Global", "library_diff", &g.LibraryDiff},
+		{"Global", "watch_library", &g.WatchLibrary},
+		{"Global", "library_stats", &g.LibraryStats},
+		{"Global", "switch_browser", &g.SwitchBrowser},
+		{"Global", "sleep_timer", &g.SleepTimer},
+		{"Global", "narrow_library", &g.NarrowLibrary},
+		{"Global", "widen_library", &g.WidenLibrary},
+
+		{"Playback", "play_pause", &g.PlayPause},
+		{"Playback", "next_track", &g.NextTrack},
+		{"Playback", "prev_track", &g.PrevTrack},
+		{"Playback", "stop", &g.Stop},
+		{"Playback", "fade_stop", &g.FadeStop},
+		{"Playback", "restart", &g.Restart},
+		{"Playback", "end_fade", &g.EndFade},
+		{"Playback", "loop_progress", &g.LoopProgress},
+		{"Playback", "seek_forward", &g.SeekForward},
+		{"Playback", "seek_backward", &g.SeekBackward},
+		{"Playback", "seek_far_forward", &g.SeekFarForward},
+		{"Playback", "seek_far_backward", &g.SeekFarBackward},
+		{"Playback", "volume_up", &g.VolumeUp},
+		{"Playback", "volume_down", &g.VolumeDown},
+		{"Playback", "mute", &g.Mute},
+
+		{"Library", "library.up", &lib.Up},
+		{"Library", "library.down", &lib.Down},
+		{"Library", "library.page_up", &lib.PageUp},
+		{"Library", "library.page_down", &lib.PageDown},
+		{"Library", "library.top", &lib.GoToTop},
+		{"Library", "library.bottom", &lib.GoToBottom},
+		{"Library", "library.enter", &lib.Enter},
+		{"Library", "library.add", &lib.Add},
+		{"Library", "library.back", &lib.Back},
+		{"Library", "library.add_all", &lib.AddAll},
+		{"Library", "library.add_visible", &lib.AddVisible},
+		{"Library", "library.add_next", &lib.AddNext},
+		{"Library", "library.filter", &lib.Filter},
+		{"Library", "library.clear_filter", &lib.ClearFilter},
+		{"Library", "library.favorite", &lib.Favorite},
+		{"Library", "library.grouping", &lib.Grouping},
+		{"Library", "library.sort", &lib.CycleSort},
+		{"Library", "library.chip_filter", &lib.ChipFilter},
+		{"Library", "library.duplicates", &lib.Duplicates},
+
+		{"Files", "files.up", &files.Up},
+		{"Files", "files.down", &files.Down},
+		{"Files", "files.page_up", &files.PageUp},
+		{"Files", "files.page_down", &files.PageDown},
+		{"Files", "files.top", &files.GoToTop},
+		{"Files", "files.bottom", &files.GoToBottom},
+		{"Files", "files.open", &files.Open},
+		{"Files", "files.add", &files.Add},
+		{"Files", "files.back", &files.Back},
+		{"Files", "files.hidden", &files.ToggleHidden},
+		{"Files", "files.filter", &files.Filter},
+		{"Files", "files.clear_filter", &files.ClearFilter},
+		{"Files", "files.add_recursive", &files.AddRecursive},
+		{"Files", "files.sort", &files.CycleSort},
+		{"Files", "files.mark", &files.Mark},
+		{"Files", "files.add_marked", &files.AddMarked},
+		{"Files", "files.full_path", &files.FullPath},
+		{"Files", "files.go_to", &files.GoTo},
+		{"Files", "files.symlinks", &files.Symlinks},
+
+		{"Playlist", "playlist.up", &pl.Up},
+		{"Playlist", "playlist.down", &pl.Down},
+		{"Playlist", "playlist.page_up", &pl.PageUp},
+		{"Playlist", "playlist.page_down", &pl.PageDown},
+		{"Playlist", "playlist.top", &pl.Top},
+		{"Playlist", "playlist.bottom", &pl.Bottom},
+		{"Playlist", "playlist.play", &pl.Select},
+		{"Playlist", "playlist.remove", &pl.Remove},
+		{"Playlist", "playlist.remove_above", &pl.RemoveAbove},
+		{"Playlist", "playlist.remove_below", &pl.RemoveBelow},
+		{"Playlist", "playlist.clear", &pl.Clear},
+		{"Playlist", "playlist.undo", &pl.Undo},
+		{"Playlist", "playlist.move_to_top", &pl.ToTop},
+		{"Playlist", "playlist.move_to_bottom", &pl.ToBottom},
+		{"Playlist", "playlist.play_next", &pl.PlayNext},
+		{"Playlist", "playlist.remove_broken", &pl.RemoveBroken},
+		{"Playlist", "playlist.sort", &pl.Sort},
+		{"Playlist", "playlist.search", &pl.Search},
+		{"Playlist", "playlist.next_match", &pl.NextMatch},
+		{"Playlist", "playlist.prev_match", &pl.PrevMatch},
+		{"Playlist", "playlist.reverse", &pl.Reverse},
+		{"Playlist", "playlist.go_to", &pl.GoTo},
+		{"Playlist", "playlist.favorite", &pl.Favorite},
+		{"Playlist", "playlist.edit_tags", &pl.EditTags},
+		{"Playlist", "playlist.remove_cursor", &pl.ToggleRemoveCursor},
+	}
+}
+
+// action returns the action with the given config name.
+func (k *keyMaps) action(name string) (keyAction, bool) {
+	for _, a := range k.actions() {
+		if a.name == name {
+			return a, true
+		}
+	}
+	return keyAction{}, false
+}
+
+// keyMaps returns a copy of the model's key maps.
+func (m Model) keyMaps() keyMaps {
+	return keyMaps{
+		global:   m.keyMap,
+		library:  m.libBrowser.KeyMap(),
+		files:    m.browser.KeyMap,
+		playlist: m.playlist.KeyMap(),
+	}
+}
+
+// setKeyMaps puts changed key maps back into the model and its components.
+func (m *Model) setKeyMaps(k keyMaps) {
+	m.keyMap = k.global
+	m.libBrowser.SetKeyMap(k.library)
+	m.browser.KeyMap = k.files
+	m.playlist.SetKeyMap(k.playlist)
+}
+
+// setBindingKeys binds b to keys, updating the help to show them.
+func setBindingKeys(b *key.Binding, keys []string) {
+	b.SetKeys(keys...)
+	b.SetHelp(keyLabel(keys), b.Help().Desc)
+}
+
+// keyLabel returns every key of a binding for display, e.g. "q/ctrl+c".
+func keyLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		labels[i] = k
+		if k == " " {
+			labels[i] = "space"
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// applyKeyBindings rebinds the actions named in the config file's "keys"
+// table. Unknown names are skipped and reported in the error.
+func (m *Model) applyKeyBindings(bindings map[string][]string) error {
+	k := m.keyMaps()
+	var unknown []string
+	for _, name := range slices.Sorted(maps.Keys(bindings)) {
+		a, ok := k.action(name)
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		if len(bindings[name]) > 0 {
+			setBindingKeys(a.binding, bindings[name])
+		}
+	}
+	m.setKeyMaps(k)
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("config: unknown key binding action: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// helpSections returns the current bindings grouped for the help popup,
+// each listing all of its keys rather than the short label in its help.
+func (m Model) helpSections() []components.HelpSection {
+	k := m.keyMaps()
+	var sections []components.HelpSection
+	for _, a := range k.actions() {
+		if n := len(sections); n == 0 || sections[n-1].Title != a.section {
+			sections = append(sections, components.HelpSection{Title: a.section})
+		}
+		b := *a.binding
+		b.SetHelp(keyLabel(b.Keys()), b.Help().Desc)
+		last := &sections[len(sections)-1]
+		last.Bindings = append(last.Bindings, b)
+	}
+	return sections
+}
+
+// keyBindingRows returns every rebindable action for the key bindings
+// popup.
+func (m Model) keyBindingRows() []components.KeyBindingRow {
+	k := m.keyMaps()
+	actions := k.actions()
+	rows := make([]components.KeyBindingRow, len(actions))
+	for i, a := range actions {
+		rows[i] = components.KeyBindingRow{
+			Action: a.name,
+			Desc:   a.binding.Help().Desc,
+			Keys:   keyLabel(a.binding.Keys()),
+		}
+	}
+	return rows
+}
+
+// rebind binds an action to a single new key, or back to its default keys
+// if newKey is empty, and saves the change to the config file. Other
+// actions that can see the same key press are named in the notice.
+func (m *Model) rebind(name, newKey string) tea.Cmd {
+	k := m.keyMaps()
+	a, ok := k.action(name)
+	if !ok {
+		return nil
+	}
+
+	if newKey == "" {
+		defaults := defaultKeyMaps()
+		d, _ := defaults.action(name)
+		*a.binding = *d.binding
+		m.notice = fmt.Sprintf("Reset %s to %s", a.binding.Help().Desc, a.binding.Help().Key)
+	} else {
+		setBindingKeys(a.binding, []string{newKey})
+		m.notice = fmt.Sprintf("Bound %s to %s", a.binding.Help().Desc, a.binding.Help().Key)
+		if clashes := k.clashes(a, newKey); len(clashes) > 0 {
+			sort.Strings(clashes)
+			m.notice += " (also " + strings.Join(clashes, ", ") + ")"
+		}
+	}
+	m.noticeTime = time.Now()
+
+	m.setKeyMaps(k)
+	m.keyBindings.SetRows(m.keyBindingRows())
+	return m.updateConfig(func(c *config.Config) {
+		if newKey == "" {
+			delete(c.Keys, name)
+			return
+		}
+		if c.Keys == nil {
+			c.Keys = make(map[string][]string)
+		}
+		c.Keys[name] = []string{newKey}
+	})
+}
+
+// clashes returns the other actions bound to keyName that a press can
+// reach alongside a: global actions clash with everything, the others
+// only within their own section.
+func (k *keyMaps) clashes(a keyAction, keyName string) []string {
+	var names []string
+	for _, other := range k.actions() {
+		if other.name == a.name || !slices.Contains(other.binding.Keys(), keyName) {
+			continue
+		}
+		if other.section == a.section || isGlobalSection(other.section) || isGlobalSection(a.section) {
+			names = append(names, other.binding.Help().Desc)
+		}
+	}
+	return names
+}
+
+// isGlobalSection reports whether actions in the help section work
+// regardless of focus.
+func isGlobalSection(section string) bool {
+	return section == "Global" || section == "Playback"
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/config"
+	"github.com/dewi-tim/vgmtui/internal/player"
+)
+
+// SetBuffersMsg resizes the audio output buffers, reopening the device.
+type SetBuffersMsg struct {
+	Buffers player.Buffers
+}
+
+// paletteBuffers are the audio buffer sizes offered by the command
+// palette, from the low-latency default up to sizes for slow systems.
+var paletteBuffers = []player.Buffers{
+	player.DefaultBuffers(),
+	{Time: 20 * time.Millisecond, Count: 8},
+	{Time: 50 * time.Millisecond, Count: 8},
+	{Time: 50 * time.Millisecond, Count: 16},
+}
+
+// AudioBuffers returns the audio buffers set in the config file, using the
+// default for anything left unset.
+func AudioBuffers(cfg config.Config) player.Buffers {
+	b := player.DefaultBuffers()
+	if cfg.AudioBufferMs != 0 {
+		b.Time = time.Duration(cfg.AudioBufferMs) * time.Millisecond
+	}
+	if cfg.AudioBufferCount != 0 {
+		b.Count = cfg.AudioBufferCount
+	}
+	return b
+}
+
+// setBuffers applies audio buffers chosen in the command palette and saves
+// them to the config file.
+func (m *Model) setBuffers(b player.Buffers) tea.Cmd {
+	if m.audioPlayer == nil {
+		return nil
+	}
+	if err := m.audioPlayer.SetBuffers(b); err != nil {
+		m.lastError = err.Error()
+		m.errorTime = time.Now()
+		return nil
+	}
+	m.notice = fmt.Sprintf("Audio buffers: %v", b)
+	m.noticeTime = time.Now()
+
+	return m.updateConfig(func(c *config.Config) {
+		c.AudioBufferMs = int(b.Time / time.Millisecond)
+		c.AudioBufferCount = b.Count
+		if b == player.DefaultBuffers() {
+			c.AudioBufferMs, c.AudioBufferCount = 0, 0
+		}
+	})
+}
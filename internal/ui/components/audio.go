@@ -0,0 +1,163 @@
+// Package components provides UI components for vgmtui.
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/player"
+)
+
+// AudioPopupKeyMap defines key bindings for the audio configuration popup.
+type AudioPopupKeyMap struct {
+	Close key.Binding
+}
+
+// DefaultAudioPopupKeyMap returns the default audio popup key bindings.
+func DefaultAudioPopupKeyMap() AudioPopupKeyMap {
+	return AudioPopupKeyMap{
+		Close: key.NewBinding(
+			key.WithKeys("i", "esc", "enter", "q"),
+			key.WithHelp("i/esc", "close"),
+		),
+	}
+}
+
+// AudioPopup is an overlay showing the audio settings in effect: the
+// output format and buffering of the driver and libvgm's playback options.
+type AudioPopup struct {
+	config  *player.AudioConfig // nil without an audio player
+	visible bool
+	width   int
+	height  int
+
+	keyMap AudioPopupKeyMap
+
+	// Styles
+	styles PopupStyles
+}
+
+// NewAudioPopup creates a new audio configuration popup.
+func NewAudioPopup() AudioPopup {
+	return AudioPopup{
+		width:  60,
+		height: 24,
+		keyMap: DefaultAudioPopupKeyMap(),
+		styles: DefaultPopupStyles(),
+	}
+}
+
+// Update handles messages for the audio popup.
+func (a AudioPopup) Update(msg tea.Msg) (AudioPopup, tea.Cmd) {
+	if !a.visible {
+		return a, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, a.keyMap.Close) {
+		a.visible = false
+	}
+	return a, nil
+}
+
+// View renders the audio popup as an overlay.
+func (a AudioPopup) View() string {
+	if !a.visible {
+		return ""
+	}
+
+	content := a.styles.Footer.Render("No audio player (TUI-only mode)")
+	if a.config != nil {
+		content = a.buildContent()
+	}
+	return a.styles.renderPopup("Audio Configuration", "i/Esc: close", clampWidth(a.width, 70, 40, 50), content)
+}
+
+// buildContent lists the settings grouped into output and playback.
+func (a AudioPopup) buildContent() string {
+	cfg := a.config
+	var b strings.Builder
+
+	addRow := func(label, value string) {
+		b.WriteString(a.styles.Key.Render(fmt.Sprintf("%-14s", label)))
+		b.WriteString(a.styles.Desc.Render(value))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(a.styles.Category.Render("Output"))
+	b.WriteString("\n")
+	addRow("Driver", defaultText(cfg.Driver, "(unknown)"))
+	addRow("Sample rate", fmt.Sprintf("%d Hz", cfg.SampleRate))
+	addRow("Channels", formatChannels(cfg.Channels))
+	addRow("Bit depth", fmt.Sprintf("%d-bit", cfg.Bits))
+	addRow("Buffers", fmt.Sprintf("%d x %s (%s)", cfg.BufferCount, cfg.BufferTime,
+		cfg.BufferTime*time.Duration(cfg.BufferCount)))
+	addRow("Latency", cfg.Latency.String())
+
+	b.WriteString("\n")
+	b.WriteString(a.styles.Category.Render("Playback"))
+	b.WriteString("\n")
+	addRow("Volume", fmt.Sprintf("%.0f%%", cfg.Volume*100))
+	addRow("Speed", fmt.Sprintf("%.2fx", cfg.Speed))
+	if cfg.LoopCount == 0 {
+		addRow("Loops", "infinite")
+	} else {
+		addRow("Loops", fmt.Sprintf("%d", cfg.LoopCount))
+	}
+	addRow("Fade-out", cfg.FadeTime.String())
+	addRow("Fade-in", cfg.FadeIn.String())
+	addRow("End silence", cfg.EndSilence.String())
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatChannels describes a channel count.
+func formatChannels(n uint8) string {
+	switch n {
+	case 1:
+		return "1 (mono)"
+	case 2:
+		return "2 (stereo)"
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// defaultText returns s, or def if s is empty.
+func defaultText(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// SetSize sets the available size for the audio popup.
+func (a *AudioPopup) SetSize(width, height int) {
+	a.width = width
+	a.height = height
+}
+
+// SetStyles sets the popup styles.
+func (a *AudioPopup) SetStyles(styles PopupStyles) {
+	a.styles = styles
+}
+
+// Show makes the audio popup visible with a snapshot of the configuration.
+// A nil config means there is no audio player.
+func (a *AudioPopup) Show(config *player.AudioConfig) {
+	a.config = config
+	a.visible = true
+}
+
+// Hide makes the audio popup invisible.
+func (a *AudioPopup) Hide() {
+	a.visible = false
+}
+
+// Visible returns whether the audio popup is visible.
+func (a AudioPopup) Visible() bool {
+	return a.visible
+}
@@ -0,0 +1,75 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dewi-tim/vgmtui/internal/player"
+)
+
+func TestAudioPopupRows(t *testing.T) {
+	cfg := player.AudioConfig{
+		Driver:      "PulseAudio",
+		SampleRate:  44100,
+		Channels:    2,
+		Bits:        16,
+		BufferTime:  10 * time.Millisecond,
+		BufferCount: 4,
+		Latency:     45 * time.Millisecond,
+		Volume:      0.75,
+		Speed:       1,
+		FadeTime:    4 * time.Second,
+		FadeIn:      500 * time.Millisecond,
+		EndSilence:  time.Second,
+	}
+	tests := []struct {
+		change func(*player.AudioConfig)
+		row    string // Label and value expected on one line
+	}{
+		{nil, "Driver PulseAudio"},
+		{func(c *player.AudioConfig) { c.Driver = "" }, "Driver (unknown)"},
+		{nil, "Sample rate 44100 Hz"},
+		{nil, "Channels 2 (stereo)"},
+		{func(c *player.AudioConfig) { c.Channels = 1 }, "Channels 1 (mono)"},
+		{func(c *player.AudioConfig) { c.Channels = 6 }, "Channels 6"},
+		{nil, "Bit depth 16-bit"},
+		{nil, "Buffers 4 x 10ms (40ms)"},
+		{nil, "Latency 45ms"},
+		{nil, "Volume 75%"},
+		{func(c *player.AudioConfig) { c.Speed = 1.25 }, "Speed 1.25x"},
+		{nil, "Loops infinite"},
+		{func(c *player.AudioConfig) { c.LoopCount = 2 }, "Loops 2"},
+		{nil, "Fade-out 4s"},
+		{nil, "Fade-in 500ms"},
+		{nil, "End silence 1s"},
+	}
+	for _, tt := range tests {
+		c := cfg
+		if tt.change != nil {
+			tt.change(&c)
+		}
+		a := NewAudioPopup()
+		a.Show(&c)
+		if !containsRow(a.View(), tt.row) {
+			t.Errorf("popup has no row %q:\n%s", tt.row, a.View())
+		}
+	}
+
+	a := NewAudioPopup()
+	a.Show(nil)
+	if !strings.Contains(a.View(), "No audio player") {
+		t.Errorf("popup without a player:\n%s", a.View())
+	}
+}
+
+// containsRow reports whether a line inside the rendered popup's border
+// reads row, with runs of spaces collapsed.
+func containsRow(view, row string) bool {
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Join(strings.Fields(strings.Trim(line, "│ ")), " ") == row {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package components
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// headerLines returns the lines above the entries: the current directory
+// (or the filter while one is set), the sort mode and the number of marked
+// entries. The directory is shortened to a breadcrumb unless the full path
+// is shown, which wraps over as many lines as it needs, as does a path
+// being typed to go to.
+func (b Browser) headerLines() []string {
+	if b.goingTo {
+		line := "Go to: " + b.goToInput + "_"
+		if b.goToErr != "" {
+			line += " (" + b.goToErr + ")"
+		}
+		return wrapWidth(line, max(b.width-2, 10))
+	}
+
+	sortLabel := " [" + b.sortMode.String() + "]"
+	if len(b.marked) > 0 {
+		sortLabel += fmt.Sprintf(" %d marked", len(b.marked))
+	}
+	if b.filtering || b.filter != "" {
+		// The filter replaces the directory line while active
+		filter := "/" + b.filter
+		if b.filtering {
+			filter += "_"
+		}
+		return []string{filter + sortLabel}
+	}
+	if b.fullPath {
+		return wrapWidth(b.currentDir+sortLabel, max(b.width-2, 10))
+	}
+	return []string{breadcrumb(b.currentDir, max(b.width-2-len(sortLabel), 10)) + sortLabel}
+}
+
+// breadcrumb shortens dir to fit width by keeping as many of its last
+// components as fit behind ".../", e.g. ".../Sega/Genesis/Sonic 2". A last
+// component too long on its own is cut from the front instead.
+func breadcrumb(dir string, width int) string {
+	if lipgloss.Width(dir) <= width {
+		return dir
+	}
+	sep := string(filepath.Separator)
+	prefix := "..." + sep
+	parts := strings.Split(filepath.Clean(dir), sep)
+
+	crumb := ""
+	for i := len(parts) - 1; i >= 0; i-- {
+		next := parts[i]
+		if crumb != "" {
+			next += sep + crumb
+		}
+		if lipgloss.Width(prefix+next) > width {
+			break
+		}
+		crumb = next
+	}
+	if crumb != "" {
+		return prefix + crumb
+	}
+	return truncateStart(parts[len(parts)-1], width)
+}
@@ -2,18 +2,19 @@
 package components
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-)
 
-// VGM-compatible file extensions.
-var vgmExtensions = []string{".vgm", ".vgz", ".s98", ".dro", ".gym"}
+	"github.com/dewi-tim/vgmtui/internal/library"
+)
 
 // BrowserKeyMap defines key bindings for the browser.
 type BrowserKeyMap struct {
@@ -27,6 +28,15 @@ type BrowserKeyMap struct {
 	Add          key.Binding // Enter directory or add file without playing
 	Back         key.Binding
 	ToggleHidden key.Binding
+	Filter       key.Binding // Start typing a filename filter
+	ClearFilter  key.Binding
+	AddRecursive key.Binding // Add every VGM file below a directory
+	CycleSort    key.Binding
+	Mark         key.Binding // Mark or unmark the entry for adding
+	AddMarked    key.Binding
+	FullPath     key.Binding // Show the whole directory path, not a breadcrumb
+	GoTo         key.Binding // Type a directory to go to
+	Symlinks     key.Binding // Follow symlinked directories or not
 }
 
 // DefaultBrowserKeyMap returns the default browser key bindings.
@@ -72,15 +82,98 @@ func DefaultBrowserKeyMap() BrowserKeyMap {
 			key.WithKeys("."),
 			key.WithHelp(".", "hidden"),
 		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ClearFilter: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear filter"),
+		),
+		AddRecursive: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "add dir recursively"),
+		),
+		CycleSort: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "sort order"),
+		),
+		Mark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "mark"),
+		),
+		AddMarked: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "add marked"),
+		),
+		FullPath: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "full path"),
+		),
+		GoTo: key.NewBinding(
+			key.WithKeys("~"),
+			key.WithHelp("~", "go to path"),
+		),
+		Symlinks: key.NewBinding(
+			key.WithKeys("@"),
+			key.WithHelp("@", "follow symlinks"),
+		),
 	}
 }
 
 // FileEntry represents a file or directory in the browser.
 type FileEntry struct {
-	Name  string
-	Path  string
-	IsDir bool
-	Size  int64
+	Name    string
+	Path    string
+	IsDir   bool
+	Size    int64
+	ModTime time.Time
+}
+
+// SortMode selects the order of entries in the browser.
+// Directories are always listed before files.
+type SortMode int
+
+const (
+	SortByName SortMode = iota // Alphabetical
+	SortBySize                 // Largest files first
+	SortByTime                 // Most recently modified first
+)
+
+// String returns the short name shown in the directory header.
+func (s SortMode) String() string {
+	switch s {
+	case SortBySize:
+		return "size"
+	case SortByTime:
+		return "date"
+	default:
+		return "name"
+	}
+}
+
+// Next returns the sort mode that follows s when cycling.
+func (s SortMode) Next() SortMode {
+	return (s + 1) % 3
+}
+
+// DirPrefs holds view preferences that can be remembered per directory.
+type DirPrefs struct {
+	ShowHidden bool     `json:"show_hidden,omitempty"`
+	Filter     string   `json:"filter,omitempty"`
+	Sort       SortMode `json:"sort,omitempty"`
+}
+
+// DirPrefsChangedMsg is sent when a remembered per-directory preference
+// changes, carrying the full path -> prefs map for persistence.
+type DirPrefsChangedMsg struct {
+	Prefs map[string]DirPrefs
+}
+
+// FollowSymlinksMsg is sent when following symlinked directories is
+// turned on or off.
+type FollowSymlinksMsg struct {
+	Follow bool
 }
 
 // Browser is a file browser component for navigating and selecting VGM files.
@@ -89,7 +182,8 @@ type Browser struct {
 	currentDir string
 
 	// File entries in the current directory
-	entries []FileEntry
+	allEntries []FileEntry // Full listing
+	entries    []FileEntry // Listing narrowed by the filter
 
 	// Selection state
 	selected int
@@ -103,8 +197,27 @@ type Browser struct {
 	// State
 	focused    bool
 	showHidden bool
+	fullPath   bool // Show the whole directory path, wrapped if needed
+	sortMode   SortMode
 	err        error
 
+	// Filename filter
+	filter    string // Case-insensitive substring ("" shows everything)
+	filtering bool   // True while the filter is being typed
+
+	// Go to path input
+	goToInput string
+	goingTo   bool   // True while a path is being typed
+	goToErr   string // Why the typed path could not be gone to
+
+	// Paths marked for adding, in marking order. Marks are kept across
+	// directories so a queue can be built from several places.
+	marked []string
+
+	// Per-directory preferences (only used when rememberPrefs is set)
+	rememberPrefs bool
+	dirPrefs      map[string]DirPrefs
+
 	// Key bindings
 	KeyMap BrowserKeyMap
 
@@ -122,6 +235,7 @@ type BrowserStyles struct {
 	SelectedDir  lipgloss.Style
 	Muted        lipgloss.Style
 	EmptyDir     lipgloss.Style
+	Marked       lipgloss.Style // Marker for entries marked for adding
 }
 
 // DefaultBrowserStyles returns the default browser styles.
@@ -147,6 +261,9 @@ func DefaultBrowserStyles() BrowserStyles {
 		EmptyDir: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#A0A0A0")).
 			Italic(true),
+		Marked: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFA500")).
+			Bold(true),
 	}
 }
 
@@ -160,6 +277,21 @@ type FilePlayMsg struct {
 	Path string
 }
 
+// DirAddMsg is sent when a directory has been searched recursively for
+// VGM files to add to the playlist.
+type DirAddMsg struct {
+	Dir   string
+	Paths []string // In walk order: lexical, parents before subdirectories
+	Err   error
+}
+
+// MarkedAddMsg is sent when the marked entries are added to the playlist:
+// marked files, and every VGM file below marked directories.
+type MarkedAddMsg struct {
+	Paths []string // In marking order
+	Err   error
+}
+
 // DirChangedMsg is sent when the directory changes.
 type DirChangedMsg struct {
 	Path string
@@ -169,6 +301,7 @@ type DirChangedMsg struct {
 type BrowserReadDirMsg struct {
 	Dir     string
 	Entries []FileEntry
+	Prefs   DirPrefs // Preferences the listing was read with
 	Err     error
 }
 
@@ -189,6 +322,7 @@ func NewBrowser(startDir string) Browser {
 
 	b := Browser{
 		currentDir: startDir,
+		allEntries: []FileEntry{},
 		entries:    []FileEntry{},
 		selected:   0,
 		min:        0,
@@ -209,18 +343,123 @@ func (b Browser) Init() tea.Cmd {
 	return b.readDir(b.currentDir)
 }
 
-// readDir returns a command to read a directory's contents.
+// readDir returns a command to read a directory's contents using the
+// preferences that apply to that directory.
 func (b Browser) readDir(path string) tea.Cmd {
+	prefs := b.prefsFor(path)
 	return func() tea.Msg {
-		entries, err := readDirFiltered(path, b.showHidden)
+		entries, err := readDirFiltered(path, prefs.ShowHidden)
 		return BrowserReadDirMsg{
 			Dir:     path,
 			Entries: entries,
+			Prefs:   prefs,
 			Err:     err,
 		}
 	}
 }
 
+// currentPrefs returns the preferences currently in effect.
+func (b Browser) currentPrefs() DirPrefs {
+	return DirPrefs{ShowHidden: b.showHidden, Filter: b.filter, Sort: b.sortMode}
+}
+
+// prefsFor returns the preferences to use when listing a directory.
+// When remembering, a directory without saved preferences gets the defaults;
+// otherwise the current preferences carry over, except for the filter,
+// which only applies to the directory it was typed in.
+func (b Browser) prefsFor(path string) DirPrefs {
+	if b.rememberPrefs {
+		return b.dirPrefs[path]
+	}
+	prefs := b.currentPrefs()
+	if path != b.currentDir {
+		prefs.Filter = ""
+	}
+	return prefs
+}
+
+// applyPrefs makes the given preferences current.
+func (b *Browser) applyPrefs(prefs DirPrefs) {
+	b.showHidden = prefs.ShowHidden
+	b.filter = prefs.Filter
+	b.sortMode = prefs.Sort
+	sortEntries(b.allEntries, b.sortMode)
+	b.applyFilter()
+}
+
+// applyFilter narrows the listing to entries whose names contain the
+// filter, keeping the selected entry selected if it is still listed.
+func (b *Browser) applyFilter() {
+	var selectedName string
+	if b.selected >= 0 && b.selected < len(b.entries) {
+		selectedName = b.entries[b.selected].Name
+	}
+
+	if b.filter == "" {
+		b.entries = b.allEntries
+	} else {
+		filter := strings.ToLower(b.filter)
+		b.entries = make([]FileEntry, 0, len(b.allEntries))
+		for _, e := range b.allEntries {
+			if strings.Contains(strings.ToLower(e.Name), filter) {
+				b.entries = append(b.entries, e)
+			}
+		}
+	}
+
+	b.selected = 0
+	for i, e := range b.entries {
+		if e.Name == selectedName {
+			b.selected = i
+			break
+		}
+	}
+	b.updateViewport()
+}
+
+// setFilter changes the filter and re-applies it to the listing.
+func (b *Browser) setFilter(filter string) {
+	b.filter = filter
+	b.applyFilter()
+}
+
+// rememberCurrentPrefs records the current preferences for the current
+// directory and returns a command announcing the change, or nil if
+// preferences are not being remembered.
+func (b *Browser) rememberCurrentPrefs() tea.Cmd {
+	if !b.rememberPrefs {
+		return nil
+	}
+	if b.dirPrefs == nil {
+		b.dirPrefs = make(map[string]DirPrefs)
+	}
+
+	prefs := b.currentPrefs()
+	if prefs == (DirPrefs{}) {
+		delete(b.dirPrefs, b.currentDir) // Defaults need no entry
+	} else {
+		b.dirPrefs[b.currentDir] = prefs
+	}
+
+	snapshot := make(map[string]DirPrefs, len(b.dirPrefs))
+	for path, p := range b.dirPrefs {
+		snapshot[path] = p
+	}
+	return func() tea.Msg {
+		return DirPrefsChangedMsg{Prefs: snapshot}
+	}
+}
+
+// SetRememberPrefs enables or disables per-directory preferences and
+// installs previously saved preferences.
+func (b *Browser) SetRememberPrefs(remember bool, prefs map[string]DirPrefs) {
+	b.rememberPrefs = remember
+	b.dirPrefs = prefs
+	if remember {
+		b.applyPrefs(b.dirPrefs[b.currentDir])
+	}
+}
+
 // readDirFiltered reads directory contents, filtering and sorting appropriately.
 func readDirFiltered(path string, showHidden bool) ([]FileEntry, error) {
 	dirEntries, err := os.ReadDir(path)
@@ -242,42 +481,66 @@ func readDirFiltered(path string, showHidden bool) ([]FileEntry, error) {
 		if err != nil {
 			continue
 		}
+		if info.Mode()&os.ModeSymlink != 0 && library.FollowSymlinks() {
+			if target, err := os.Stat(filepath.Join(path, name)); err == nil {
+				info = target
+			}
+		}
 
-		isDir := de.IsDir()
+		isDir := info.IsDir()
 
 		// For files, only include VGM-compatible types
-		if !isDir && !isVGMFile(name) {
+		if !isDir && !library.IsVGMFile(name) {
 			continue
 		}
 
 		entries = append(entries, FileEntry{
-			Name:  name,
-			Path:  filepath.Join(path, name),
-			IsDir: isDir,
-			Size:  info.Size(),
+			Name:    name,
+			Path:    filepath.Join(path, name),
+			IsDir:   isDir,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
 		})
 	}
 
-	// Sort: directories first, then alphabetically
-	sort.Slice(entries, func(i, j int) bool {
-		if entries[i].IsDir != entries[j].IsDir {
-			return entries[i].IsDir
-		}
-		return strings.ToLower(entries[i].Name) < strings.ToLower(entries[j].Name)
-	})
-
+	sortEntries(entries, SortByName)
 	return entries, nil
 }
 
-// isVGMFile checks if a filename has a VGM-compatible extension.
-func isVGMFile(name string) bool {
-	lower := strings.ToLower(name)
-	for _, ext := range vgmExtensions {
-		if strings.HasSuffix(lower, ext) {
-			return true
+// sortEntries sorts entries in place: directories first, then by the sort
+// mode, with ties broken by name in natural order, so "Track 2" comes
+// before "Track 10". Directories have no meaningful size, so they stay in
+// name order when sorting by size.
+func sortEntries(entries []FileEntry, mode SortMode) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.IsDir != b.IsDir {
+			return a.IsDir
+		}
+		switch {
+		case mode == SortBySize && !a.IsDir && a.Size != b.Size:
+			return a.Size > b.Size
+		case mode == SortByTime && !a.ModTime.Equal(b.ModTime):
+			return a.ModTime.After(b.ModTime)
 		}
+		return library.NaturalLess(a.Name, b.Name)
+	})
+}
+
+// formatSize formats a file size compactly, e.g. "812B", "34K", "1.2M".
+func formatSize(size int64) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%dB", size)
+	case size < 10*1024:
+		return fmt.Sprintf("%.1fK", float64(size)/1024)
+	case size < 1024*1024:
+		return fmt.Sprintf("%dK", size/1024)
+	case size < 10*1024*1024:
+		return fmt.Sprintf("%.1fM", float64(size)/(1024*1024))
+	default:
+		return fmt.Sprintf("%dM", size/(1024*1024))
 	}
-	return false
 }
 
 // Update handles messages and updates the browser state.
@@ -289,7 +552,9 @@ func (b Browser) Update(msg tea.Msg) (Browser, tea.Cmd) {
 			return b, nil
 		}
 		b.currentDir = msg.Dir
+		b.allEntries = msg.Entries
 		b.entries = msg.Entries
+		b.filtering = false
 		b.err = nil
 		// Reset selection if needed
 		if b.selected >= len(b.entries) {
@@ -298,7 +563,7 @@ func (b Browser) Update(msg tea.Msg) (Browser, tea.Cmd) {
 				b.selected = 0
 			}
 		}
-		b.updateViewport()
+		b.applyPrefs(msg.Prefs)
 		return b, nil
 
 	case tea.KeyMsg:
@@ -311,9 +576,50 @@ func (b Browser) Update(msg tea.Msg) (Browser, tea.Cmd) {
 	return b, nil
 }
 
+// handleFilterKey handles keyboard input while the filter is being typed.
+// The listing narrows as the user types; enter keeps the filter and esc
+// restores the full listing.
+func (b Browser) handleFilterKey(msg tea.KeyMsg) (Browser, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		b.filtering = false
+		return b, b.rememberCurrentPrefs()
+	case tea.KeyEsc:
+		b.filtering = false
+		b.setFilter("")
+		return b, b.rememberCurrentPrefs()
+	case tea.KeyBackspace:
+		if r := []rune(b.filter); len(r) > 0 {
+			b.setFilter(string(r[:len(r)-1]))
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		b.setFilter(b.filter + string(msg.Runes))
+	}
+	return b, nil
+}
+
 // handleKeyMsg handles keyboard input when focused.
 func (b Browser) handleKeyMsg(msg tea.KeyMsg) (Browser, tea.Cmd) {
+	if b.filtering {
+		return b.handleFilterKey(msg)
+	}
+	if b.goingTo {
+		return b.handleGoToKey(msg)
+	}
+
 	switch {
+	case key.Matches(msg, b.KeyMap.Filter):
+		b.filtering = true
+		return b, nil
+
+	case key.Matches(msg, b.KeyMap.ClearFilter):
+		if b.filter == "" {
+			b.marked = nil // Esc with no filter clears the marks instead
+			return b, nil
+		}
+		b.setFilter("")
+		return b, b.rememberCurrentPrefs()
+
 	case key.Matches(msg, b.KeyMap.Up):
 		b.moveUp()
 		return b, nil
@@ -339,17 +645,59 @@ func (b Browser) handleKeyMsg(msg tea.KeyMsg) (Browser, tea.Cmd) {
 		return b, nil
 
 	case key.Matches(msg, b.KeyMap.Open):
+		if len(b.marked) > 0 {
+			return b.addMarked()
+		}
 		return b.openSelected()
 
+	case key.Matches(msg, b.KeyMap.Mark):
+		if entry := b.SelectedEntry(); entry != nil {
+			b.toggleMark(entry.Path)
+			b.moveDown()
+		}
+		return b, nil
+
+	case key.Matches(msg, b.KeyMap.AddMarked):
+		return b.addMarked()
+
 	case key.Matches(msg, b.KeyMap.Add):
 		return b.addSelected()
 
 	case key.Matches(msg, b.KeyMap.Back):
 		return b.goToParent()
 
+	case key.Matches(msg, b.KeyMap.AddRecursive):
+		return b, b.addRecursive()
+
+	case key.Matches(msg, b.KeyMap.CycleSort):
+		b.sortMode = b.sortMode.Next()
+		sortEntries(b.allEntries, b.sortMode)
+		b.applyFilter()
+		return b, b.rememberCurrentPrefs()
+
+	case key.Matches(msg, b.KeyMap.Symlinks):
+		follow := !library.FollowSymlinks()
+		library.SetFollowSymlinks(follow)
+		return b, tea.Batch(
+			b.readDir(b.currentDir),
+			func() tea.Msg { return FollowSymlinksMsg{Follow: follow} },
+		)
+
+	case key.Matches(msg, b.KeyMap.GoTo):
+		b.startGoTo()
+		return b, nil
+
+	case key.Matches(msg, b.KeyMap.FullPath):
+		b.fullPath = !b.fullPath
+		b.max = b.min + b.visibleCount() - 1
+		b.updateViewport()
+		return b, nil
+
 	case key.Matches(msg, b.KeyMap.ToggleHidden):
 		b.showHidden = !b.showHidden
-		return b, b.readDir(b.currentDir)
+		// Record first so the re-read picks up the new preference
+		saveCmd := b.rememberCurrentPrefs()
+		return b, tea.Batch(b.readDir(b.currentDir), saveCmd)
 	}
 
 	return b, nil
@@ -484,6 +832,88 @@ func (b Browser) addSelected() (Browser, tea.Cmd) {
 	}
 }
 
+// addRecursive returns a command that finds every VGM file below the
+// selected directory, or below the current directory if a file is selected.
+func (b Browser) addRecursive() tea.Cmd {
+	dir := b.currentDir
+	if entry := b.SelectedEntry(); entry != nil && entry.IsDir {
+		dir = entry.Path
+	}
+	showHidden := b.showHidden
+	return func() tea.Msg {
+		paths, err := FindVGMFiles(dir, showHidden)
+		return DirAddMsg{Dir: dir, Paths: paths, Err: err}
+	}
+}
+
+// toggleMark marks or unmarks a path.
+func (b *Browser) toggleMark(path string) {
+	for i, p := range b.marked {
+		if p == path {
+			b.marked = append(b.marked[:i:i], b.marked[i+1:]...)
+			return
+		}
+	}
+	b.marked = append(b.marked, path)
+}
+
+// isMarked returns true if a path is marked.
+func (b Browser) isMarked(path string) bool {
+	for _, p := range b.marked {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// addMarked clears the marks and returns a command that collects the
+// marked files, recursing into marked directories.
+func (b Browser) addMarked() (Browser, tea.Cmd) {
+	if len(b.marked) == 0 {
+		return b, nil
+	}
+	marked := b.marked
+	b.marked = nil
+	showHidden := b.showHidden
+	return b, func() tea.Msg {
+		var paths []string
+		for _, path := range marked {
+			info, err := os.Stat(path)
+			if err != nil {
+				return MarkedAddMsg{Err: err}
+			}
+			if !info.IsDir() {
+				paths = append(paths, path)
+				continue
+			}
+			found, err := FindVGMFiles(path, showHidden)
+			if err != nil {
+				return MarkedAddMsg{Err: err}
+			}
+			paths = append(paths, found...)
+		}
+		return MarkedAddMsg{Paths: paths}
+	}
+}
+
+// Marked returns the number of marked entries.
+func (b Browser) Marked() int {
+	return len(b.marked)
+}
+
+// FindVGMFiles walks root recursively and returns the paths of all VGM
+// files. Hidden files and directories are skipped unless showHidden is set.
+func FindVGMFiles(root string, showHidden bool) ([]string, error) {
+	var paths []string
+	err := library.WalkFiles(root, showHidden, func(path string, info os.FileInfo) {
+		if (showHidden || !strings.HasPrefix(info.Name(), ".")) && library.IsVGMFile(info.Name()) {
+			paths = append(paths, path)
+		}
+	})
+	return paths, err
+}
+
 // goToParent navigates to the parent directory.
 func (b Browser) goToParent() (Browser, tea.Cmd) {
 	parent := filepath.Dir(b.currentDir)
@@ -522,9 +952,15 @@ func (b *Browser) HandleSelectName(name string) {
 	}
 }
 
+// Filtering returns true while the filter or a path to go to is being
+// typed, in which case the browser wants every key press.
+func (b Browser) Filtering() bool {
+	return b.filtering || b.goingTo
+}
+
 // visibleCount returns the number of visible items.
 func (b Browser) visibleCount() int {
-	count := b.height - 2 // Account for header line and padding
+	count := b.height - 1 - len(b.headerLines()) // Account for header and padding
 	if count < 1 {
 		count = 1
 	}
@@ -576,24 +1012,19 @@ func (b *Browser) updateViewport() {
 func (b Browser) View() string {
 	var s strings.Builder
 
-	// Available width for entry names (minus cursor "  " or "> ")
+	// Available width for entry names (minus cursor "  " or "> " and
+	// the size column)
 	cursorWidth := 2
-	nameWidth := b.width - cursorWidth
+	sizeWidth := 6
+	nameWidth := b.width - cursorWidth - sizeWidth
 	if nameWidth < 5 {
 		nameWidth = 5
 	}
 
-	// Show current directory (truncated if needed)
-	dir := b.currentDir
-	maxDirLen := b.width - 2
-	if maxDirLen < 10 {
-		maxDirLen = 10
-	}
-	if len(dir) > maxDirLen {
-		dir = "..." + dir[len(dir)-maxDirLen+3:]
+	for _, line := range b.headerLines() {
+		s.WriteString(b.Styles.Muted.Render(line))
+		s.WriteRune('\n')
 	}
-	s.WriteString(b.Styles.Muted.Render(dir))
-	s.WriteRune('\n')
 
 	// Handle errors
 	if b.err != nil {
@@ -603,6 +1034,10 @@ func (b Browser) View() string {
 
 	// Handle empty directory
 	if len(b.entries) == 0 {
+		if b.filter != "" {
+			s.WriteString(b.Styles.EmptyDir.Render("(no matches)"))
+			return b.constrainToHeight(s.String())
+		}
 		s.WriteString(b.Styles.EmptyDir.Render("(empty)"))
 		return b.constrainToHeight(s.String())
 	}
@@ -612,11 +1047,17 @@ func (b Browser) View() string {
 		entry := b.entries[i]
 		isSelected := i == b.selected
 
-		// Cursor
+		// Cursor, with a marker in the second column for marked entries
 		cursor := "  "
 		if isSelected {
 			cursor = b.Styles.Cursor.Render("> ")
 		}
+		if b.isMarked(entry.Path) {
+			cursor = " " + b.Styles.Marked.Render("*")
+			if isSelected {
+				cursor = b.Styles.Cursor.Render(">") + b.Styles.Marked.Render("*")
+			}
+		}
 
 		// Build display name
 		var displayName string
@@ -626,8 +1067,14 @@ func (b Browser) View() string {
 			displayName = entry.Name
 		}
 
-		// Truncate or scroll the name to fit
+		// Truncate or scroll the name to fit, padding files out to the
+		// size column
 		displayName = b.fitName(displayName, nameWidth, isSelected)
+		var size string
+		if !entry.IsDir {
+			padding := strings.Repeat(" ", max(0, nameWidth-lipgloss.Width(displayName)))
+			size = padding + fmt.Sprintf(" %*s", sizeWidth-1, formatSize(entry.Size))
+		}
 
 		// Apply style
 		var styledName string
@@ -645,7 +1092,7 @@ func (b Browser) View() string {
 			}
 		}
 
-		s.WriteString(cursor + styledName)
+		s.WriteString(cursor + styledName + b.Styles.Muted.Render(size))
 		s.WriteRune('\n')
 	}
 
@@ -656,25 +1103,13 @@ func (b Browser) View() string {
 // For selected items, it scrolls to show the end of long names.
 // For non-selected items, it truncates with "..." suffix.
 func (b Browser) fitName(name string, maxWidth int, isSelected bool) string {
-	if len(name) <= maxWidth {
-		return name
-	}
-
 	if isSelected {
 		// Scroll: show the end portion with "..." prefix
-		visibleLen := maxWidth - 3 // Account for "..."
-		if visibleLen < 1 {
-			visibleLen = 1
-		}
-		return "..." + name[len(name)-visibleLen:]
+		return truncateStart(name, maxWidth)
 	}
 
 	// Truncate: show the beginning with "..." suffix
-	visibleLen := maxWidth - 3 // Account for "..."
-	if visibleLen < 1 {
-		visibleLen = 1
-	}
-	return name[:visibleLen] + "..."
+	return truncateEnd(name, maxWidth)
 }
 
 // constrainToHeight ensures the rendered content fits within the browser's height.
@@ -0,0 +1,155 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyMsg returns the key message for a key as bindings name it, such as
+// "a", "enter" or "ctrl+g".
+func keyMsg(k string) tea.KeyMsg {
+	for t := tea.KeyType(-100); t < 200; t++ {
+		if t != tea.KeyRunes && (tea.Key{Type: t}).String() == k {
+			return tea.KeyMsg{Type: t}
+		}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// runBrowser runs cmd and feeds the directory listings it reads back to
+// the browser, returning the other messages it produced.
+func runBrowser(b Browser, cmd tea.Cmd) (Browser, []tea.Msg) {
+	if cmd == nil {
+		return b, nil
+	}
+	var other []tea.Msg
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			var msgs []tea.Msg
+			b, msgs = runBrowser(b, c)
+			other = append(other, msgs...)
+		}
+	case BrowserReadDirMsg:
+		b, _ = b.Update(msg)
+	default:
+		other = append(other, msg)
+	}
+	return b, other
+}
+
+// pressBrowser sends keys to the browser, running the commands they
+// return.
+func pressBrowser(b Browser, keys ...string) (Browser, []tea.Msg) {
+	var msgs []tea.Msg
+	for _, k := range keys {
+		var cmd tea.Cmd
+		b, cmd = b.Update(keyMsg(k))
+		var more []tea.Msg
+		b, more = runBrowser(b, cmd)
+		msgs = append(msgs, more...)
+	}
+	return b, msgs
+}
+
+// enterDir selects the directory named name and opens it.
+func enterDir(t *testing.T, b Browser, name string) Browser {
+	t.Helper()
+	b, _ = pressBrowser(b, "g")
+	for b.SelectedEntry() == nil || b.SelectedEntry().Name != name {
+		before := b.selected
+		b, _ = pressBrowser(b, "j")
+		if b.selected == before {
+			t.Fatalf("no entry %q in %s", name, b.currentDir)
+		}
+	}
+	b, _ = pressBrowser(b, "enter")
+	return b
+}
+
+func TestBrowserDirPrefs(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"alpha/a.vgm", "alpha/.hidden.vgm", "alpha/b.vgz", "beta/x.vgm"} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	alpha := filepath.Join(root, "alpha")
+
+	tests := []struct {
+		remember bool
+		// Preferences expected in beta and back in alpha
+		beta, alpha DirPrefs
+	}{
+		{
+			remember: true,
+			beta:     DirPrefs{},
+			alpha:    DirPrefs{ShowHidden: true, Filter: "vg", Sort: SortBySize},
+		},
+		{
+			// Without remembering, the sort and hidden files carry over
+			// but the filter stays behind
+			remember: false,
+			beta:     DirPrefs{ShowHidden: true, Sort: SortBySize},
+			alpha:    DirPrefs{ShowHidden: true, Sort: SortBySize},
+		},
+	}
+	for _, tt := range tests {
+		b := NewBrowser(root)
+		b.Focus()
+		b.SetRememberPrefs(tt.remember, nil)
+		b, _ = runBrowser(b, b.Init())
+
+		b = enterDir(t, b, "alpha")
+		b, msgs := pressBrowser(b, "o", ".", "/", "v", "g", "enter")
+		set := DirPrefs{ShowHidden: true, Filter: "vg", Sort: SortBySize}
+		if got := b.currentPrefs(); got != set {
+			t.Fatalf("remember %v: prefs in alpha = %+v, want %+v", tt.remember, got, set)
+		}
+		var saved map[string]DirPrefs
+		for _, msg := range msgs {
+			if changed, ok := msg.(DirPrefsChangedMsg); ok {
+				saved = changed.Prefs
+			}
+		}
+		if tt.remember && saved[alpha] != set {
+			t.Errorf("saved prefs = %+v, want %+v for %s", saved, set, alpha)
+		}
+		if !tt.remember && saved != nil {
+			t.Errorf("prefs saved while not remembering: %+v", saved)
+		}
+
+		b, _ = pressBrowser(b, "backspace")
+		b = enterDir(t, b, "beta")
+		if got := b.currentPrefs(); got != tt.beta {
+			t.Errorf("remember %v: prefs in beta = %+v, want %+v", tt.remember, got, tt.beta)
+		}
+
+		b, _ = pressBrowser(b, "backspace")
+		b = enterDir(t, b, "alpha")
+		if got := b.currentPrefs(); got != tt.alpha {
+			t.Errorf("remember %v: prefs back in alpha = %+v, want %+v", tt.remember, got, tt.alpha)
+		}
+	}
+}
+
+func TestBrowserSetRememberPrefsRestores(t *testing.T) {
+	root := t.TempDir()
+	saved := map[string]DirPrefs{root: {Filter: "boss", Sort: SortByTime}}
+
+	b := NewBrowser(root)
+	b.SetRememberPrefs(true, saved)
+	if got := b.currentPrefs(); got != saved[root] {
+		t.Errorf("prefs after SetRememberPrefs = %+v, want %+v", got, saved[root])
+	}
+	if cmd := b.readDir(root); cmd().(BrowserReadDirMsg).Prefs != saved[root] {
+		t.Errorf("listing the start directory does not use its saved prefs")
+	}
+}
@@ -0,0 +1,159 @@
+package components
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startGoTo opens the go to path input, filled in with the current
+// directory so a nearby path only needs editing.
+func (b *Browser) startGoTo() {
+	b.goingTo = true
+	b.goToErr = ""
+	b.goToInput = abbreviateHome(b.currentDir)
+	if !strings.HasSuffix(b.goToInput, string(filepath.Separator)) {
+		b.goToInput += string(filepath.Separator)
+	}
+}
+
+// handleGoToKey handles keyboard input while a path is being typed. Enter
+// goes to the directory, tab completes the directory name being typed and
+// esc cancels.
+func (b Browser) handleGoToKey(msg tea.KeyMsg) (Browser, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		dir, err := b.resolvePath(b.goToInput)
+		if err != nil {
+			b.goToErr = err.Error()
+			return b, nil
+		}
+		b.goingTo = false
+		b.selected = 0
+		b.min = 0
+		b.max = b.visibleCount() - 1
+		return b, tea.Batch(
+			b.readDir(dir),
+			func() tea.Msg { return DirChangedMsg{Path: dir} },
+		)
+	case tea.KeyEsc:
+		b.goingTo = false
+	case tea.KeyTab:
+		b.goToInput = b.completePath(b.goToInput)
+		b.goToErr = ""
+	case tea.KeyBackspace:
+		if r := []rune(b.goToInput); len(r) > 0 {
+			b.goToInput = string(r[:len(r)-1])
+		}
+		b.goToErr = ""
+	case tea.KeyRunes, tea.KeySpace:
+		b.goToInput += string(msg.Runes)
+		b.goToErr = ""
+	}
+	return b, nil
+}
+
+// resolvePath returns the absolute directory a typed path names. A leading
+// "~" stands for the home directory, and relative paths start from the
+// current directory.
+func (b Browser) resolvePath(typed string) (string, error) {
+	path := expandHome(strings.TrimSpace(typed))
+	if path == "" {
+		return "", errors.New("no path")
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(b.currentDir, path)
+	}
+	path = filepath.Clean(path)
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", errors.New("no such directory")
+	}
+	if !info.IsDir() {
+		return "", errors.New("not a directory")
+	}
+	return path, nil
+}
+
+// completePath completes the last component of a typed path to the
+// directories starting with it: all of a single match, followed by a
+// separator, or as much as the matches have in common. Hidden directories
+// are offered only while shown or once a "." is typed.
+func (b Browser) completePath(typed string) string {
+	sep := string(filepath.Separator)
+	parent, prefix := "", typed
+	if i := strings.LastIndex(typed, sep); i >= 0 {
+		parent, prefix = typed[:i+1], typed[i+1:]
+	}
+
+	dir := expandHome(parent)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(b.currentDir, dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return typed
+	}
+
+	var matches []string
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, prefix) || (strings.HasPrefix(name, ".") && !b.showHidden && !strings.HasPrefix(prefix, ".")) {
+			continue
+		}
+		if e.IsDir() || (e.Type()&os.ModeSymlink != 0 && isDir(filepath.Join(dir, name))) {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return typed
+	case 1:
+		return parent + matches[0] + sep
+	}
+	common := matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, common) {
+			r := []rune(common)
+			common = string(r[:len(r)-1])
+		}
+	}
+	return parent + common
+}
+
+// isDir reports whether path is a directory, following symlinks.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// expandHome replaces a leading "~" with the home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return home + path[1:]
+}
+
+// abbreviateHome replaces the home directory at the start of path with
+// "~".
+func abbreviateHome(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" || home == string(filepath.Separator) {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if rest, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return "~" + string(filepath.Separator) + rest
+	}
+	return path
+}
@@ -0,0 +1,208 @@
+// Package components provides UI components for vgmtui.
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+)
+
+// ChipFilterOpenMsg is sent by the library browser to open the chip filter
+// selector with the chips present in the library.
+type ChipFilterOpenMsg struct {
+	Chips   []library.Count // Chips and how many tracks use each
+	Current string          // Active chip filter ("" if none)
+}
+
+// ChipFilterSelectMsg is sent when a chip filter is chosen.
+// An empty Chip clears the filter.
+type ChipFilterSelectMsg struct {
+	Chip string
+}
+
+// ChipFilterPopupKeyMap defines key bindings for the chip filter popup.
+type ChipFilterPopupKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Close  key.Binding
+}
+
+// DefaultChipFilterPopupKeyMap returns the default chip filter popup key bindings.
+func DefaultChipFilterPopupKeyMap() ChipFilterPopupKeyMap {
+	return ChipFilterPopupKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "down"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "filter"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("F", "esc", "q"),
+			key.WithHelp("F/esc", "close"),
+		),
+	}
+}
+
+// ChipFilterPopup is an overlay listing the sound chips in the library,
+// from which one is chosen to narrow the library tree. The first entry
+// clears the filter.
+type ChipFilterPopup struct {
+	chips   []library.Count
+	cursor  int // 0 is "All chips", i > 0 is chips[i-1]
+	offset  int // First visible entry
+	visible bool
+	width   int
+	height  int
+
+	keyMap ChipFilterPopupKeyMap
+
+	// Styles
+	styles PopupStyles
+}
+
+// NewChipFilterPopup creates a new chip filter popup.
+func NewChipFilterPopup() ChipFilterPopup {
+	return ChipFilterPopup{
+		width:  60,
+		height: 24,
+		keyMap: DefaultChipFilterPopupKeyMap(),
+		styles: DefaultPopupStyles(),
+	}
+}
+
+// Update handles messages for the chip filter popup.
+func (c ChipFilterPopup) Update(msg tea.Msg) (ChipFilterPopup, tea.Cmd) {
+	if !c.visible {
+		return c, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return c, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, c.keyMap.Close):
+		c.visible = false
+	case key.Matches(keyMsg, c.keyMap.Up):
+		c.moveCursor(-1)
+	case key.Matches(keyMsg, c.keyMap.Down):
+		c.moveCursor(1)
+	case key.Matches(keyMsg, c.keyMap.Select):
+		chip := ""
+		if c.cursor > 0 {
+			chip = c.chips[c.cursor-1].Name
+		}
+		c.visible = false
+		return c, func() tea.Msg { return ChipFilterSelectMsg{Chip: chip} }
+	}
+	return c, nil
+}
+
+// moveCursor moves the cursor by delta entries, scrolling to keep it visible.
+func (c *ChipFilterPopup) moveCursor(delta int) {
+	c.cursor = max(0, min(c.cursor+delta, len(c.chips)))
+	rows := c.listHeight()
+	if c.cursor < c.offset {
+		c.offset = c.cursor
+	}
+	if c.cursor >= c.offset+rows {
+		c.offset = c.cursor - rows + 1
+	}
+}
+
+// View renders the chip filter popup as an overlay.
+func (c ChipFilterPopup) View() string {
+	if !c.visible {
+		return ""
+	}
+
+	popupWidth := c.popupWidth()
+	return c.styles.renderPopup("Filter by Chip", "Enter: filter  F/Esc: close", popupWidth,
+		c.buildContent(popupWidth-4))
+}
+
+// popupWidth returns the width of the popup box for the current size.
+func (c ChipFilterPopup) popupWidth() int {
+	return clampWidth(c.width, 60, 36, 50)
+}
+
+// listHeight returns the number of entries shown at once.
+func (c ChipFilterPopup) listHeight() int {
+	rows := c.height*80/100 - 6
+	if rows < 5 {
+		rows = 5
+	}
+	return rows
+}
+
+// buildContent renders the visible entries, one per line, as
+// "chip  tracks".
+func (c ChipFilterPopup) buildContent(width int) string {
+	var b strings.Builder
+	end := min(c.offset+c.listHeight(), len(c.chips)+1)
+	for i := c.offset; i < end; i++ {
+		label, count := "All chips", ""
+		if i > 0 {
+			label = c.chips[i-1].Name
+			count = fmt.Sprintf("%d", c.chips[i-1].Count)
+		}
+		nameWidth := width - 10
+		line := fmt.Sprintf("%-*s%8s", nameWidth, truncateEnd(label, nameWidth), count)
+
+		if i == c.cursor {
+			b.WriteString(c.styles.Key.Render("> " + line))
+		} else {
+			b.WriteString(c.styles.Desc.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// SetSize sets the available size for the chip filter popup.
+func (c *ChipFilterPopup) SetSize(width, height int) {
+	c.width = width
+	c.height = height
+}
+
+// SetStyles sets the popup styles.
+func (c *ChipFilterPopup) SetStyles(styles PopupStyles) {
+	c.styles = styles
+}
+
+// Show makes the chip filter popup visible with the given chips, placing
+// the cursor on the current filter.
+func (c *ChipFilterPopup) Show(chips []library.Count, current string) {
+	c.chips = chips
+	c.cursor = 0
+	c.offset = 0
+	for i, chip := range chips {
+		if chip.Name == current {
+			c.moveCursor(i + 1)
+			break
+		}
+	}
+	c.visible = true
+}
+
+// Hide makes the chip filter popup invisible.
+func (c *ChipFilterPopup) Hide() {
+	c.visible = false
+}
+
+// Visible returns whether the chip filter popup is visible.
+func (c ChipFilterPopup) Visible() bool {
+	return c.visible
+}
@@ -0,0 +1,232 @@
+// Package components provides UI components for vgmtui.
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// ChipPopupKeyMap defines key bindings for the chip details popup.
+type ChipPopupKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Solo  key.Binding
+	Close key.Binding
+}
+
+// DefaultChipPopupKeyMap returns the default chip popup key bindings.
+func DefaultChipPopupKeyMap() ChipPopupKeyMap {
+	return ChipPopupKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "down"),
+		),
+		Solo: key.NewBinding(
+			key.WithKeys("s", " "),
+			key.WithHelp("s", "solo"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("c", "esc", "enter", "q"),
+			key.WithHelp("c/esc", "close"),
+		),
+	}
+}
+
+// ChipSoloMsg is sent when the chip popup solos a chip for audition.
+// Index is the chip to solo, or -1 to restore all chips.
+type ChipSoloMsg struct {
+	Index int
+}
+
+// ChipPopup is an overlay listing the sound chips of the current track
+// together with their emulation cores and clock rates.
+type ChipPopup struct {
+	chips    []metadata.ChipInfo
+	selected int
+	soloed   int // Index of the soloed chip (-1 if none)
+	visible  bool
+	width    int
+	height   int
+
+	keyMap ChipPopupKeyMap
+
+	// Styles
+	styles PopupStyles
+}
+
+// NewChipPopup creates a new chip details popup.
+func NewChipPopup() ChipPopup {
+	return ChipPopup{
+		width:  60,
+		height: 24,
+		soloed: -1,
+		keyMap: DefaultChipPopupKeyMap(),
+		styles: DefaultPopupStyles(),
+	}
+}
+
+// Update handles messages for the chip popup.
+func (c ChipPopup) Update(msg tea.Msg) (ChipPopup, tea.Cmd) {
+	if !c.visible {
+		return c, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, c.keyMap.Close):
+			c.visible = false
+			return c, c.setSolo(-1) // Restore all chips on close
+		case key.Matches(msg, c.keyMap.Solo):
+			if len(c.chips) == 0 {
+				break
+			}
+			if c.soloed == c.selected {
+				return c, c.setSolo(-1)
+			}
+			return c, c.setSolo(c.selected)
+		case key.Matches(msg, c.keyMap.Up):
+			if c.selected > 0 {
+				c.selected--
+			}
+		case key.Matches(msg, c.keyMap.Down):
+			if c.selected < len(c.chips)-1 {
+				c.selected++
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// setSolo changes the soloed chip and returns a command announcing it,
+// or nil if nothing changed.
+func (c *ChipPopup) setSolo(index int) tea.Cmd {
+	if c.soloed == index {
+		return nil
+	}
+	c.soloed = index
+	return func() tea.Msg {
+		return ChipSoloMsg{Index: index}
+	}
+}
+
+// View renders the chip popup as an overlay.
+func (c ChipPopup) View() string {
+	if !c.visible {
+		return ""
+	}
+
+	var b strings.Builder
+
+	// Hide the clock column entirely when no chip reports one
+	showClock := false
+	for _, chip := range c.chips {
+		if chip.Clock > 0 {
+			showClock = true
+			break
+		}
+	}
+
+	if len(c.chips) == 0 {
+		b.WriteString(c.styles.Footer.Render("No chip information available"))
+	} else {
+		header := fmt.Sprintf("  %-16s %-8s", "Chip", "Core")
+		if showClock {
+			header += fmt.Sprintf(" %12s", "Clock")
+		}
+		b.WriteString(c.styles.Category.Render(header))
+
+		for i, chip := range c.chips {
+			b.WriteString("\n")
+			row := fmt.Sprintf("%-16s %-8s", chip.Name, chip.Core)
+			if showClock {
+				row += fmt.Sprintf(" %12s", FormatClock(chip.Clock))
+			}
+			if i == c.soloed {
+				row += " SOLO"
+			}
+			if i == c.selected {
+				b.WriteString(c.styles.Key.Render("> " + row))
+			} else {
+				b.WriteString(c.styles.Desc.Render("  " + row))
+			}
+		}
+	}
+
+	return c.styles.renderPopup("Sound Chips", "s: solo chip | c/Esc: close", clampWidth(c.width, 70, 40, 60), b.String())
+}
+
+// FormatClock formats a chip clock rate in Hz for display.
+// Returns an empty string if the clock is unknown (0).
+func FormatClock(hz uint32) string {
+	switch {
+	case hz == 0:
+		return ""
+	case hz >= 1000000:
+		return fmt.Sprintf("%.3f MHz", float64(hz)/1e6)
+	case hz >= 1000:
+		return fmt.Sprintf("%.1f kHz", float64(hz)/1e3)
+	default:
+		return fmt.Sprintf("%d Hz", hz)
+	}
+}
+
+// SetSize sets the available size for the chip popup.
+func (c *ChipPopup) SetSize(width, height int) {
+	c.width = width
+	c.height = height
+}
+
+// SetStyles sets the popup styles.
+func (c *ChipPopup) SetStyles(styles PopupStyles) {
+	c.styles = styles
+}
+
+// SetChips replaces the chip list, keeping the selection in range.
+// Any solo is dropped, as a newly loaded track starts with all chips unmuted.
+func (c *ChipPopup) SetChips(chips []metadata.ChipInfo) {
+	c.chips = chips
+	c.soloed = -1
+	if c.selected >= len(c.chips) {
+		c.selected = len(c.chips) - 1
+	}
+	if c.selected < 0 {
+		c.selected = 0
+	}
+}
+
+// Show makes the chip popup visible with the given chips.
+func (c *ChipPopup) Show(chips []metadata.ChipInfo) {
+	c.visible = true
+	c.selected = 0
+	c.SetChips(chips)
+}
+
+// Hide makes the chip popup invisible.
+func (c *ChipPopup) Hide() {
+	c.visible = false
+}
+
+// Visible returns whether the chip popup is visible.
+func (c ChipPopup) Visible() bool {
+	return c.visible
+}
+
+// Soloed returns the index of the soloed chip, or -1 if none.
+func (c ChipPopup) Soloed() int {
+	return c.soloed
+}
+
+// Selected returns the index of the highlighted chip.
+func (c ChipPopup) Selected() int {
+	return c.selected
+}
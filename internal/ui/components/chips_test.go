@@ -0,0 +1,72 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+func TestChipPopupSolo(t *testing.T) {
+	chips := []metadata.ChipInfo{{Name: "YM2612"}, {Name: "SN76489"}, {Name: "RF5C164"}}
+
+	// Each step presses a key and expects the soloed chip after it and the
+	// solo message sent (none if want is -2)
+	const none = -2
+	steps := []struct {
+		key     string
+		soloed  int
+		sent    int
+		visible bool
+	}{
+		{"s", 0, 0, true},      // Solo the first chip
+		{"j", 0, none, true},   // Moving doesn't change the solo
+		{"s", 1, 1, true},      // Solo the next chip in its place
+		{"s", -1, -1, true},    // Soloing the soloed chip restores all
+		{"j", -1, none, true},  // Move to the last chip
+		{"s", 2, 2, true},      // and solo it
+		{"esc", -1, -1, false}, // Closing restores all chips
+		{"s", -1, none, false}, // A hidden popup ignores keys
+	}
+
+	c := NewChipPopup()
+	c.Show(chips)
+	for i, step := range steps {
+		var sent tea.Cmd
+		c, sent = c.Update(keyMsg(step.key))
+		if c.Soloed() != step.soloed || c.Visible() != step.visible {
+			t.Fatalf("step %d (%s): soloed %d, visible %v; want %d, %v",
+				i, step.key, c.Soloed(), c.Visible(), step.soloed, step.visible)
+		}
+		switch {
+		case step.sent == none && sent != nil:
+			t.Fatalf("step %d (%s): sent %#v, want nothing", i, step.key, sent())
+		case step.sent != none && sent == nil:
+			t.Fatalf("step %d (%s): sent nothing, want solo of %d", i, step.key, step.sent)
+		case step.sent != none:
+			if msg := sent().(ChipSoloMsg); msg.Index != step.sent {
+				t.Fatalf("step %d (%s): solo of %d sent, want %d", i, step.key, msg.Index, step.sent)
+			}
+		}
+	}
+}
+
+func TestChipPopupSoloReset(t *testing.T) {
+	c := NewChipPopup()
+	c.Show(nil)
+	if _, cmd := c.Update(keyMsg("s")); cmd != nil || c.Soloed() != -1 {
+		t.Errorf("solo without chips: soloed %d, command %v", c.Soloed(), cmd != nil)
+	}
+
+	// A new track's chips start unmuted, so the solo is dropped quietly
+	c.Show([]metadata.ChipInfo{{Name: "YM2151"}, {Name: "SegaPCM"}})
+	c, _ = c.Update(keyMsg("s"))
+	c.SetChips([]metadata.ChipInfo{{Name: "YM2612"}})
+	if c.Soloed() != -1 {
+		t.Errorf("soloed %d after new chips, want -1", c.Soloed())
+	}
+	if _, cmd := c.Update(keyMsg("esc")); cmd != nil {
+		t.Errorf("closing after new chips sent %#v, want nothing", cmd())
+	}
+}
@@ -0,0 +1,228 @@
+// Package components provides UI components for vgmtui.
+package components
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+)
+
+// DiffPopupKeyMap defines key bindings for the library diff popup.
+type DiffPopupKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
+	Close    key.Binding
+}
+
+// DefaultDiffPopupKeyMap returns the default library diff popup key bindings.
+func DefaultDiffPopupKeyMap() DiffPopupKeyMap {
+	return DiffPopupKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "scroll up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "scroll down"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup", "ctrl+u"),
+			key.WithHelp("pgup", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+d"),
+			key.WithHelp("pgdn", "page down"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("U", "esc", "enter", "q"),
+			key.WithHelp("U/esc", "close"),
+		),
+	}
+}
+
+// DiffPopup is an overlay listing the tracks added, removed and modified
+// by the last library scan compared to the one before it.
+type DiffPopup struct {
+	viewport viewport.Model
+	visible  bool
+	width    int
+	height   int
+
+	// Diff being shown
+	diff     library.Diff
+	root     string
+	since    time.Time
+	baseline bool // False if there was no earlier scan to compare with
+
+	keyMap DiffPopupKeyMap
+
+	// Styles
+	styles PopupStyles
+}
+
+// NewDiffPopup creates a new library diff popup.
+func NewDiffPopup() DiffPopup {
+	vp := viewport.New(50, 20)
+	vp.MouseWheelEnabled = true
+
+	return DiffPopup{
+		viewport: vp,
+		width:    60,
+		height:   24,
+		keyMap:   DefaultDiffPopupKeyMap(),
+		styles:   DefaultPopupStyles(),
+	}
+}
+
+// Update handles messages for the diff popup.
+func (d DiffPopup) Update(msg tea.Msg) (DiffPopup, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, d.keyMap.Close):
+			d.visible = false
+			return d, nil
+		case key.Matches(msg, d.keyMap.Up):
+			d.viewport.ScrollUp(1)
+		case key.Matches(msg, d.keyMap.Down):
+			d.viewport.ScrollDown(1)
+		case key.Matches(msg, d.keyMap.PageUp):
+			d.viewport.PageUp()
+		case key.Matches(msg, d.keyMap.PageDown):
+			d.viewport.PageDown()
+		}
+		return d, nil
+	}
+
+	var cmd tea.Cmd
+	d.viewport, cmd = d.viewport.Update(msg)
+	return d, cmd
+}
+
+// View renders the diff popup as an overlay.
+func (d DiffPopup) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	return d.styles.renderPopup("Library Changes", "Press U or Esc to close", d.popupWidth(), d.viewport.View())
+}
+
+// popupWidth returns the width of the popup box for the current size.
+func (d DiffPopup) popupWidth() int {
+	return clampWidth(d.width, 80, 45, 80)
+}
+
+// buildContent creates the diff text content.
+func (d DiffPopup) buildContent() string {
+	var b strings.Builder
+
+	if !d.baseline {
+		b.WriteString(d.styles.Desc.Render("No earlier scan to compare with."))
+		b.WriteString("\n")
+		b.WriteString(d.styles.Footer.Render("Changes are shown from the next rescan (R) on."))
+		return b.String()
+	}
+
+	summary := fmt.Sprintf("+%d added  -%d removed  ~%d modified",
+		len(d.diff.Added), len(d.diff.Removed), len(d.diff.Modified))
+	b.WriteString(d.styles.Key.Render(summary))
+	b.WriteString("\n")
+	if !d.since.IsZero() {
+		b.WriteString(d.styles.Footer.Render("since scan of " + d.since.Format("2006-01-02 15:04")))
+		b.WriteString("\n")
+	}
+
+	if d.diff.Empty() {
+		b.WriteString("\n")
+		b.WriteString(d.styles.Desc.Render("No changes."))
+		return b.String()
+	}
+
+	addSection := func(title, marker string, tracks []library.Track) {
+		if len(tracks) == 0 {
+			return
+		}
+		b.WriteString("\n")
+		b.WriteString(d.styles.Category.Render(title))
+		b.WriteString("\n")
+		for _, t := range tracks {
+			b.WriteString(d.styles.Desc.Render(marker + " " + d.relPath(t.Path)))
+			b.WriteString("\n")
+		}
+	}
+	addSection("Added", "+", d.diff.Added)
+	addSection("Removed", "-", d.diff.Removed)
+	addSection("Modified", "~", d.diff.Modified)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// relPath returns a track path relative to the library root where possible.
+func (d DiffPopup) relPath(path string) string {
+	if rel, err := filepath.Rel(d.root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
+// SetSize sets the available size for the diff popup.
+func (d *DiffPopup) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+
+	contentHeight := height * 80 / 100
+	if contentHeight < 15 {
+		contentHeight = 15
+	}
+	if contentHeight > 30 {
+		contentHeight = 30
+	}
+
+	d.viewport.Width = d.popupWidth() - 4
+	d.viewport.Height = contentHeight - 4
+}
+
+// SetStyles sets the popup styles.
+func (d *DiffPopup) SetStyles(styles PopupStyles) {
+	d.styles = styles
+}
+
+// SetDiff sets the changes to show. root is the library root used to
+// shorten paths, since is when the earlier scan ran, and baseline is false
+// if there was no earlier scan at all.
+func (d *DiffPopup) SetDiff(diff library.Diff, root string, since time.Time, baseline bool) {
+	d.diff = diff
+	d.root = root
+	d.since = since
+	d.baseline = baseline
+}
+
+// Show makes the diff popup visible.
+func (d *DiffPopup) Show() {
+	d.visible = true
+	d.viewport.SetContent(d.buildContent())
+	d.viewport.GotoTop()
+}
+
+// Hide makes the diff popup invisible.
+func (d *DiffPopup) Hide() {
+	d.visible = false
+}
+
+// Visible returns whether the diff popup is visible.
+func (d DiffPopup) Visible() bool {
+	return d.visible
+}
@@ -0,0 +1,247 @@
+// Package components provides UI components for vgmtui.
+package components
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+)
+
+// DuplicatesFindMsg is sent to search the library for duplicate tracks.
+type DuplicatesFindMsg struct {
+	Mode library.DuplicateMode
+}
+
+// DuplicatesPopupKeyMap defines key bindings for the duplicates popup.
+type DuplicatesPopupKeyMap struct {
+	Up         key.Binding
+	Down       key.Binding
+	PageUp     key.Binding
+	PageDown   key.Binding
+	SwitchMode key.Binding
+	Close      key.Binding
+}
+
+// DefaultDuplicatesPopupKeyMap returns the default duplicates popup key bindings.
+func DefaultDuplicatesPopupKeyMap() DuplicatesPopupKeyMap {
+	return DuplicatesPopupKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "scroll up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "scroll down"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup", "ctrl+u"),
+			key.WithHelp("pgup", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+d"),
+			key.WithHelp("pgdn", "page down"),
+		),
+		SwitchMode: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "metadata/content"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("D", "esc", "enter", "q"),
+			key.WithHelp("D/esc", "close"),
+		),
+	}
+}
+
+// DuplicatesPopup is an overlay listing groups of duplicate library
+// tracks, matched by metadata or by file content.
+type DuplicatesPopup struct {
+	viewport viewport.Model
+	visible  bool
+	width    int
+	height   int
+
+	// Report being shown
+	mode      library.DuplicateMode
+	groups    []library.DuplicateGroup
+	root      string
+	searching bool // True until the results for mode arrive
+
+	keyMap DuplicatesPopupKeyMap
+
+	// Styles
+	styles PopupStyles
+}
+
+// NewDuplicatesPopup creates a new duplicates popup.
+func NewDuplicatesPopup() DuplicatesPopup {
+	vp := viewport.New(50, 20)
+	vp.MouseWheelEnabled = true
+
+	return DuplicatesPopup{
+		viewport: vp,
+		width:    60,
+		height:   24,
+		keyMap:   DefaultDuplicatesPopupKeyMap(),
+		styles:   DefaultPopupStyles(),
+	}
+}
+
+// Update handles messages for the duplicates popup.
+func (d DuplicatesPopup) Update(msg tea.Msg) (DuplicatesPopup, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, d.keyMap.Close):
+			d.visible = false
+			return d, nil
+		case key.Matches(msg, d.keyMap.SwitchMode):
+			if !d.searching {
+				mode := d.mode.Next()
+				return d, func() tea.Msg { return DuplicatesFindMsg{Mode: mode} }
+			}
+		case key.Matches(msg, d.keyMap.Up):
+			d.viewport.ScrollUp(1)
+		case key.Matches(msg, d.keyMap.Down):
+			d.viewport.ScrollDown(1)
+		case key.Matches(msg, d.keyMap.PageUp):
+			d.viewport.PageUp()
+		case key.Matches(msg, d.keyMap.PageDown):
+			d.viewport.PageDown()
+		}
+		return d, nil
+	}
+
+	var cmd tea.Cmd
+	d.viewport, cmd = d.viewport.Update(msg)
+	return d, cmd
+}
+
+// View renders the duplicates popup as an overlay.
+func (d DuplicatesPopup) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	footer := "c: match by " + d.mode.Next().String() + "  D/Esc: close"
+	return d.styles.renderPopup("Duplicate Tracks", footer, d.popupWidth(), d.viewport.View())
+}
+
+// popupWidth returns the width of the popup box for the current size.
+func (d DuplicatesPopup) popupWidth() int {
+	return clampWidth(d.width, 80, 45, 100)
+}
+
+// buildContent creates the report text content.
+func (d DuplicatesPopup) buildContent() string {
+	if d.searching {
+		if d.mode == library.DuplicatesByContent {
+			return d.styles.Desc.Render("Hashing files...")
+		}
+		return d.styles.Desc.Render("Searching...")
+	}
+	if len(d.groups) == 0 {
+		return d.styles.Desc.Render("No duplicates found by " + d.mode.String() + ".")
+	}
+
+	extra := 0
+	for _, g := range d.groups {
+		extra += len(g.Tracks) - 1
+	}
+
+	var b strings.Builder
+	b.WriteString(d.styles.Footer.Render(fmt.Sprintf(
+		"%d groups by %s, %d redundant files", len(d.groups), d.mode, extra)))
+	b.WriteString("\n")
+
+	width := d.popupWidth() - 8
+	for _, g := range d.groups {
+		label := g.Key
+		if d.mode == library.DuplicatesByContent {
+			label = g.Tracks[0].Title + " [" + g.Key[:12] + "]"
+		}
+		b.WriteString("\n")
+		b.WriteString(d.styles.Category.Render(truncateEnd(label, width)))
+		b.WriteString("\n")
+		for _, t := range g.Tracks {
+			b.WriteString(d.styles.Key.Render("  "))
+			b.WriteString(d.styles.Desc.Render(truncateEnd(d.relPath(t.Path), width)))
+			b.WriteString("\n")
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// relPath returns a track path relative to the library root where possible.
+func (d DuplicatesPopup) relPath(path string) string {
+	if rel, err := filepath.Rel(d.root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
+// SetSize sets the available size for the duplicates popup.
+func (d *DuplicatesPopup) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+
+	contentHeight := height * 80 / 100
+	if contentHeight < 15 {
+		contentHeight = 15
+	}
+	if contentHeight > 40 {
+		contentHeight = 40
+	}
+
+	d.viewport.Width = d.popupWidth() - 4
+	d.viewport.Height = contentHeight - 4
+	d.viewport.SetContent(d.buildContent())
+}
+
+// SetStyles sets the popup styles.
+func (d *DuplicatesPopup) SetStyles(styles PopupStyles) {
+	d.styles = styles
+}
+
+// ShowSearching makes the popup visible while duplicates are found by mode
+// in the library rooted at root.
+func (d *DuplicatesPopup) ShowSearching(mode library.DuplicateMode, root string) {
+	d.mode = mode
+	d.root = root
+	d.groups = nil
+	d.searching = true
+	d.visible = true
+	d.viewport.SetContent(d.buildContent())
+	d.viewport.GotoTop()
+}
+
+// SetGroups shows the duplicate groups found by mode. Results for a mode
+// other than the one being searched for are ignored.
+func (d *DuplicatesPopup) SetGroups(mode library.DuplicateMode, groups []library.DuplicateGroup) {
+	if mode != d.mode {
+		return
+	}
+	d.groups = groups
+	d.searching = false
+	d.viewport.SetContent(d.buildContent())
+	d.viewport.GotoTop()
+}
+
+// Hide makes the duplicates popup invisible.
+func (d *DuplicatesPopup) Hide() {
+	d.visible = false
+}
+
+// Visible returns whether the duplicates popup is visible.
+func (d DuplicatesPopup) Visible() bool {
+	return d.visible
+}
@@ -0,0 +1,29 @@
+package components
+
+import "sort"
+
+// FavoriteMarker is shown next to favorite tracks.
+const FavoriteMarker = "*"
+
+// Favorites is a set of favorite track paths.
+type Favorites map[string]bool
+
+// Has reports whether path is a favorite. It is safe on a nil set.
+func (f Favorites) Has(path string) bool {
+	return f[path]
+}
+
+// Paths returns the favorite paths in sorted order.
+func (f Favorites) Paths() []string {
+	paths := make([]string, 0, len(f))
+	for path := range f {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// FavoriteToggleMsg is sent to star or unstar the track at Path.
+type FavoriteToggleMsg struct {
+	Path string
+}
@@ -18,12 +18,46 @@ type HelpPopup struct {
 	height   int
 
 	// Styles
-	borderStyle   lipgloss.Style
-	titleStyle    lipgloss.Style
-	categoryStyle lipgloss.Style
-	keyStyle      lipgloss.Style
-	descStyle     lipgloss.Style
-	footerStyle   lipgloss.Style
+	styles PopupStyles
+}
+
+// HelpSection is a titled group of key bindings shown in the help popup.
+type HelpSection struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// PopupStyles contains styles shared by the overlay popups.
+type PopupStyles struct {
+	Border   lipgloss.Style
+	Title    lipgloss.Style
+	Category lipgloss.Style
+	Key      lipgloss.Style
+	Desc     lipgloss.Style
+	Footer   lipgloss.Style
+}
+
+// DefaultPopupStyles returns the default popup styles.
+func DefaultPopupStyles() PopupStyles {
+	return PopupStyles{
+		Border: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#7571F9")),
+		Title: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#7571F9")).
+			Bold(true),
+		Category: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFA500")).
+			Bold(true),
+		Key: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#7571F9")).
+			Bold(true),
+		Desc: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")),
+		Footer: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#A0A0A0")).
+			Italic(true),
+	}
 }
 
 // HelpKeyMap defines key bindings for the help popup.
@@ -71,23 +105,7 @@ func NewHelpPopup() HelpPopup {
 		visible:  false,
 		width:    60,
 		height:   24,
-		borderStyle: lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#7571F9")),
-		titleStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#7571F9")).
-			Bold(true),
-		categoryStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFA500")).
-			Bold(true),
-		keyStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#7571F9")).
-			Bold(true),
-		descStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")),
-		footerStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#A0A0A0")).
-			Italic(true),
+		styles:   DefaultPopupStyles(),
 	}
 }
 
@@ -127,110 +145,31 @@ func (h HelpPopup) View() string {
 		return ""
 	}
 
-	// Calculate popup dimensions
-	popupWidth := h.width * 70 / 100
-	if popupWidth < 45 {
-		popupWidth = 45
-	}
-	if popupWidth > 60 {
-		popupWidth = 60
-	}
-
-	popupHeight := h.height * 80 / 100
-	if popupHeight < 20 {
-		popupHeight = 20
-	}
-	if popupHeight > 35 {
-		popupHeight = 35
-	}
-
-	// Build the popup with title above border
-	title := h.titleStyle.Render("Help")
-	footer := h.footerStyle.Render("Press ? or Esc to close")
-
-	viewportContent := h.viewport.View()
-
-	// Footer centered
-	footerLine := lipgloss.NewStyle().Width(popupWidth - 4).Align(lipgloss.Center).Render(footer)
-
-	// Combine viewport and footer
-	innerContent := lipgloss.JoinVertical(lipgloss.Left,
-		viewportContent,
-		"",
-		footerLine,
-	)
-
-	// Apply border
-	box := h.borderStyle.
-		Padding(0, 1).
-		Width(popupWidth).
-		Render(innerContent)
-
-	// Title centered above box
-	titleLine := lipgloss.NewStyle().
-		Width(popupWidth).
-		Align(lipgloss.Center).
-		Render(title)
-
-	return lipgloss.JoinVertical(lipgloss.Center, titleLine, box)
+	return h.styles.renderPopup("Help", "Press ? or Esc to close", clampWidth(h.width, 70, 45, 60), h.viewport.View())
 }
 
-// buildHelpContent creates the help text content.
-func (h HelpPopup) buildHelpContent() string {
+// buildHelpContent creates the help text content from the bindings, so
+// it always shows the keys actually in use.
+func (h HelpPopup) buildHelpContent(sections []HelpSection) string {
 	var b strings.Builder
 
-	// Helper to add a keybinding line
-	addKey := func(key, desc string) {
-		keyPadded := lipgloss.NewStyle().Width(14).Render(h.keyStyle.Render(key))
-		b.WriteString(keyPadded)
-		b.WriteString(h.descStyle.Render(desc))
+	for _, section := range sections {
 		b.WriteString("\n")
-	}
-
-	// Helper to add a category header
-	addCategory := func(name string) {
-		b.WriteString("\n")
-		b.WriteString(h.categoryStyle.Render(name))
+		b.WriteString(h.styles.Category.Render(section.Title))
 		b.WriteString("\n")
 		b.WriteString(strings.Repeat("-", 35))
 		b.WriteString("\n")
-	}
-
-	// Global
-	addCategory("Global")
-	addKey("?", "Toggle this help")
-	addKey("q", "Quit application")
-	addKey("Tab", "Switch panel focus")
 
-	// Playback
-	addCategory("Playback")
-	addKey("Space", "Play/Pause")
-	addKey("n", "Next track")
-	addKey("N", "Previous track")
-	addKey("s", "Stop playback")
-	addKey("f", "Seek forward 5s")
-	addKey("b", "Seek backward 5s")
-	addKey("+/=", "Volume up")
-	addKey("-", "Volume down")
-
-	// Browser/Library
-	addCategory("Browser/Library")
-	addKey("j/k", "Navigate up/down")
-	addKey("g/G", "Go to top/bottom")
-	addKey("PgUp/Dn", "Page up/down")
-	addKey("Enter/l", "Open/select")
-	addKey("Backspace/h", "Go back/collapse")
-	addKey("a", "Add all from game/system")
-	addKey(".", "Toggle hidden files")
-
-	// Playlist
-	addCategory("Playlist")
-	addKey("j/k", "Navigate up/down")
-	addKey("g/G", "Go to top/bottom")
-	addKey("PgUp/Dn", "Page up/down")
-	addKey("Enter/l", "Play selected track")
-	addKey("d", "Remove track (stops if playing)")
-	addKey("D", "Clear playlist (stops playback)")
+		for _, binding := range section.Bindings {
+			if !binding.Enabled() || binding.Help().Key == "" {
+				continue
+			}
+			keyPadded := lipgloss.NewStyle().Width(18).Render(h.styles.Key.Render(binding.Help().Key))
+			b.WriteString(keyPadded)
+			b.WriteString(h.styles.Desc.Render(binding.Help().Desc))
+			b.WriteString("\n")
+		}
+	}
 
 	return b.String()
 }
@@ -261,10 +200,15 @@ func (h *HelpPopup) SetSize(width, height int) {
 	h.viewport.Height = contentHeight - 4
 }
 
-// Show makes the help popup visible.
-func (h *HelpPopup) Show() {
+// SetStyles sets the popup styles.
+func (h *HelpPopup) SetStyles(styles PopupStyles) {
+	h.styles = styles
+}
+
+// Show makes the help popup visible, listing the given bindings.
+func (h *HelpPopup) Show(sections []HelpSection) {
 	h.visible = true
-	h.viewport.SetContent(h.buildHelpContent())
+	h.viewport.SetContent(h.buildHelpContent(sections))
 	h.viewport.GotoTop()
 }
 
@@ -279,10 +223,10 @@ func (h HelpPopup) Visible() bool {
 }
 
 // Toggle toggles the visibility of the help popup.
-func (h *HelpPopup) Toggle() {
+func (h *HelpPopup) Toggle(sections []HelpSection) {
 	if h.visible {
 		h.Hide()
 	} else {
-		h.Show()
+		h.Show(sections)
 	}
 }
@@ -0,0 +1,246 @@
+// Package components provides UI components for vgmtui.
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HistoryEntry is a track that started playing and when.
+type HistoryEntry struct {
+	Track    Track     `json:"track"`
+	PlayedAt time.Time `json:"played_at"`
+}
+
+// HistoryPlayMsg is sent to play a track again from the history.
+type HistoryPlayMsg struct {
+	Track Track
+}
+
+// HistoryAddMsg is sent to add a track from the history to the playlist.
+type HistoryAddMsg struct {
+	Track Track
+}
+
+// HistoryPopupKeyMap defines key bindings for the history popup.
+type HistoryPopupKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
+	Play     key.Binding
+	Add      key.Binding
+	Close    key.Binding
+}
+
+// DefaultHistoryPopupKeyMap returns the default history popup key bindings.
+func DefaultHistoryPopupKeyMap() HistoryPopupKeyMap {
+	return HistoryPopupKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "down"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup", "ctrl+u"),
+			key.WithHelp("pgup", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+d"),
+			key.WithHelp("pgdn", "page down"),
+		),
+		Play: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "play again"),
+		),
+		Add: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "add to playlist"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("H", "esc", "q"),
+			key.WithHelp("H/esc", "close"),
+		),
+	}
+}
+
+// HistoryPopup is an overlay listing recently played tracks, newest
+// first, from which a track can be played again or re-added.
+type HistoryPopup struct {
+	entries []HistoryEntry
+	cursor  int
+	offset  int // First visible entry
+	visible bool
+	width   int
+	height  int
+
+	keyMap HistoryPopupKeyMap
+
+	// Styles
+	styles PopupStyles
+}
+
+// NewHistoryPopup creates a new history popup.
+func NewHistoryPopup() HistoryPopup {
+	return HistoryPopup{
+		width:  60,
+		height: 24,
+		keyMap: DefaultHistoryPopupKeyMap(),
+		styles: DefaultPopupStyles(),
+	}
+}
+
+// Update handles messages for the history popup.
+func (h HistoryPopup) Update(msg tea.Msg) (HistoryPopup, tea.Cmd) {
+	if !h.visible {
+		return h, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return h, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, h.keyMap.Close):
+		h.visible = false
+	case key.Matches(keyMsg, h.keyMap.Up):
+		h.moveCursor(-1)
+	case key.Matches(keyMsg, h.keyMap.Down):
+		h.moveCursor(1)
+	case key.Matches(keyMsg, h.keyMap.PageUp):
+		h.moveCursor(-h.listHeight())
+	case key.Matches(keyMsg, h.keyMap.PageDown):
+		h.moveCursor(h.listHeight())
+	case key.Matches(keyMsg, h.keyMap.Play):
+		if len(h.entries) > 0 {
+			track := h.entries[h.cursor].Track
+			h.visible = false
+			return h, func() tea.Msg { return HistoryPlayMsg{Track: track} }
+		}
+	case key.Matches(keyMsg, h.keyMap.Add):
+		if len(h.entries) > 0 {
+			track := h.entries[h.cursor].Track
+			return h, func() tea.Msg { return HistoryAddMsg{Track: track} }
+		}
+	}
+	return h, nil
+}
+
+// moveCursor moves the cursor by delta entries, scrolling to keep it visible.
+func (h *HistoryPopup) moveCursor(delta int) {
+	h.cursor = max(0, min(h.cursor+delta, len(h.entries)-1))
+	rows := h.listHeight()
+	if h.cursor < h.offset {
+		h.offset = h.cursor
+	}
+	if h.cursor >= h.offset+rows {
+		h.offset = h.cursor - rows + 1
+	}
+}
+
+// View renders the history popup as an overlay.
+func (h HistoryPopup) View() string {
+	if !h.visible {
+		return ""
+	}
+
+	popupWidth := h.popupWidth()
+	return h.styles.renderPopup("Recently Played", "Enter: play  a: add  H/Esc: close", popupWidth,
+		h.buildContent(popupWidth-4))
+}
+
+// popupWidth returns the width of the popup box for the current size.
+func (h HistoryPopup) popupWidth() int {
+	return clampWidth(h.width, 80, 45, 90)
+}
+
+// listHeight returns the number of entries shown at once.
+func (h HistoryPopup) listHeight() int {
+	rows := h.height*80/100 - 6
+	if rows < 5 {
+		rows = 5
+	}
+	return rows
+}
+
+// buildContent renders the visible entries, one per line, as
+// "when  title - game" trimmed to width.
+func (h HistoryPopup) buildContent(width int) string {
+	if len(h.entries) == 0 {
+		return h.styles.Desc.Render("Nothing played yet.")
+	}
+
+	var b strings.Builder
+	end := min(h.offset+h.listHeight(), len(h.entries))
+	now := time.Now()
+	for i := h.offset; i < end; i++ {
+		e := h.entries[i]
+		when := fmt.Sprintf("%-12s", formatPlayedAt(e.PlayedAt, now))
+		label := e.Track.Title
+		if e.Track.Game != "" {
+			label += " - " + e.Track.Game
+		}
+		label = truncateEnd(label, width-len(when)-2)
+
+		if i == h.cursor {
+			b.WriteString(h.styles.Key.Render("> " + when + label))
+		} else {
+			b.WriteString(h.styles.Footer.Render("  " + when))
+			b.WriteString(h.styles.Desc.Render(label))
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatPlayedAt describes when a track was played relative to now:
+// a time today, "yesterday 15:04" or a date.
+func formatPlayedAt(t, now time.Time) string {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := now.Date()
+	switch {
+	case y1 == y2 && m1 == m2 && d1 == d2:
+		return t.Format("15:04")
+	case now.AddDate(0, 0, -1).Format("2006-01-02") == t.Format("2006-01-02"):
+		return "yest. " + t.Format("15:04")
+	default:
+		return t.Format("Jan 02")
+	}
+}
+
+// SetSize sets the available size for the history popup.
+func (h *HistoryPopup) SetSize(width, height int) {
+	h.width = width
+	h.height = height
+}
+
+// SetStyles sets the popup styles.
+func (h *HistoryPopup) SetStyles(styles PopupStyles) {
+	h.styles = styles
+}
+
+// Show makes the history popup visible with the given entries, newest first.
+func (h *HistoryPopup) Show(entries []HistoryEntry) {
+	h.entries = entries
+	h.cursor = 0
+	h.offset = 0
+	h.visible = true
+}
+
+// Hide makes the history popup invisible.
+func (h *HistoryPopup) Hide() {
+	h.visible = false
+}
+
+// Visible returns whether the history popup is visible.
+func (h HistoryPopup) Visible() bool {
+	return h.visible
+}
@@ -0,0 +1,244 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyBindingRow is an action listed in the key bindings popup.
+type KeyBindingRow struct {
+	Action string // Name of the action in the config file
+	Desc   string
+	Keys   string // Keys currently bound, for display
+}
+
+// RebindMsg is sent to bind an action to a new key. An empty Key resets
+// the action to its default keys.
+type RebindMsg struct {
+	Action string
+	Key    string
+}
+
+// KeyBindingsPopupKeyMap defines key bindings for the key bindings popup.
+type KeyBindingsPopupKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
+	Rebind   key.Binding
+	Reset    key.Binding
+	Close    key.Binding
+}
+
+// DefaultKeyBindingsPopupKeyMap returns the default key bindings popup
+// key bindings.
+func DefaultKeyBindingsPopupKeyMap() KeyBindingsPopupKeyMap {
+	return KeyBindingsPopupKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "down"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup", "ctrl+u"),
+			key.WithHelp("pgup", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+d"),
+			key.WithHelp("pgdn", "page down"),
+		),
+		Rebind: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "rebind"),
+		),
+		Reset: key.NewBinding(
+			key.WithKeys("backspace", "delete"),
+			key.WithHelp("backspace", "reset to default"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "close"),
+		),
+	}
+}
+
+// KeyBindingsPopup is an overlay listing every rebindable action with its
+// keys. Choosing an action waits for the next key press and sends a
+// RebindMsg for it.
+type KeyBindingsPopup struct {
+	rows      []KeyBindingRow
+	cursor    int
+	offset    int  // First visible row
+	capturing bool // Waiting for the new key of the row under the cursor
+	visible   bool
+	width     int
+	height    int
+
+	keyMap KeyBindingsPopupKeyMap
+
+	// Styles
+	styles PopupStyles
+}
+
+// NewKeyBindingsPopup creates a new key bindings popup.
+func NewKeyBindingsPopup() KeyBindingsPopup {
+	return KeyBindingsPopup{
+		width:  60,
+		height: 24,
+		keyMap: DefaultKeyBindingsPopupKeyMap(),
+		styles: DefaultPopupStyles(),
+	}
+}
+
+// Update handles messages for the key bindings popup.
+func (k KeyBindingsPopup) Update(msg tea.Msg) (KeyBindingsPopup, tea.Cmd) {
+	if !k.visible {
+		return k, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return k, nil
+	}
+
+	if k.capturing {
+		k.capturing = false
+		if keyMsg.Type == tea.KeyEsc || len(k.rows) == 0 {
+			return k, nil
+		}
+		rebind := RebindMsg{Action: k.rows[k.cursor].Action, Key: keyMsg.String()}
+		return k, func() tea.Msg { return rebind }
+	}
+
+	switch {
+	case key.Matches(keyMsg, k.keyMap.Close):
+		k.visible = false
+	case key.Matches(keyMsg, k.keyMap.Up):
+		k.moveCursor(-1)
+	case key.Matches(keyMsg, k.keyMap.Down):
+		k.moveCursor(1)
+	case key.Matches(keyMsg, k.keyMap.PageUp):
+		k.moveCursor(-k.listHeight())
+	case key.Matches(keyMsg, k.keyMap.PageDown):
+		k.moveCursor(k.listHeight())
+	case key.Matches(keyMsg, k.keyMap.Rebind):
+		k.capturing = len(k.rows) > 0
+	case key.Matches(keyMsg, k.keyMap.Reset):
+		if len(k.rows) > 0 {
+			reset := RebindMsg{Action: k.rows[k.cursor].Action}
+			return k, func() tea.Msg { return reset }
+		}
+	}
+	return k, nil
+}
+
+// moveCursor moves the cursor by delta rows, scrolling to keep it visible.
+func (k *KeyBindingsPopup) moveCursor(delta int) {
+	k.cursor = max(0, min(k.cursor+delta, len(k.rows)-1))
+	rows := k.listHeight()
+	if k.cursor < k.offset {
+		k.offset = k.cursor
+	}
+	if k.cursor >= k.offset+rows {
+		k.offset = k.cursor - rows + 1
+	}
+}
+
+// View renders the key bindings popup as an overlay.
+func (k KeyBindingsPopup) View() string {
+	if !k.visible {
+		return ""
+	}
+
+	popupWidth := k.popupWidth()
+	footerText := "Enter: rebind  Backspace: default  Esc: close"
+	if k.capturing && len(k.rows) > 0 {
+		footerText = fmt.Sprintf("Press the new key for %s (Esc cancels)", k.rows[k.cursor].Desc)
+	}
+	return k.styles.renderPopup("Key Bindings", footerText, popupWidth, k.buildContent(popupWidth-4))
+}
+
+// popupWidth returns the width of the popup box for the current size.
+func (k KeyBindingsPopup) popupWidth() int {
+	return clampWidth(k.width, 80, 60, 76)
+}
+
+// listHeight returns the number of rows shown at once.
+func (k KeyBindingsPopup) listHeight() int {
+	rows := k.height*80/100 - 6
+	if rows < 5 {
+		rows = 5
+	}
+	return rows
+}
+
+// buildContent renders the visible rows, one per line, as
+// "description  action name  keys".
+func (k KeyBindingsPopup) buildContent(width int) string {
+	if len(k.rows) == 0 {
+		return k.styles.Desc.Render("  No key bindings")
+	}
+
+	var b strings.Builder
+	end := min(k.offset+k.listHeight(), len(k.rows))
+	for i := k.offset; i < end; i++ {
+		row := k.rows[i]
+		descWidth := width - 2 - 24 - 18
+		keys := row.Keys
+		if i == k.cursor && k.capturing {
+			keys = "..."
+		}
+		line := fmt.Sprintf("%-*s%-24s%18s",
+			descWidth, truncateEnd(row.Desc, descWidth-1), truncateEnd(row.Action, 23), truncateEnd(keys, 18))
+
+		if i == k.cursor {
+			b.WriteString(k.styles.Key.Render("> " + line))
+		} else {
+			b.WriteString(k.styles.Desc.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// SetRows replaces the listed actions, keeping the cursor position.
+func (k *KeyBindingsPopup) SetRows(rows []KeyBindingRow) {
+	k.rows = rows
+	k.cursor = max(0, min(k.cursor, len(rows)-1))
+}
+
+// SetSize sets the available size for the key bindings popup.
+func (k *KeyBindingsPopup) SetSize(width, height int) {
+	k.width = width
+	k.height = height
+}
+
+// SetStyles sets the popup styles.
+func (k *KeyBindingsPopup) SetStyles(styles PopupStyles) {
+	k.styles = styles
+}
+
+// Show makes the key bindings popup visible with the given actions.
+func (k *KeyBindingsPopup) Show(rows []KeyBindingRow) {
+	k.rows = rows
+	k.cursor = 0
+	k.offset = 0
+	k.capturing = false
+	k.visible = true
+}
+
+// Hide makes the key bindings popup invisible.
+func (k *KeyBindingsPopup) Hide() {
+	k.visible = false
+}
+
+// Visible returns whether the key bindings popup is visible.
+func (k KeyBindingsPopup) Visible() bool {
+	return k.visible
+}
@@ -3,6 +3,8 @@ package components
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -14,16 +16,25 @@ import (
 
 // LibBrowserKeyMap defines key bindings for the library browser.
 type LibBrowserKeyMap struct {
-	Up         key.Binding
-	Down       key.Binding
-	PageUp     key.Binding
-	PageDown   key.Binding
-	GoToTop    key.Binding
-	GoToBottom key.Binding
-	Enter      key.Binding // Expand/collapse or select and play
-	Add        key.Binding // Add track to playlist without playing
-	Back       key.Binding // Collapse or go to parent
-	AddAll     key.Binding // Add entire game/system to playlist
+	Up          key.Binding
+	Down        key.Binding
+	PageUp      key.Binding
+	PageDown    key.Binding
+	GoToTop     key.Binding
+	GoToBottom  key.Binding
+	Enter       key.Binding // Expand/collapse or select and play
+	Add         key.Binding // Add track to playlist without playing
+	Back        key.Binding // Collapse or go to parent
+	AddAll      key.Binding // Add entire game/system to playlist
+	AddVisible  key.Binding // Add every visible track to playlist
+	AddNext     key.Binding // Insert tracks after the playing track
+	Filter      key.Binding // Start typing a filter
+	ClearFilter key.Binding // Clear the filter
+	Favorite    key.Binding // Star or unstar the selected track
+	Grouping    key.Binding // Switch between system and composer grouping
+	CycleSort   key.Binding // Cycle the order of tracks within games
+	ChipFilter  key.Binding // Choose a sound chip to filter by
+	Duplicates  key.Binding // Report duplicate tracks
 }
 
 // DefaultLibBrowserKeyMap returns the default library browser key bindings.
@@ -69,6 +80,42 @@ func DefaultLibBrowserKeyMap() LibBrowserKeyMap {
 			key.WithKeys("a"),
 			key.WithHelp("a", "add all"),
 		),
+		AddVisible: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "add visible"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ClearFilter: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear filter"),
+		),
+		Favorite: key.NewBinding(
+			key.WithKeys("*"),
+			key.WithHelp("*", "favorite"),
+		),
+		Grouping: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "group by"),
+		),
+		CycleSort: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "track order"),
+		),
+		ChipFilter: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "filter by chip"),
+		),
+		Duplicates: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "duplicates"),
+		),
+		AddNext: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "play next"),
+		),
 	}
 }
 
@@ -79,8 +126,36 @@ const (
 	NodeSystem NodeType = iota
 	NodeGame
 	NodeTrack
+	NodeFavorites  // Virtual node holding every favorite track
+	NodeCollection // Virtual node holding the most played or recently added tracks
+	NodeSmart      // Smart playlist, matched against the library when used
+	NodeComposer   // Composer group (composer grouping only)
 )
 
+// LibGrouping selects how the library tree is grouped at the top level.
+type LibGrouping int
+
+const (
+	GroupBySystem   LibGrouping = iota // System > Game > Track
+	GroupByComposer                    // Composer > Game > Track
+)
+
+// String returns a short name for the grouping.
+func (g LibGrouping) String() string {
+	if g == GroupByComposer {
+		return "composer"
+	}
+	return "system"
+}
+
+// Next returns the other grouping.
+func (g LibGrouping) Next() LibGrouping {
+	if g == GroupByComposer {
+		return GroupBySystem
+	}
+	return GroupByComposer
+}
+
 // TreeNode represents a node in the library tree.
 type TreeNode struct {
 	Type     NodeType
@@ -89,6 +164,7 @@ type TreeNode struct {
 	Game     string // For tracks
 	Path     string // For tracks
 	Track    *library.Track
+	Smart    *library.SmartPlaylist // For smart playlists
 	Children []*TreeNode
 	Expanded bool
 	Parent   *TreeNode
@@ -97,8 +173,13 @@ type TreeNode struct {
 // LibBrowser is a tree-based library browser component.
 type LibBrowser struct {
 	// Library data
-	lib  *library.Library
-	root []*TreeNode // Root nodes (systems)
+	lib    *library.Library
+	root   []*TreeNode // Root nodes (Favorites, collections, smart playlists, groups)
+	groups []*TreeNode // Top-level groups (systems or composers)
+
+	grouping LibGrouping // How tracks are grouped at the top level
+
+	smartNodes []*TreeNode // One per smart playlist
 
 	// Flat list for navigation
 	flatList []*TreeNode
@@ -113,9 +194,24 @@ type LibBrowser struct {
 	height int
 
 	// State
-	focused bool
-	keyMap  LibBrowserKeyMap
-	styles  LibBrowserStyles
+	focused   bool
+	keyMap    LibBrowserKeyMap
+	styles    LibBrowserStyles
+	gameLabel library.GameLabel // Source of displayed game names
+
+	// Filter state
+	filter    string // Case-insensitive track filter ("" shows everything)
+	filtering bool   // True while the filter is being typed
+	chip      string // Only show tracks using this chip ("" shows every chip)
+
+	// Favorite tracks, also gathered under a Favorites node at the top
+	favorites     Favorites
+	favoritesNode *TreeNode // nil when there are no favorites
+
+	// Most Played and Recently Added, gathered from the whole library
+	plays          PlayStats
+	mostPlayedNode *TreeNode // nil when nothing has been played to the end
+	recentNode     *TreeNode // nil when the library is empty
 
 	// Status
 	scanning   bool
@@ -165,6 +261,7 @@ func DefaultLibBrowserStyles() LibBrowserStyles {
 type LibBrowserScanCompleteMsg struct {
 	TrackCount int
 	Err        error
+	Refresh    bool // From Refresh: keep the expanded nodes and selection
 }
 
 // LibTrackSelectedMsg is sent when a track is selected.
@@ -173,8 +270,18 @@ type LibTrackSelectedMsg struct {
 }
 
 // LibTracksSelectedMsg is sent when multiple tracks are selected (add all).
+// Next is set if they should play after the current track rather than be
+// appended to the playlist.
 type LibTracksSelectedMsg struct {
 	Tracks []library.Track
+	Next   bool
+}
+
+// LibSmartPlaylistMsg is sent when a smart playlist is opened: the
+// playlist should be replaced with its current matches.
+type LibSmartPlaylistMsg struct {
+	Name   string
+	Tracks []library.Track
 }
 
 // LibTrackPlayMsg is sent when a track should be added and played immediately.
@@ -214,9 +321,136 @@ func (b *LibBrowser) Scan() tea.Cmd {
 	}
 }
 
-// buildTree builds the tree structure from the library.
+// Refresh returns a command that rescans the library in the background.
+// Unlike Scan, the current tree stays on screen while scanning, and when
+// it is rebuilt the same nodes stay expanded and selected.
+func (b *LibBrowser) Refresh() tea.Cmd {
+	lib := b.lib
+	return func() tea.Msg {
+		count, err := lib.Scan()
+		return LibBrowserScanCompleteMsg{TrackCount: count, Err: err, Refresh: true}
+	}
+}
+
+// Scanning returns true while a full scan started by Scan is running.
+func (b *LibBrowser) Scanning() bool {
+	return b.scanning
+}
+
+// rebuildTree rebuilds the tree from the library, re-expanding the nodes
+// that were expanded and keeping the cursor on the same node if it still
+// exists.
+func (b *LibBrowser) rebuildTree() {
+	expanded := make(map[string]bool)
+	walkNodes(b.root, func(node *TreeNode) {
+		if node.Expanded {
+			expanded[nodeKey(node)] = true
+		}
+	})
+	selectedKey := ""
+	if node := b.SelectedNode(); node != nil {
+		selectedKey = nodeKey(node)
+	}
+
+	b.buildTree()
+	walkNodes(b.root, func(node *TreeNode) {
+		if expanded[nodeKey(node)] {
+			node.Expanded = true
+		}
+	})
+	b.rebuildFlatList()
+
+	for i, node := range b.flatList {
+		if nodeKey(node) == selectedKey {
+			b.selected = i
+			b.updateViewport()
+			break
+		}
+	}
+}
+
+// walkNodes calls fn for every node in the trees below nodes, parents first.
+func walkNodes(nodes []*TreeNode, fn func(*TreeNode)) {
+	for _, node := range nodes {
+		fn(node)
+		walkNodes(node.Children, fn)
+	}
+}
+
+// nodeKey identifies a node across tree rebuilds by the type, name and
+// path of it and its ancestors.
+func nodeKey(node *TreeNode) string {
+	var b strings.Builder
+	for n := node; n != nil; n = n.Parent {
+		fmt.Fprintf(&b, "%d\x00%s\x00%s\x00", n.Type, n.Name, n.Path)
+	}
+	return b.String()
+}
+
+// buildTree builds the tree structure from the library in the current
+// grouping.
 func (b *LibBrowser) buildTree() {
-	b.root = make([]*TreeNode, 0)
+	if b.grouping == GroupByComposer {
+		b.buildComposerTree()
+	} else {
+		b.buildSystemTree()
+	}
+
+	b.sortGames()
+	b.favoritesNode = nil
+	b.mostPlayedNode = nil
+	b.recentNode = nil
+	b.buildCollections()
+	b.buildFavorites()
+}
+
+// buildComposerTree groups the library as Composer > Game > Track. A game
+// released on several systems gets one node per system.
+func (b *LibBrowser) buildComposerTree() {
+	b.groups = make([]*TreeNode, 0)
+
+	for _, composer := range b.lib.Composers() {
+		compNode := &TreeNode{
+			Type:     NodeComposer,
+			Name:     composer,
+			Children: make([]*TreeNode, 0),
+		}
+
+		games := make(map[string]*TreeNode)
+		tracks := b.lib.TracksByComposer(composer)
+		for i := range tracks {
+			t := &tracks[i]
+			id := t.System + "\x00" + t.Game
+			gameNode, ok := games[id]
+			if !ok {
+				gameNode = &TreeNode{
+					Type:     NodeGame,
+					Name:     t.Game,
+					System:   t.System,
+					Children: make([]*TreeNode, 0),
+					Parent:   compNode,
+				}
+				games[id] = gameNode
+				compNode.Children = append(compNode.Children, gameNode)
+			}
+			gameNode.Children = append(gameNode.Children, &TreeNode{
+				Type:   NodeTrack,
+				Name:   t.Title,
+				System: t.System,
+				Game:   t.Game,
+				Path:   t.Path,
+				Track:  t,
+				Parent: gameNode,
+			})
+		}
+
+		b.groups = append(b.groups, compNode)
+	}
+}
+
+// buildSystemTree groups the library as System > Game > Track.
+func (b *LibBrowser) buildSystemTree() {
+	b.groups = make([]*TreeNode, 0)
 
 	systems := b.lib.Systems()
 	for _, sysName := range systems {
@@ -255,10 +489,201 @@ func (b *LibBrowser) buildTree() {
 			sysNode.Children = append(sysNode.Children, gameNode)
 		}
 
-		b.root = append(b.root, sysNode)
+		b.groups = append(b.groups, sysNode)
 	}
+}
 
+// SetGrouping regroups the library tree and moves to the top.
+func (b *LibBrowser) SetGrouping(g LibGrouping) {
+	b.grouping = g
+	b.selected = 0
+	b.min = 0
+	if !b.scanning {
+		b.buildTree()
+	}
+}
+
+// SetTrackSort changes the order of tracks within games, re-sorting the
+// library and the tree in place so expanded nodes and the selection stay.
+func (b *LibBrowser) SetTrackSort(order library.TrackSort) {
+	b.lib.SetTrackSort(order)
+
+	selected := b.SelectedNode()
+	for _, group := range b.groups {
+		for _, game := range group.Children {
+			nodes := game.Children
+			sort.SliceStable(nodes, func(i, j int) bool {
+				return library.NaturalLess(nodes[i].Path, nodes[j].Path)
+			})
+			if order != library.SortByPath {
+				sort.SliceStable(nodes, func(i, j int) bool {
+					return order.Less(*nodes[i].Track, *nodes[j].Track)
+				})
+			}
+		}
+	}
 	b.rebuildFlatList()
+	for i, node := range b.flatList {
+		if node == selected {
+			b.selected = i
+			b.updateViewport()
+			break
+		}
+	}
+}
+
+// Grouping returns how the library tree is grouped.
+func (b LibBrowser) Grouping() LibGrouping {
+	return b.grouping
+}
+
+// assembleRoot sets the root nodes: Favorites, Most Played and Recently
+// Added, then the smart playlists, then the systems.
+func (b *LibBrowser) assembleRoot() {
+	b.root = make([]*TreeNode, 0, len(b.smartNodes)+len(b.groups)+3)
+	for _, node := range []*TreeNode{b.favoritesNode, b.mostPlayedNode, b.recentNode} {
+		if node != nil {
+			b.root = append(b.root, node)
+		}
+	}
+	b.root = append(b.root, b.smartNodes...)
+	b.root = append(b.root, b.groups...)
+}
+
+// libraryTracks returns the track nodes of the tree in library order.
+func (b *LibBrowser) libraryTracks() []*TreeNode {
+	var tracks []*TreeNode
+	for _, group := range b.groups {
+		for _, game := range group.Children {
+			tracks = append(tracks, game.Children...)
+		}
+	}
+	return tracks
+}
+
+// virtualNode returns a node of the given type holding copies of the
+// track nodes, expanded if the node it replaces was, or nil if there are
+// no tracks.
+func virtualNode(typ NodeType, name string, tracks []*TreeNode, old *TreeNode) *TreeNode {
+	if len(tracks) == 0 {
+		return nil
+	}
+	node := &TreeNode{Type: typ, Name: name, Expanded: old != nil && old.Expanded}
+	for _, t := range tracks {
+		node.Children = append(node.Children, &TreeNode{
+			Type:   NodeTrack,
+			Name:   t.Name,
+			System: t.System,
+			Game:   t.Game,
+			Path:   t.Path,
+			Track:  t.Track,
+			Parent: node,
+		})
+	}
+	return node
+}
+
+// replaceVirtual runs replace, which swaps virtual nodes for new ones,
+// and rebuilds the visible list, keeping the cursor on the same node (or
+// track, inside a replaced node).
+func (b *LibBrowser) replaceVirtual(replace func()) {
+	selected := b.SelectedNode()
+	selectedKey := ""
+	if selected != nil {
+		selectedKey = nodeKey(selected)
+	}
+
+	replace()
+	b.assembleRoot()
+	b.rebuildFlatList()
+
+	for i, n := range b.flatList {
+		if n == selected || nodeKey(n) == selectedKey {
+			b.selected = i
+			b.updateViewport()
+			break
+		}
+	}
+}
+
+// buildFavorites replaces the Favorites node with one holding the current
+// favorite tracks in library order and rebuilds the visible list.
+func (b *LibBrowser) buildFavorites() {
+	b.replaceVirtual(func() {
+		var tracks []*TreeNode
+		if len(b.favorites) > 0 {
+			for _, t := range b.libraryTracks() {
+				if b.favorites.Has(t.Path) {
+					tracks = append(tracks, t)
+				}
+			}
+		}
+		b.favoritesNode = virtualNode(NodeFavorites, "Favorites", tracks, b.favoritesNode)
+	})
+}
+
+// SetSmartPlaylists sets the smart playlists listed below Favorites.
+func (b *LibBrowser) SetSmartPlaylists(playlists []library.SmartPlaylist) {
+	b.smartNodes = make([]*TreeNode, len(playlists))
+	for i := range playlists {
+		b.smartNodes[i] = &TreeNode{
+			Type:  NodeSmart,
+			Name:  playlists[i].Name,
+			Smart: &playlists[i],
+		}
+	}
+	b.assembleRoot()
+	b.rebuildFlatList()
+}
+
+// SetPlayStats sets the play counts the Most Played node is ordered by and
+// rebuilds it. Call it again after the counts change.
+func (b *LibBrowser) SetPlayStats(plays PlayStats) {
+	b.plays = plays
+	b.buildCollections()
+}
+
+// SetFavorites sets the favorite tracks to mark and gather under the
+// Favorites node.
+func (b *LibBrowser) SetFavorites(favorites Favorites) {
+	b.favorites = favorites
+	b.buildFavorites()
+}
+
+// gameName returns the displayed name of a game node.
+func (b *LibBrowser) gameName(node *TreeNode) string {
+	if b.gameLabel == library.GameLabelDir && len(node.Children) > 0 {
+		if t := node.Children[0].Track; t != nil {
+			return t.GameName(b.gameLabel)
+		}
+	}
+	return node.Name
+}
+
+// sortGames orders the games of every system by their displayed name.
+func (b *LibBrowser) sortGames() {
+	for _, sys := range b.groups {
+		sort.SliceStable(sys.Children, func(i, j int) bool {
+			return b.gameName(sys.Children[i]) < b.gameName(sys.Children[j])
+		})
+	}
+}
+
+// SetGameLabel sets where displayed game names come from and re-sorts
+// the games, keeping the selection on the same node.
+func (b *LibBrowser) SetGameLabel(label library.GameLabel) {
+	b.gameLabel = label
+
+	selected := b.SelectedNode()
+	b.sortGames()
+	b.rebuildFlatList()
+	for i, node := range b.flatList {
+		if node == selected {
+			b.selected = i
+			b.updateViewport()
+			break
+		}
+	}
 }
 
 // rebuildFlatList rebuilds the flat list from the tree.
@@ -280,7 +705,20 @@ func (b *LibBrowser) rebuildFlatList() {
 }
 
 // addToFlatList adds a node and its visible children to the flat list.
+// While a filter or chip filter is set, only matching tracks and their
+// ancestors are visible, and every ancestor is shown expanded.
 func (b *LibBrowser) addToFlatList(node *TreeNode, depth int) {
+	if b.filter != "" || b.chip != "" {
+		if !b.nodeMatches(node) {
+			return
+		}
+		b.flatList = append(b.flatList, node)
+		for _, child := range node.Children {
+			b.addToFlatList(child, depth+1)
+		}
+		return
+	}
+
 	b.flatList = append(b.flatList, node)
 	if node.Expanded {
 		for _, child := range node.Children {
@@ -289,10 +727,82 @@ func (b *LibBrowser) addToFlatList(node *TreeNode, depth int) {
 	}
 }
 
+// nodeMatches reports whether a track node matches the filter and chip
+// filter, or any track below a system or game node does.
+func (b *LibBrowser) nodeMatches(node *TreeNode) bool {
+	if node.Type == NodeTrack {
+		return node.Track != nil && trackMatches(*node.Track, b.filter) &&
+			(b.chip == "" || slices.Contains(node.Track.Chips, b.chip))
+	}
+	for _, child := range node.Children {
+		if b.nodeMatches(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// trackMatches reports whether any of a track's names, including its sound
+// chips, contain the filter, ignoring case.
+func trackMatches(t library.Track, filter string) bool {
+	filter = strings.ToLower(filter)
+	fields := append([]string{t.Title, t.Game, t.DirGame, t.System, t.Composer}, t.Chips...)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// setFilter changes the filter and rebuilds the visible list from the top.
+func (b *LibBrowser) setFilter(filter string) {
+	b.filter = filter
+	b.selected = 0
+	b.min = 0
+	b.rebuildFlatList()
+}
+
+// SetChipFilter shows only tracks using the given chip ("" shows all)
+// and rebuilds the visible list from the top.
+func (b *LibBrowser) SetChipFilter(chip string) {
+	b.chip = chip
+	b.selected = 0
+	b.min = 0
+	b.rebuildFlatList()
+}
+
+// ChipFilter returns the chip tracks are filtered by ("" if none).
+func (b *LibBrowser) ChipFilter() string {
+	return b.chip
+}
+
+// VisibleTracks returns the tracks currently shown in the tree, in display
+// order. With a filter set, these are exactly the matching tracks.
+// Favorites shown both under Favorites and their game are returned once.
+func (b *LibBrowser) VisibleTracks() []library.Track {
+	var tracks []library.Track
+	seen := make(map[string]bool)
+	for _, node := range b.flatList {
+		if node.Type == NodeTrack && node.Track != nil && !seen[node.Path] {
+			seen[node.Path] = true
+			tracks = append(tracks, *node.Track)
+		}
+	}
+	return tracks
+}
+
 // Update handles messages and updates the browser state.
 func (b LibBrowser) Update(msg tea.Msg) (LibBrowser, tea.Cmd) {
 	switch msg := msg.(type) {
 	case LibBrowserScanCompleteMsg:
+		if msg.Refresh {
+			if msg.Err == nil && !b.scanning {
+				b.trackCount = msg.TrackCount
+				b.rebuildTree()
+			}
+			return b, nil
+		}
 		b.scanning = false
 		if msg.Err == nil {
 			b.trackCount = msg.TrackCount
@@ -310,9 +820,70 @@ func (b LibBrowser) Update(msg tea.Msg) (LibBrowser, tea.Cmd) {
 	return b, nil
 }
 
+// handleFilterKey handles keyboard input while the filter is being typed.
+// The tree narrows as the user types; enter keeps the filter, esc clears it.
+func (b LibBrowser) handleFilterKey(msg tea.KeyMsg) (LibBrowser, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		b.filtering = false
+	case tea.KeyEsc:
+		b.filtering = false
+		b.setFilter("")
+	case tea.KeyBackspace:
+		if r := []rune(b.filter); len(r) > 0 {
+			b.setFilter(string(r[:len(r)-1]))
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		b.setFilter(b.filter + string(msg.Runes))
+	}
+	return b, nil
+}
+
 // handleKeyMsg handles keyboard input when focused.
 func (b LibBrowser) handleKeyMsg(msg tea.KeyMsg) (LibBrowser, tea.Cmd) {
+	if b.filtering {
+		return b.handleFilterKey(msg)
+	}
+
 	switch {
+	case key.Matches(msg, b.keyMap.Filter):
+		b.filtering = true
+		return b, nil
+
+	case key.Matches(msg, b.keyMap.ClearFilter):
+		// Clear the text filter first, then the chip filter
+		if b.filter != "" {
+			b.setFilter("")
+		} else if b.chip != "" {
+			b.SetChipFilter("")
+		}
+		return b, nil
+
+	case key.Matches(msg, b.keyMap.Duplicates):
+		if b.scanning {
+			return b, nil
+		}
+		return b, func() tea.Msg {
+			return DuplicatesFindMsg{Mode: library.DuplicatesByMetadata}
+		}
+
+	case key.Matches(msg, b.keyMap.ChipFilter):
+		if b.scanning {
+			return b, nil
+		}
+		chips, current := b.lib.Stats().Chips, b.chip
+		return b, func() tea.Msg {
+			return ChipFilterOpenMsg{Chips: chips, Current: current}
+		}
+
+	case key.Matches(msg, b.keyMap.AddVisible):
+		if tracks := b.VisibleTracks(); len(tracks) > 0 {
+			return b, func() tea.Msg {
+				return LibTracksSelectedMsg{Tracks: tracks}
+			}
+		}
+		return b, nil
+
 	case key.Matches(msg, b.keyMap.Up):
 		b.moveUp()
 		return b, nil
@@ -347,7 +918,25 @@ func (b LibBrowser) handleKeyMsg(msg tea.KeyMsg) (LibBrowser, tea.Cmd) {
 		return b.handleBack()
 
 	case key.Matches(msg, b.keyMap.AddAll):
-		return b.handleAddAll()
+		return b.handleAddAll(false)
+
+	case key.Matches(msg, b.keyMap.AddNext):
+		return b.handleAddAll(true)
+
+	case key.Matches(msg, b.keyMap.Grouping):
+		b.SetGrouping(b.grouping.Next())
+		return b, nil
+
+	case key.Matches(msg, b.keyMap.CycleSort):
+		b.SetTrackSort(b.lib.TrackSort().Next())
+		return b, nil
+
+	case key.Matches(msg, b.keyMap.Favorite):
+		if node := b.SelectedNode(); node != nil && node.Type == NodeTrack {
+			path := node.Path
+			return b, func() tea.Msg { return FavoriteToggleMsg{Path: path} }
+		}
+		return b, nil
 	}
 
 	return b, nil
@@ -362,7 +951,7 @@ func (b LibBrowser) handleEnter() (LibBrowser, tea.Cmd) {
 	node := b.flatList[b.selected]
 
 	switch node.Type {
-	case NodeSystem:
+	case NodeSystem, NodeComposer, NodeFavorites, NodeCollection:
 		// Accordion: collapse all other systems, toggle this one
 		expanding := !node.Expanded
 		for _, sys := range b.root {
@@ -388,6 +977,13 @@ func (b LibBrowser) handleEnter() (LibBrowser, tea.Cmd) {
 		b.rebuildFlatList()
 		return b, nil
 
+	case NodeSmart:
+		// Replace the playlist with the current matches
+		name, tracks := node.Name, b.lib.Query(node.Smart.Query)
+		return b, func() tea.Msg {
+			return LibSmartPlaylistMsg{Name: name, Tracks: tracks}
+		}
+
 	case NodeTrack:
 		// Add and play the track
 		if node.Track != nil {
@@ -409,7 +1005,7 @@ func (b LibBrowser) handleAdd() (LibBrowser, tea.Cmd) {
 	node := b.flatList[b.selected]
 
 	switch node.Type {
-	case NodeSystem:
+	case NodeSystem, NodeComposer, NodeFavorites, NodeCollection:
 		// Accordion: collapse all other systems, toggle this one
 		expanding := !node.Expanded
 		for _, sys := range b.root {
@@ -434,6 +1030,10 @@ func (b LibBrowser) handleAdd() (LibBrowser, tea.Cmd) {
 		b.rebuildFlatList()
 		return b, nil
 
+	case NodeSmart:
+		// Add the current matches without replacing the playlist
+		return b.handleAddAll(false)
+
 	case NodeTrack:
 		// Add track to playlist without playing
 		if node.Track != nil {
@@ -475,8 +1075,9 @@ func (b LibBrowser) handleBack() (LibBrowser, tea.Cmd) {
 	return b, nil
 }
 
-// handleAddAll handles adding all tracks from selected game/system.
-func (b LibBrowser) handleAddAll() (LibBrowser, tea.Cmd) {
+// handleAddAll handles adding all tracks from selected game/system. With
+// next set, they are inserted after the playing track instead.
+func (b LibBrowser) handleAddAll(next bool) (LibBrowser, tea.Cmd) {
 	if len(b.flatList) == 0 || b.selected < 0 || b.selected >= len(b.flatList) {
 		return b, nil
 	}
@@ -493,8 +1094,24 @@ func (b LibBrowser) handleAddAll() (LibBrowser, tea.Cmd) {
 		}
 
 	case NodeGame:
-		// Add all tracks from game
-		tracks = b.lib.Tracks(node.System, node.Name)
+		// Add all tracks from game (only the composer's when grouped by composer)
+		for _, child := range node.Children {
+			tracks = append(tracks, *child.Track)
+		}
+
+	case NodeComposer:
+		// Add all tracks by the composer
+		tracks = b.lib.TracksByComposer(node.Name)
+
+	case NodeSmart:
+		// Add every track matching the smart playlist
+		tracks = b.lib.Query(node.Smart.Query)
+
+	case NodeFavorites, NodeCollection:
+		// Add every favorite, most played or recently added track
+		for _, child := range node.Children {
+			tracks = append(tracks, *child.Track)
+		}
 
 	case NodeTrack:
 		// Add single track
@@ -505,7 +1122,7 @@ func (b LibBrowser) handleAddAll() (LibBrowser, tea.Cmd) {
 
 	if len(tracks) > 0 {
 		return b, func() tea.Msg {
-			return LibTracksSelectedMsg{Tracks: tracks}
+			return LibTracksSelectedMsg{Tracks: tracks, Next: next}
 		}
 	}
 
@@ -632,12 +1249,32 @@ func (b *LibBrowser) View() string {
 	}
 
 	statusLine := fmt.Sprintf("%d tracks in %s", b.trackCount, b.lib.Root())
+	if b.grouping == GroupByComposer {
+		statusLine += " by composer"
+	}
+	if order := b.lib.TrackSort(); order != library.SortByNumber {
+		statusLine += " [" + order.String() + "]"
+	}
+	if b.filtering || b.filter != "" {
+		// The filter replaces the status line while active
+		statusLine = "/" + b.filter
+		if b.filtering {
+			statusLine += "_"
+		}
+	}
+	if b.chip != "" {
+		statusLine += " [chip: " + b.chip + "]"
+	}
 	s.WriteString(b.styles.Muted.Render(statusLine))
 	s.WriteRune('\n')
 
 	// Handle empty library
 	if len(b.flatList) == 0 {
-		s.WriteString(b.styles.Muted.Render("No tracks found"))
+		if b.filter != "" || b.chip != "" {
+			s.WriteString(b.styles.Muted.Render("No matching tracks"))
+		} else {
+			s.WriteString(b.styles.Muted.Render("No tracks found"))
+		}
 		return b.constrainToHeight(s.String())
 	}
 
@@ -661,7 +1298,7 @@ func (b *LibBrowser) View() string {
 		var marker string
 
 		switch node.Type {
-		case NodeSystem:
+		case NodeSystem, NodeComposer, NodeFavorites, NodeCollection:
 			if node.Expanded {
 				marker = "[-]"
 			} else {
@@ -675,10 +1312,22 @@ func (b *LibBrowser) View() string {
 			} else {
 				marker = "[+]"
 			}
-			content = fmt.Sprintf("%s %s", marker, node.Name)
+			name := b.gameName(node)
+			if b.grouping == GroupByComposer {
+				// The same game may appear once per system
+				name += " (" + node.System + ")"
+			}
+			content = fmt.Sprintf("%s %s", marker, name)
+
+		case NodeSmart:
+			content = fmt.Sprintf("[=] %s", node.Name)
 
 		case NodeTrack:
-			content = fmt.Sprintf("    %s", node.Name)
+			if b.favorites.Has(node.Path) {
+				content = fmt.Sprintf("  %s %s", FavoriteMarker, node.Name)
+			} else {
+				content = fmt.Sprintf("    %s", node.Name)
+			}
 		}
 
 		// Fit to width (cursor=2, indent=2*depth, padding=2)
@@ -694,7 +1343,7 @@ func (b *LibBrowser) View() string {
 			styledContent = b.styles.Selected.Render(content)
 		} else {
 			switch node.Type {
-			case NodeSystem:
+			case NodeSystem, NodeComposer, NodeFavorites, NodeCollection, NodeSmart:
 				styledContent = b.styles.System.Render(content)
 			case NodeGame:
 				styledContent = b.styles.Game.Render(content)
@@ -768,6 +1417,27 @@ func (b *LibBrowser) KeyMap() LibBrowserKeyMap {
 	return b.keyMap
 }
 
+// SetKeyMap replaces the key map.
+func (b *LibBrowser) SetKeyMap(keyMap LibBrowserKeyMap) {
+	b.keyMap = keyMap
+}
+
+// SetStyles sets the library browser styles.
+func (b *LibBrowser) SetStyles(styles LibBrowserStyles) {
+	b.styles = styles
+}
+
+// Filtering returns true while the filter is being typed, in which case
+// the browser wants every key press.
+func (b *LibBrowser) Filtering() bool {
+	return b.filtering
+}
+
+// Filter returns the current filter ("" if none).
+func (b *LibBrowser) Filter() string {
+	return b.filter
+}
+
 // SelectedNode returns the currently selected node.
 func (b *LibBrowser) SelectedNode() *TreeNode {
 	if len(b.flatList) == 0 || b.selected < 0 || b.selected >= len(b.flatList) {
@@ -780,23 +1450,11 @@ func (b *LibBrowser) SelectedNode() *TreeNode {
 // For selected items, it scrolls to show the end of long names.
 // For non-selected items, it truncates with "..." suffix.
 func (b *LibBrowser) fitName(name string, maxWidth int, isSelected bool) string {
-	if len(name) <= maxWidth {
-		return name
-	}
-
 	if isSelected {
 		// Scroll: show the end portion with "..." prefix
-		visibleLen := maxWidth - 3 // Account for "..."
-		if visibleLen < 1 {
-			visibleLen = 1
-		}
-		return "..." + name[len(name)-visibleLen:]
+		return truncateStart(name, maxWidth)
 	}
 
 	// Truncate: show the beginning with "..." suffix
-	visibleLen := maxWidth - 3 // Account for "..."
-	if visibleLen < 1 {
-		visibleLen = 1
-	}
-	return name[:visibleLen] + "..."
+	return truncateEnd(name, maxWidth)
 }
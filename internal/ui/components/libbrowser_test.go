@@ -0,0 +1,146 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// testLibraryTracks are the files of the test library and their tags.
+var testLibraryTracks = map[string]metadata.Track{
+	"sonic/01 Green Hill Zone.vgm": {
+		Title: "Green Hill Zone", Game: "Sonic the Hedgehog", System: "Mega Drive",
+		Composer: "Masato Nakamura", Chips: []metadata.ChipInfo{{Name: "YM2612"}},
+	},
+	"sonic/02 Marble Zone.vgm": {
+		Title: "Marble Zone", Game: "Sonic the Hedgehog", System: "Mega Drive",
+		Composer: "Masato Nakamura", Chips: []metadata.ChipInfo{{Name: "YM2612"}},
+	},
+	"sor/01 Go Straight.vgm": {
+		Title: "Go Straight", Game: "Streets of Rage", System: "Mega Drive",
+		Composer: "Yuzo Koshiro", Chips: []metadata.ChipInfo{{Name: "YM2612"}},
+	},
+	"outrun/01 Magical Sound Shower.vgm": {
+		Title: "Magical Sound Shower", Game: "OutRun", System: "Arcade",
+		Composer: "Hiroshi Kawaguchi", Chips: []metadata.ChipInfo{{Name: "YM2151"}},
+	},
+}
+
+// testLibraryAdded lists the files of testLibraryTracks newest first, as
+// Recently Added shows them.
+var testLibraryAdded = []string{
+	"sonic/01 Green Hill Zone.vgm",
+	"sonic/02 Marble Zone.vgm",
+	"sor/01 Go Straight.vgm",
+	"outrun/01 Magical Sound Shower.vgm",
+}
+
+// newTestLibBrowser returns a focused browser on a scanned library of
+// testLibraryTracks.
+func newTestLibBrowser(t *testing.T) LibBrowser {
+	t.Helper()
+	root := t.TempDir()
+	added := time.Now()
+	for _, name := range testLibraryAdded {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		added = added.Add(-time.Minute)
+		if err := os.Chtimes(path, added, added); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reader := metadata.ReaderFunc(func(path string) (metadata.Track, error) {
+		rel, _ := filepath.Rel(root, path)
+		track := testLibraryTracks[filepath.ToSlash(rel)]
+		track.Path = path
+		return track, nil
+	})
+
+	b := NewLibBrowser(library.New(root, reader))
+	b.SetSize(60, 30)
+	b.Focus()
+	b, _ = b.Update(b.Init()())
+	return b
+}
+
+// pressLibBrowser sends keys to the browser and returns the message of the
+// command the last one returned, if any.
+func pressLibBrowser(b LibBrowser, keys ...string) (LibBrowser, tea.Msg) {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		b, cmd = b.Update(keyMsg(k))
+	}
+	if cmd == nil {
+		return b, nil
+	}
+	return b, cmd()
+}
+
+// titles returns the titles of tracks, in order.
+func titles(tracks []library.Track) []string {
+	names := make([]string, len(tracks))
+	for i, t := range tracks {
+		names[i] = t.Title
+	}
+	return names
+}
+
+func TestLibBrowserAddVisible(t *testing.T) {
+	tests := []struct {
+		filter string
+		chip   string
+		want   []string // Titles queued, in display order; nil queues nothing
+	}{
+		{filter: "koshiro", want: []string{"Go Straight"}},
+		{filter: "mega", want: []string{"Green Hill Zone", "Marble Zone", "Go Straight"}},
+		{filter: "zone", want: []string{"Green Hill Zone", "Marble Zone"}},
+		{chip: "YM2151", want: []string{"Magical Sound Shower"}},
+		{filter: "zone", chip: "YM2151"},
+		{filter: "no such track"},
+		{}, // Collapsed tree: no tracks are visible
+	}
+	for _, tt := range tests {
+		b := newTestLibBrowser(t)
+		keys := []string{}
+		if tt.filter != "" {
+			keys = append(keys, "/")
+			for _, r := range tt.filter {
+				keys = append(keys, string(r))
+			}
+			keys = append(keys, "enter")
+		}
+		b, _ = pressLibBrowser(b, keys...)
+		b.SetChipFilter(tt.chip)
+
+		if got := titles(b.VisibleTracks()); !slices.Equal(got, tt.want) {
+			t.Errorf("filter %q, chip %q: VisibleTracks() = %q, want %q", tt.filter, tt.chip, got, tt.want)
+		}
+		_, msg := pressLibBrowser(b, "A")
+		if tt.want == nil {
+			if msg != nil {
+				t.Errorf("filter %q, chip %q: adding visible sent %#v, want nothing", tt.filter, tt.chip, msg)
+			}
+			continue
+		}
+		selected, ok := msg.(LibTracksSelectedMsg)
+		if !ok {
+			t.Errorf("filter %q, chip %q: adding visible sent %#v, want LibTracksSelectedMsg", tt.filter, tt.chip, msg)
+			continue
+		}
+		if got := titles(selected.Tracks); !slices.Equal(got, tt.want) || selected.Next {
+			t.Errorf("filter %q, chip %q: queued %q (next %v), want %q appended", tt.filter, tt.chip, got, selected.Next, tt.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package components
+
+import (
+	"cmp"
+	"slices"
+)
+
+// collectionSize is how many tracks the Most Played and Recently Added
+// nodes hold.
+const collectionSize = 50
+
+// buildCollections replaces the Most Played and Recently Added nodes with
+// ones gathered from the current tree and play counts, and rebuilds the
+// visible list. Most Played holds the tracks played to the end most often,
+// Recently Added those whose files changed last.
+func (b *LibBrowser) buildCollections() {
+	b.replaceVirtual(func() {
+		tracks := b.libraryTracks()
+
+		var played []*TreeNode
+		for _, t := range tracks {
+			if b.plays[t.Path].Count > 0 {
+				played = append(played, t)
+			}
+		}
+		slices.SortStableFunc(played, func(x, y *TreeNode) int {
+			return cmp.Compare(b.plays[y.Path].Count, b.plays[x.Path].Count)
+		})
+		b.mostPlayedNode = virtualNode(NodeCollection, "Most Played", firstNodes(played), b.mostPlayedNode)
+
+		recent := slices.Clone(tracks)
+		slices.SortStableFunc(recent, func(x, y *TreeNode) int {
+			return y.Track.ModTime.Compare(x.Track.ModTime)
+		})
+		b.recentNode = virtualNode(NodeCollection, "Recently Added", firstNodes(recent), b.recentNode)
+	})
+}
+
+// firstNodes returns up to collectionSize of nodes.
+func firstNodes(nodes []*TreeNode) []*TreeNode {
+	return nodes[:min(len(nodes), collectionSize)]
+}
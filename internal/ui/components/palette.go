@@ -0,0 +1,229 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PaletteCommand is a named action offered by the command palette.
+type PaletteCommand struct {
+	Name string  // Shown in the list and matched against the typed filter
+	Key  string  // Key that does the same outside the palette ("" if none)
+	Msg  tea.Msg // Dispatched when the command is chosen
+}
+
+// CommandPaletteKeyMap defines key bindings for the command palette.
+// Printable keys are typed into the filter, so only non-printable keys
+// are bound.
+type CommandPaletteKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Close  key.Binding
+}
+
+// DefaultCommandPaletteKeyMap returns the default command palette key bindings.
+func DefaultCommandPaletteKeyMap() CommandPaletteKeyMap {
+	return CommandPaletteKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "ctrl+k"),
+			key.WithHelp("up", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "ctrl+j"),
+			key.WithHelp("down", "down"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "run"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc", "ctrl+p"),
+			key.WithHelp("esc", "close"),
+		),
+	}
+}
+
+// CommandPalette is an overlay listing named actions, narrowed by typing.
+// Every word typed must appear in a command's name.
+type CommandPalette struct {
+	commands []PaletteCommand
+	filter   string
+	matches  []int // Indices into commands matching the filter
+	cursor   int   // Index into matches
+	offset   int   // First visible match
+	visible  bool
+	width    int
+	height   int
+
+	keyMap CommandPaletteKeyMap
+
+	// Styles
+	styles PopupStyles
+}
+
+// NewCommandPalette creates a new command palette.
+func NewCommandPalette() CommandPalette {
+	return CommandPalette{
+		width:  60,
+		height: 24,
+		keyMap: DefaultCommandPaletteKeyMap(),
+		styles: DefaultPopupStyles(),
+	}
+}
+
+// Update handles messages for the command palette.
+func (c CommandPalette) Update(msg tea.Msg) (CommandPalette, tea.Cmd) {
+	if !c.visible {
+		return c, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return c, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, c.keyMap.Close):
+		c.visible = false
+	case key.Matches(keyMsg, c.keyMap.Up):
+		c.moveCursor(-1)
+	case key.Matches(keyMsg, c.keyMap.Down):
+		c.moveCursor(1)
+	case key.Matches(keyMsg, c.keyMap.Select):
+		c.visible = false
+		if len(c.matches) == 0 {
+			return c, nil
+		}
+		run := c.commands[c.matches[c.cursor]].Msg
+		return c, func() tea.Msg { return run }
+	case keyMsg.Type == tea.KeyBackspace:
+		if r := []rune(c.filter); len(r) > 0 {
+			c.filter = string(r[:len(r)-1])
+			c.applyFilter()
+		}
+	case keyMsg.Type == tea.KeyRunes, keyMsg.Type == tea.KeySpace:
+		c.filter += string(keyMsg.Runes)
+		c.applyFilter()
+	}
+	return c, nil
+}
+
+// applyFilter recomputes the commands matching the filter and moves the
+// cursor back to the first.
+func (c *CommandPalette) applyFilter() {
+	words := strings.Fields(strings.ToLower(c.filter))
+	c.matches = c.matches[:0]
+	for i, cmd := range c.commands {
+		name := strings.ToLower(cmd.Name)
+		matched := true
+		for _, w := range words {
+			if !strings.Contains(name, w) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			c.matches = append(c.matches, i)
+		}
+	}
+	c.cursor = 0
+	c.offset = 0
+}
+
+// moveCursor moves the cursor by delta matches, scrolling to keep it visible.
+func (c *CommandPalette) moveCursor(delta int) {
+	c.cursor = max(0, min(c.cursor+delta, len(c.matches)-1))
+	rows := c.listHeight()
+	if c.cursor < c.offset {
+		c.offset = c.cursor
+	}
+	if c.cursor >= c.offset+rows {
+		c.offset = c.cursor - rows + 1
+	}
+}
+
+// View renders the command palette as an overlay.
+func (c CommandPalette) View() string {
+	if !c.visible {
+		return ""
+	}
+
+	popupWidth := c.popupWidth()
+	return c.styles.renderPopup("Commands", "Type to filter  Enter: run  Esc: close", popupWidth,
+		c.styles.Key.Render(": ")+c.filter+"_",
+		"",
+		c.buildContent(popupWidth-4),
+	)
+}
+
+// popupWidth returns the width of the popup box for the current size.
+func (c CommandPalette) popupWidth() int {
+	return clampWidth(c.width, 60, 40, 60)
+}
+
+// listHeight returns the number of commands shown at once.
+func (c CommandPalette) listHeight() int {
+	rows := c.height*80/100 - 8
+	if rows < 5 {
+		rows = 5
+	}
+	return rows
+}
+
+// buildContent renders the visible matches, one per line, as
+// "name  key".
+func (c CommandPalette) buildContent(width int) string {
+	if len(c.matches) == 0 {
+		return c.styles.Desc.Render("  No matching commands")
+	}
+
+	var b strings.Builder
+	end := min(c.offset+c.listHeight(), len(c.matches))
+	for i := c.offset; i < end; i++ {
+		cmd := c.commands[c.matches[i]]
+		nameWidth := width - 12
+		line := fmt.Sprintf("%-*s%10s", nameWidth, truncateEnd(cmd.Name, nameWidth), cmd.Key)
+
+		if i == c.cursor {
+			b.WriteString(c.styles.Key.Render("> " + line))
+		} else {
+			b.WriteString(c.styles.Desc.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// SetSize sets the available size for the command palette.
+func (c *CommandPalette) SetSize(width, height int) {
+	c.width = width
+	c.height = height
+}
+
+// SetStyles sets the popup styles.
+func (c *CommandPalette) SetStyles(styles PopupStyles) {
+	c.styles = styles
+}
+
+// Show makes the command palette visible with the given commands and an
+// empty filter.
+func (c *CommandPalette) Show(commands []PaletteCommand) {
+	c.commands = commands
+	c.filter = ""
+	c.applyFilter()
+	c.visible = true
+}
+
+// Hide makes the command palette invisible.
+func (c *CommandPalette) Hide() {
+	c.visible = false
+}
+
+// Visible returns whether the command palette is visible.
+func (c CommandPalette) Visible() bool {
+	return c.visible
+}
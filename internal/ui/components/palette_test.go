@@ -0,0 +1,53 @@
+package components
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCommandPaletteFilter(t *testing.T) {
+	commands := []PaletteCommand{
+		{Name: "Toggle radio mode", Msg: "radio"},
+		{Name: "Toggle shuffle", Msg: "shuffle"},
+		{Name: "Rescan library", Msg: "rescan"},
+		{Name: "Café mode", Msg: "cafe"},
+	}
+	all := []string{"Toggle radio mode", "Toggle shuffle", "Rescan library", "Café mode"}
+	names := func(c CommandPalette) []string {
+		var n []string
+		for _, i := range c.matches {
+			n = append(n, commands[i].Name)
+		}
+		return n
+	}
+
+	tests := []struct {
+		keys   []string
+		filter string
+		want   []string
+	}{
+		{nil, "", all},
+		{[]string{"t", "o", "g"}, "tog", []string{"Toggle radio mode", "Toggle shuffle"}},
+		{[]string{"m", "o", "d", "e", " ", "t", "o", "g"}, "mode tog", []string{"Toggle radio mode"}},
+		{[]string{"L", "I", "B"}, "LIB", []string{"Rescan library"}},
+		{[]string{"x", "backspace", "r", "e", "s"}, "res", []string{"Rescan library"}},
+		// Backspace removes the whole last character, not its last byte
+		{[]string{"c", "a", "f", "é", "backspace"}, "caf", []string{"Café mode"}},
+		{[]string{"é", "é", "backspace", "backspace", "backspace"}, "", all},
+		{[]string{"n", "o", "p", "e"}, "nope", nil},
+	}
+	for _, tt := range tests {
+		c := NewCommandPalette()
+		c.Show(commands)
+		for _, k := range tt.keys {
+			msg := keyMsg(k)
+			if k == " " {
+				msg.Runes = []rune(k) // The terminal sends the space along
+			}
+			c, _ = c.Update(msg)
+		}
+		if c.filter != tt.filter || !slices.Equal(names(c), tt.want) {
+			t.Errorf("keys %q: filter %q matching %q, want %q matching %q", tt.keys, c.filter, names(c), tt.filter, tt.want)
+		}
+	}
+}
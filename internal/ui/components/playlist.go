@@ -3,12 +3,16 @@ package components
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
 )
 
 // Track represents a track in the playlist.
@@ -16,13 +20,33 @@ import (
 type Track struct {
 	Path        string
 	Title       string
-	Game        string
+	Game        string // GD3 game name (or directory name if untagged)
+	DirGame     string // Name of the directory containing the file
 	System      string
 	Composer    string
 	Duration    time.Duration
 	TrackNumber int
+
+	added  int  // Order added to the playlist, for sorting back into it
+	broken bool // Failed to load the last time it was played
+}
+
+// GameName returns the track's game name from the given label source.
+func (t Track) GameName(label library.GameLabel) string {
+	return label.Pick(t.Game, t.DirGame)
 }
 
+// RemoveCursor selects where the cursor goes after removing a track.
+type RemoveCursor int
+
+const (
+	// RemoveCursorStay keeps the cursor on the same row, which now holds
+	// the next track (or the new last track if the last one was removed).
+	RemoveCursorStay RemoveCursor = iota
+	// RemoveCursorUp moves the cursor up to the row before the removed one.
+	RemoveCursorUp
+)
+
 // PlaylistKeyMap defines keybindings for the playlist component.
 type PlaylistKeyMap struct {
 	Up       key.Binding
@@ -34,6 +58,26 @@ type PlaylistKeyMap struct {
 	Clear    key.Binding
 	PageUp   key.Binding
 	PageDown key.Binding
+	Reverse  key.Binding
+	GoTo     key.Binding // Prompt for a queue position to jump to
+	Favorite key.Binding // Star or unstar the selected track
+	EditTags key.Binding // Edit the tags stored in the selected track's file
+	Undo     key.Binding // Restore the playlist from before a remove or clear
+	ToTop    key.Binding // Move the selected track to the start of the queue
+	ToBottom key.Binding // Move the selected track to the end of the queue
+	PlayNext key.Binding // Move the selected track to play after the current one
+	Sort     key.Binding // Sort by the next order: title, game, duration, added
+
+	RemoveBroken key.Binding // Remove every track that failed to load
+
+	Search    key.Binding // Find a track by title or game
+	NextMatch key.Binding // Jump to the next search match
+	PrevMatch key.Binding // Jump to the previous search match
+
+	RemoveAbove key.Binding // Remove every track before the selected one
+	RemoveBelow key.Binding // Remove every track after the selected one
+
+	ToggleRemoveCursor key.Binding
 }
 
 // DefaultPlaylistKeyMap returns the default keybindings for the playlist.
@@ -67,6 +111,70 @@ func DefaultPlaylistKeyMap() PlaylistKeyMap {
 			key.WithKeys("D"),
 			key.WithHelp("D", "clear"),
 		),
+		Reverse: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "reverse view"),
+		),
+		GoTo: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "go to number"),
+		),
+		Favorite: key.NewBinding(
+			key.WithKeys("*"),
+			key.WithHelp("*", "favorite"),
+		),
+		EditTags: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit tags"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("ctrl+z"),
+			key.WithHelp("ctrl+z", "undo remove/clear"),
+		),
+		ToTop: key.NewBinding(
+			key.WithKeys("ctrl+k"),
+			key.WithHelp("ctrl+k", "move to top"),
+		),
+		ToBottom: key.NewBinding(
+			key.WithKeys("ctrl+j"),
+			key.WithHelp("ctrl+j", "move to bottom"),
+		),
+		RemoveAbove: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "remove above"),
+		),
+		RemoveBelow: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "remove below"),
+		),
+		PlayNext: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "play next"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "cycle sort"),
+		),
+		RemoveBroken: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "remove unplayable"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "previous match"),
+		),
+		ToggleRemoveCursor: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "cursor after remove"),
+		),
 		PageUp: key.NewBinding(
 			key.WithKeys("pgup", "ctrl+u"),
 			key.WithHelp("pgup", "page up"),
@@ -85,6 +193,38 @@ type Playlist struct {
 	current int // Currently playing index (-1 if none)
 	focused bool
 
+	columns   []PlaylistColumn  // Columns shown, in order
+	gameLabel library.GameLabel // Source of the Game column
+	sort      PlaylistSort      // Sort order until tracks are added or moved
+	added     int               // Tracks added so far, for numbering them
+	reversed  bool              // Display newest tracks first (play order is unchanged)
+
+	// Shuffle play: tracks play in a random order, each once per round,
+	// while the displayed order stays as it is
+	shuffle  bool
+	weighted bool // Smart shuffle: draw rounds weighted by shuffleWeight
+	bag      shuffleBag
+
+	plays PlayStats // Play counts and times, for smart shuffle
+
+	removeCursor RemoveCursor // Cursor placement after RemoveSelected
+
+	// Playlist before the last remove or clear, dropped by any other
+	// change to the tracks, which undoing would silently lose
+	undo *playlistSnapshot
+
+	favorites Favorites // Tracks marked with FavoriteMarker
+
+	// Go-to prompt: a queue position being typed
+	goTo    string
+	goingTo bool
+
+	// Search: text matched against titles and games, kept after the
+	// prompt closes so matches can be stepped through
+	search     string
+	searching  bool // Typing the search
+	searchFrom int  // Track selected when the search started
+
 	keyMap PlaylistKeyMap
 
 	// Dimensions
@@ -95,6 +235,13 @@ type Playlist struct {
 	styles PlaylistStyles
 }
 
+// playlistSnapshot is a copy of the playlist taken before a destructive
+// change, for undo.
+type playlistSnapshot struct {
+	tracks  []Track
+	current int
+}
+
 // PlaylistStyles defines the styles for the playlist component.
 type PlaylistStyles struct {
 	// Table styles
@@ -141,42 +288,58 @@ func DefaultPlaylistStyles() PlaylistStyles {
 
 // NewPlaylist creates a new Playlist component.
 func NewPlaylist() Playlist {
-	columns := []table.Column{
-		{Title: "Duration", Width: 10}, // Extra width for "> " indicator
-		{Title: "Title", Width: 20},
-		{Title: "Game", Width: 15},
+	p := Playlist{
+		tracks:  []Track{},
+		columns: DefaultPlaylistColumns(),
+		current: -1,
+		focused: false,
+		keyMap:  DefaultPlaylistKeyMap(),
+		width:   40,
+		height:  10,
 	}
 
-	t := table.New(
-		table.WithColumns(columns),
+	p.table = table.New(
+		table.WithColumns(p.tableColumns(34)),
 		table.WithRows([]table.Row{}),
 		table.WithFocused(false),
 		table.WithHeight(5),
 	)
 
 	// Apply default table styles
+	p.SetStyles(DefaultPlaylistStyles())
+
+	return p
+}
+
+// SetStyles sets the playlist styles and applies them to the table.
+func (p *Playlist) SetStyles(styles PlaylistStyles) {
+	p.styles = styles
+
 	s := table.DefaultStyles()
-	s.Header = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#A0A0A0")).
-		Padding(0, 1)
-	s.Cell = lipgloss.NewStyle().
-		Padding(0, 1)
-	s.Selected = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#7571F9"))
-	t.SetStyles(s)
-
-	return Playlist{
-		table:   t,
-		tracks:  []Track{},
-		current: -1,
-		focused: false,
-		keyMap:  DefaultPlaylistKeyMap(),
-		styles:  DefaultPlaylistStyles(),
-		width:   40,
-		height:  10,
-	}
+	s.Header = styles.Header
+	s.Cell = styles.Cell
+	s.Selected = styles.Selected
+	p.table.SetStyles(s)
+}
+
+// SetGameLabel sets where the Game column's names come from.
+func (p *Playlist) SetGameLabel(label library.GameLabel) {
+	p.gameLabel = label
+	p.updateTableRows()
+}
+
+// PlaylistGoToMsg is sent when a queue position entered at the go-to
+// prompt has been jumped to. Play is set if the track should be played.
+type PlaylistGoToMsg struct {
+	Index int
+	Play  bool
+}
+
+// PlaylistGoToErrorMsg is sent when the go-to prompt is confirmed with a
+// number outside the queue.
+type PlaylistGoToErrorMsg struct {
+	Number int
+	Len    int
 }
 
 // Update handles messages for the playlist.
@@ -187,6 +350,25 @@ func (p Playlist) Update(msg tea.Msg) (Playlist, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if p.goingTo {
+			return p.handleGoToKey(msg)
+		}
+		if p.searching {
+			return p.handleSearchKey(msg)
+		}
+		if p.search != "" {
+			switch {
+			case key.Matches(msg, p.keyMap.NextMatch):
+				p.stepMatch(1)
+				return p, nil
+			case key.Matches(msg, p.keyMap.PrevMatch):
+				p.stepMatch(-1)
+				return p, nil
+			case msg.Type == tea.KeyEsc:
+				p.search = ""
+				return p, nil
+			}
+		}
 		// Handle navigation keys directly - don't forward to table
 		// to avoid double-triggering (table also handles j/k/up/down)
 		switch {
@@ -208,6 +390,37 @@ func (p Playlist) Update(msg tea.Msg) (Playlist, tea.Cmd) {
 		case key.Matches(msg, p.keyMap.PageDown):
 			p.table.MoveDown(p.table.Height())
 			return p, nil
+		case key.Matches(msg, p.keyMap.Reverse):
+			p.SetReversed(!p.reversed)
+			return p, nil
+		case key.Matches(msg, p.keyMap.ToggleRemoveCursor):
+			if p.removeCursor == RemoveCursorStay {
+				p.removeCursor = RemoveCursorUp
+			} else {
+				p.removeCursor = RemoveCursorStay
+			}
+			return p, nil
+		case key.Matches(msg, p.keyMap.Favorite):
+			if t := p.SelectedTrack(); t != nil {
+				path := t.Path
+				return p, func() tea.Msg { return FavoriteToggleMsg{Path: path} }
+			}
+			return p, nil
+		case key.Matches(msg, p.keyMap.EditTags):
+			if t := p.SelectedTrack(); t != nil {
+				path := t.Path
+				return p, func() tea.Msg { return EditTagsMsg{Path: path} }
+			}
+			return p, nil
+		case key.Matches(msg, p.keyMap.Search):
+			p.startSearch()
+			return p, nil
+		case key.Matches(msg, p.keyMap.GoTo):
+			if len(p.tracks) > 0 {
+				p.goingTo = true
+				p.goTo = ""
+			}
+			return p, nil
 		}
 	}
 
@@ -217,6 +430,70 @@ func (p Playlist) Update(msg tea.Msg) (Playlist, tea.Cmd) {
 	return p, cmd
 }
 
+// handleGoToKey handles keyboard input while a queue position is typed.
+// Enter jumps to it, p jumps to it and plays it, and esc cancels.
+func (p Playlist) handleGoToKey(msg tea.KeyMsg) (Playlist, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		p.goingTo = false
+		return p, nil
+	case tea.KeyBackspace:
+		if len(p.goTo) > 0 {
+			p.goTo = p.goTo[:len(p.goTo)-1]
+		}
+		return p, nil
+	case tea.KeyEnter:
+		return p.confirmGoTo(false)
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			switch {
+			case r >= '0' && r <= '9':
+				if len(p.goTo) < 6 {
+					p.goTo += string(r)
+				}
+			case r == 'p':
+				return p.confirmGoTo(true)
+			}
+		}
+	}
+	return p, nil
+}
+
+// confirmGoTo closes the go-to prompt and jumps to the typed position.
+func (p Playlist) confirmGoTo(play bool) (Playlist, tea.Cmd) {
+	p.goingTo = false
+	if p.goTo == "" {
+		return p, nil
+	}
+
+	number, _ := strconv.Atoi(p.goTo) // Only digits can be typed
+	if !p.GoToIndex(number - 1) {
+		n := len(p.tracks)
+		return p, func() tea.Msg {
+			return PlaylistGoToErrorMsg{Number: number, Len: n}
+		}
+	}
+	index := number - 1
+	return p, func() tea.Msg {
+		return PlaylistGoToMsg{Index: index, Play: play}
+	}
+}
+
+// GoToIndex moves the cursor to the track at index (0-based, in play
+// order). It returns false and leaves the cursor alone if index is out of range.
+func (p *Playlist) GoToIndex(index int) bool {
+	if index < 0 || index >= len(p.tracks) {
+		return false
+	}
+	p.table.SetCursor(p.rowTrack(index, len(p.tracks)))
+	return true
+}
+
+// GoingTo returns true while a queue position is being typed.
+func (p Playlist) GoingTo() bool {
+	return p.goingTo
+}
+
 // View renders the playlist.
 func (p Playlist) View() string {
 	return p.table.View()
@@ -228,28 +505,12 @@ func (p *Playlist) SetSize(width, height int) {
 	p.height = height
 
 	// Calculate column widths based on available space
-	// Duration: 10 (includes "> " indicator), Title: flexible, Game: ~30%
 	availableWidth := width - 6 // Account for borders and padding
 	if availableWidth < 20 {
 		availableWidth = 20
 	}
 
-	durationWidth := 10 // Includes space for "> " indicator
-	gameWidth := availableWidth * 30 / 100
-	if gameWidth < 10 {
-		gameWidth = 10
-	}
-	titleWidth := availableWidth - durationWidth - gameWidth
-	if titleWidth < 10 {
-		titleWidth = 10
-	}
-
-	columns := []table.Column{
-		{Title: "Duration", Width: durationWidth},
-		{Title: "Title", Width: titleWidth},
-		{Title: "Game", Width: gameWidth},
-	}
-	p.table.SetColumns(columns)
+	p.table.SetColumns(p.tableColumns(availableWidth))
 	p.table.SetWidth(availableWidth)
 
 	// Height minus header row and borders
@@ -258,6 +519,9 @@ func (p *Playlist) SetSize(width, height int) {
 		tableHeight = 1
 	}
 	p.table.SetHeight(tableHeight)
+
+	// Rows are fitted to the column widths, so they're rebuilt
+	p.updateTableRows()
 }
 
 // Focus sets the playlist to focused state.
@@ -279,13 +543,20 @@ func (p Playlist) Focused() bool {
 
 // AddTrack adds a single track to the playlist.
 func (p *Playlist) AddTrack(track Track) {
-	p.tracks = append(p.tracks, track)
-	p.updateTableRows()
+	p.AddTracks([]Track{track})
 }
 
 // AddTracks adds multiple tracks to the playlist.
 func (p *Playlist) AddTracks(tracks []Track) {
 	p.tracks = append(p.tracks, tracks...)
+	p.stampAdded(len(p.tracks)-len(tracks), len(p.tracks))
+	if len(tracks) > 0 {
+		p.sort = PlaylistUnsorted // Appended after the sorted tracks
+		p.undo = nil
+	}
+	if p.shuffle {
+		p.bag.grow(len(p.tracks))
+	}
 	p.updateTableRows()
 }
 
@@ -295,13 +566,18 @@ func (p *Playlist) RemoveSelected() {
 		return
 	}
 
-	idx := p.table.Cursor()
+	row := p.table.Cursor()
+	idx := p.SelectedIndex()
 	if idx < 0 || idx >= len(p.tracks) {
 		return
 	}
 
 	// Remove the track
+	p.saveUndo()
 	p.tracks = append(p.tracks[:idx], p.tracks[idx+1:]...)
+	if p.shuffle {
+		p.bag.remove(idx)
+	}
 
 	// Adjust current playing index if needed
 	if p.current >= 0 {
@@ -314,19 +590,227 @@ func (p *Playlist) RemoveSelected() {
 
 	p.updateTableRows()
 
-	// Adjust cursor if it's now out of bounds
-	if idx >= len(p.tracks) && len(p.tracks) > 0 {
-		p.table.SetCursor(len(p.tracks) - 1)
+	// Keep the cursor on the same row or move it up, adjusting if it's now
+	// out of bounds
+	if p.removeCursor == RemoveCursorUp && row > 0 {
+		row--
+	}
+	if row >= len(p.tracks) {
+		row = len(p.tracks) - 1
+	}
+	if row >= 0 {
+		p.table.SetCursor(row)
+	}
+}
+
+// InsertAfterCurrent inserts tracks right after the playing track (at the
+// start if nothing is playing), so they play next in order. In shuffle
+// play they are also drawn next.
+func (p *Playlist) InsertAfterCurrent(tracks []Track) {
+	if len(tracks) == 0 {
+		return
+	}
+	selected := p.SelectedIndex()
+	at := p.current + 1
+	p.tracks = slices.Insert(p.tracks, at, tracks...)
+	p.stampAdded(at, at+len(tracks))
+	p.sort = PlaylistUnsorted // Placed by hand
+	p.undo = nil
+	if p.shuffle {
+		p.bag.insert(at, len(tracks))
+	}
+	p.updateTableRows()
+
+	// Keep the same track selected
+	if selected >= at {
+		selected += len(tracks)
+	}
+	if selected >= 0 && selected < len(p.tracks) {
+		p.table.SetCursor(p.rowTrack(selected, len(p.tracks)))
+	}
+}
+
+// MoveAfterCurrent moves the selected track to play right after the
+// playing track and keeps it selected. It returns false if there was
+// nothing to move.
+func (p *Playlist) MoveAfterCurrent() bool {
+	from := p.SelectedIndex()
+	if from < 0 || from >= len(p.tracks) || from == p.current {
+		return false
+	}
+	to := p.current + 1
+	if from < p.current {
+		to = p.current // The playing track moves up one
+	}
+	moved := p.moveSelected(to)
+	if p.shuffle {
+		p.bag.playNext(to)
+		moved = true
+	}
+	return moved
+}
+
+// MoveToTop moves the selected track to the start of the play order and
+// keeps it selected. It returns false if there was nothing to move.
+func (p *Playlist) MoveToTop() bool {
+	return p.moveSelected(0)
+}
+
+// MoveToBottom moves the selected track to the end of the play order and
+// keeps it selected. It returns false if there was nothing to move.
+func (p *Playlist) MoveToBottom() bool {
+	return p.moveSelected(len(p.tracks) - 1)
+}
+
+// moveSelected moves the selected track to index to, shifting the tracks
+// in between, and keeps the playing track marked as current.
+func (p *Playlist) moveSelected(to int) bool {
+	from := p.SelectedIndex()
+	if from < 0 || from >= len(p.tracks) || from == to {
+		return false
+	}
+
+	t := p.tracks[from]
+	p.tracks = slices.Delete(p.tracks, from, from+1)
+	p.tracks = slices.Insert(p.tracks, to, t)
+	p.sort = PlaylistUnsorted // Placed by hand
+	p.undo = nil
+
+	// Where each track index ends up after the move
+	moved := func(i int) int {
+		switch {
+		case i == from:
+			return to
+		case from < to && i > from && i <= to:
+			return i - 1
+		case to < from && i >= to && i < from:
+			return i + 1
+		}
+		return i
+	}
+	if p.current >= 0 {
+		p.current = moved(p.current)
+	}
+	for k, i := range p.bag.order {
+		p.bag.order[k] = moved(i)
+	}
+
+	p.updateTableRows()
+	p.table.SetCursor(p.rowTrack(to, len(p.tracks)))
+	return true
+}
+
+// RemoveAbove removes every track before the selected one in play order,
+// keeping the selection. It returns true if the playing track was removed.
+func (p *Playlist) RemoveAbove() bool {
+	return p.removeRange(0, p.SelectedIndex())
+}
+
+// RemoveBelow removes every track after the selected one in play order,
+// keeping the selection. It returns true if the playing track was removed.
+func (p *Playlist) RemoveBelow() bool {
+	if idx := p.SelectedIndex(); idx >= 0 {
+		return p.removeRange(idx+1, len(p.tracks))
 	}
+	return false
+}
+
+// removeRange removes the tracks from index lo up to hi (exclusive) and
+// keeps the track that was selected selected. It returns true if the
+// playing track was among them.
+func (p *Playlist) removeRange(lo, hi int) bool {
+	if lo < 0 || hi > len(p.tracks) || lo >= hi {
+		return false
+	}
+	selected := p.SelectedIndex()
+
+	p.saveUndo()
+	p.tracks = slices.Delete(p.tracks, lo, hi)
+
+	removedCurrent := p.current >= lo && p.current < hi
+	switch {
+	case removedCurrent:
+		p.current = -1
+	case p.current >= hi:
+		p.current -= hi - lo
+	}
+	if p.shuffle {
+		p.bag = p.drawShuffle(p.current)
+	}
+	p.updateTableRows()
+
+	if selected >= hi {
+		selected -= hi - lo
+	}
+	if selected >= 0 && selected < len(p.tracks) {
+		p.table.SetCursor(p.rowTrack(selected, len(p.tracks)))
+	}
+	return removedCurrent
+}
+
+// SetRemoveCursor sets where the cursor goes after removing a track.
+func (p *Playlist) SetRemoveCursor(rc RemoveCursor) {
+	p.removeCursor = rc
+}
+
+// RemoveCursor returns where the cursor goes after removing a track.
+func (p Playlist) RemoveCursor() RemoveCursor {
+	return p.removeCursor
 }
 
 // Clear removes all tracks from the playlist.
 func (p *Playlist) Clear() {
+	if len(p.tracks) > 0 {
+		p.saveUndo()
+	}
 	p.tracks = []Track{}
 	p.current = -1
+	p.bag = shuffleBag{pos: -1}
 	p.updateTableRows()
 }
 
+// saveUndo snapshots the playlist so the next change can be undone.
+func (p *Playlist) saveUndo() {
+	p.undo = &playlistSnapshot{tracks: p.Tracks(), current: p.current}
+}
+
+// CanUndo returns true if there is a removal or clear to undo.
+func (p Playlist) CanUndo() bool {
+	return p.undo != nil
+}
+
+// Undo restores the playlist from before the last remove or clear and
+// returns false if there is nothing to undo. playing is the path of the
+// track playing now, if any: it stays marked as current, since playback
+// may have moved on since the snapshot.
+func (p *Playlist) Undo(playing string) bool {
+	if p.undo == nil {
+		return false
+	}
+	snap := *p.undo
+	p.undo = nil
+
+	p.tracks = snap.tracks
+	p.current = -1
+	if playing != "" {
+		if t := p.GetTrack(snap.current); t != nil && t.Path == playing {
+			p.current = snap.current
+		} else {
+			for i, t := range p.tracks {
+				if t.Path == playing {
+					p.current = i
+					break
+				}
+			}
+		}
+	}
+	if p.shuffle {
+		p.bag = p.drawShuffle(p.current)
+	}
+	p.updateTableRows()
+	return true
+}
+
 // SetCurrentTrack sets the index of the currently playing track.
 func (p *Playlist) SetCurrentTrack(index int) {
 	if index < -1 {
@@ -336,12 +820,106 @@ func (p *Playlist) SetCurrentTrack(index int) {
 		index = -1
 	}
 	p.current = index
+	if p.shuffle && index >= 0 && p.bag.play(index) {
+		p.bag = p.drawShuffle(index)
+	}
 	p.updateTableRows()
 }
 
 // SelectedIndex returns the index of the currently selected (highlighted) track.
 func (p Playlist) SelectedIndex() int {
-	return p.table.Cursor()
+	return p.rowTrack(p.table.Cursor(), len(p.tracks))
+}
+
+// rowTrack maps between table rows and track indices for a playlist of
+// n tracks. The mapping is its own inverse, so it works in both directions.
+func (p Playlist) rowTrack(i, n int) int {
+	if p.reversed && n > 0 {
+		return n - 1 - i
+	}
+	return i
+}
+
+// SetReversed sets whether the newest tracks are displayed first.
+// Play order is unaffected and the selected track stays selected.
+func (p *Playlist) SetReversed(reversed bool) {
+	selected := p.SelectedIndex()
+	p.reversed = reversed
+	p.updateTableRows()
+	if selected >= 0 && selected < len(p.tracks) {
+		p.table.SetCursor(p.rowTrack(selected, len(p.tracks)))
+	}
+}
+
+// SetShuffleMode turns shuffle play on or off. With it on, the next and
+// previous tracks come from a random play order that plays every track
+// once before starting a new round; the displayed order is unchanged.
+// Smart shuffle weights each round's draw (see shuffleWeight).
+func (p *Playlist) SetShuffleMode(mode ShuffleMode) {
+	p.shuffle = mode != ShuffleOff
+	p.weighted = mode == ShuffleWeighted
+	p.bag = shuffleBag{pos: -1}
+	if p.shuffle {
+		p.bag = p.drawShuffle(p.current)
+	}
+}
+
+// ShuffleMode returns the shuffle mode.
+func (p Playlist) ShuffleMode() ShuffleMode {
+	switch {
+	case p.weighted:
+		return ShuffleWeighted
+	case p.shuffle:
+		return ShuffleRandom
+	}
+	return ShuffleOff
+}
+
+// Shuffle returns whether shuffle play is on.
+func (p Playlist) Shuffle() bool {
+	return p.shuffle
+}
+
+// SetPlayStats sets the play counts and times smart shuffle weighs by.
+// The playlist reads them when drawing a round, so changes to the map
+// apply from the next round.
+func (p *Playlist) SetPlayStats(plays PlayStats) {
+	p.plays = plays
+}
+
+// drawShuffle draws a new shuffle round over the tracks, with first (if
+// a track index) as its current track.
+func (p Playlist) drawShuffle(first int) shuffleBag {
+	if !p.weighted {
+		return newShuffleBag(len(p.tracks), first)
+	}
+	now := time.Now()
+	weights := make([]float64, len(p.tracks))
+	for i, t := range p.tracks {
+		weights[i] = p.shuffleWeight(t, now)
+	}
+	return newWeightedShuffleBag(weights, first)
+}
+
+// shuffleWeight returns how likely smart shuffle draws a track early on:
+// favorites are twice as likely, every earlier play makes a track less
+// likely, and a track played within recentPlayWindow is held back.
+func (p Playlist) shuffleWeight(t Track, now time.Time) float64 {
+	w := 1.0
+	if p.favorites.Has(t.Path) {
+		w *= 2
+	}
+	stat := p.plays[t.Path]
+	w /= float64(1 + stat.Count)
+	if since := now.Sub(stat.Last); !stat.Last.IsZero() && since < recentPlayWindow {
+		w *= max(float64(since)/float64(recentPlayWindow), 0.01)
+	}
+	return w
+}
+
+// Reversed returns whether the newest tracks are displayed first.
+func (p Playlist) Reversed() bool {
+	return p.reversed
 }
 
 // GetTrack returns the track at the given index, or nil if out of bounds.
@@ -352,6 +930,33 @@ func (p Playlist) GetTrack(index int) *Track {
 	return &p.tracks[index]
 }
 
+// SetDuration records the duration of the track at index, e.g. once
+// playing it has shown the real length.
+func (p *Playlist) SetDuration(index int, d time.Duration) {
+	if index < 0 || index >= len(p.tracks) {
+		return
+	}
+	p.tracks[index].Duration = d
+	p.updateTableRows()
+}
+
+// SetTrackTags replaces the title, game, system, composer and duration of
+// every entry for track's file, e.g. after its tags are edited.
+func (p *Playlist) SetTrackTags(track Track) {
+	for i := range p.tracks {
+		t := &p.tracks[i]
+		if t.Path != track.Path {
+			continue
+		}
+		t.Title = track.Title
+		t.Game = track.Game
+		t.System = track.System
+		t.Composer = track.Composer
+		t.Duration = track.Duration
+	}
+	p.updateTableRows()
+}
+
 // SelectedTrack returns the currently selected track, or nil if none.
 func (p Playlist) SelectedTrack() *Track {
 	return p.GetTrack(p.SelectedIndex())
@@ -381,41 +986,62 @@ func (p Playlist) Tracks() []Track {
 
 // updateTableRows syncs the table rows with the tracks slice.
 func (p *Playlist) updateTableRows() {
-	// Save cursor position before updating rows
-	savedCursor := p.table.Cursor()
+	// Save the selected track, mapped through the rows as they were
+	savedTrack := p.rowTrack(p.table.Cursor(), len(p.table.Rows()))
 
 	rows := make([]table.Row, len(p.tracks))
 	for i, track := range p.tracks {
-		// Format duration with playing indicator
-		duration := formatDuration(track.Duration)
-		if i == p.current {
-			// Use play symbol as indicator (visible in all terminals)
-			duration = "> " + duration
-		} else {
-			duration = "  " + duration
-		}
-
-		rows[i] = table.Row{duration, track.Title, track.Game}
+		rows[p.rowTrack(i, len(rows))] = p.tableRow(i, track)
 	}
 	p.table.SetRows(rows)
 
-	// Restore cursor position if still valid
-	if savedCursor >= 0 && savedCursor < len(rows) {
-		p.table.SetCursor(savedCursor)
+	// Restore the selected track if still valid
+	if savedTrack >= 0 && savedTrack < len(rows) {
+		p.table.SetCursor(p.rowTrack(savedTrack, len(rows)))
 	} else if len(rows) > 0 {
 		p.table.SetCursor(0)
 	}
 }
 
+// SetFavorites sets the favorite tracks to mark.
+func (p *Playlist) SetFavorites(favorites Favorites) {
+	p.favorites = favorites
+	p.updateTableRows()
+}
+
 // Title returns the title for the playlist panel.
 func (p Playlist) Title() string {
 	if len(p.tracks) == 0 {
 		return "Playlist"
 	}
+	order := ""
+	if p.reversed {
+		order = " (newest first)"
+	}
+	if p.weighted {
+		order += " (smart shuffle)"
+	} else if p.shuffle {
+		order += " (shuffle)"
+	}
+	if p.sort != PlaylistUnsorted {
+		order += " (" + p.sort.String() + ")"
+	}
+	if p.goingTo {
+		return fmt.Sprintf("Playlist [%d] go to #%s_", len(p.tracks), p.goTo)
+	}
+	if p.searching {
+		return fmt.Sprintf("Playlist [%d] find: %s_", len(p.tracks), p.search)
+	}
+	if p.search != "" {
+		order += fmt.Sprintf(" (find %q: n/N)", p.search)
+	}
+	if n := p.BrokenCount(); n > 0 {
+		order += fmt.Sprintf(" (%d unplayable)", n)
+	}
 	if p.current >= 0 {
-		return fmt.Sprintf("Playlist [%d/%d]", p.current+1, len(p.tracks))
+		return fmt.Sprintf("Playlist [%d/%d]%s", p.current+1, len(p.tracks), order)
 	}
-	return fmt.Sprintf("Playlist [%d]", len(p.tracks))
+	return fmt.Sprintf("Playlist [%d]%s", len(p.tracks), order)
 }
 
 // KeyMap returns the playlist's keymap for help display.
@@ -423,6 +1049,11 @@ func (p Playlist) KeyMap() PlaylistKeyMap {
 	return p.keyMap
 }
 
+// SetKeyMap replaces the playlist's keymap.
+func (p *Playlist) SetKeyMap(keyMap PlaylistKeyMap) {
+	p.keyMap = keyMap
+}
+
 // IsEmpty returns true if the playlist has no tracks.
 func (p Playlist) IsEmpty() bool {
 	return len(p.tracks) == 0
@@ -463,6 +1094,9 @@ func (p Playlist) PeekNextTrack() int {
 	if len(p.tracks) == 0 {
 		return -1
 	}
+	if p.shuffle {
+		return p.bag.next()
+	}
 	if p.current < 0 {
 		return 0 // First track if nothing playing
 	}
@@ -472,12 +1106,46 @@ func (p Playlist) PeekNextTrack() int {
 	return -1 // At end of playlist
 }
 
+// PeekNextTrackMin is like PeekNextTrack but skips tracks with a known
+// duration shorter than min. If every remaining track is shorter, it falls
+// back to the first of them so auto-advance never skips everything. Tracks
+// shorter than broken are always skipped, returning -1 if nothing else is
+// left.
+func (p Playlist) PeekNextTrackMin(min, broken time.Duration) int {
+	next := p.PeekNextTrack()
+	if next < 0 || (min <= 0 && broken <= 0) {
+		return next
+	}
+	var upcoming []int
+	if p.shuffle {
+		upcoming = p.bag.upcoming()
+	} else {
+		for i := next; i < len(p.tracks); i++ {
+			upcoming = append(upcoming, i)
+		}
+	}
+	fallback := -1
+	for _, i := range upcoming {
+		d := p.tracks[i].Duration
+		if d == 0 || (d >= min && d >= broken) {
+			return i
+		}
+		if fallback < 0 && d >= broken {
+			fallback = i
+		}
+	}
+	return fallback
+}
+
 // PeekPrevTrack returns the index of the previous track without modifying state.
 // Returns -1 if at start of playlist or playlist is empty.
 func (p Playlist) PeekPrevTrack() int {
 	if len(p.tracks) == 0 {
 		return -1
 	}
+	if p.shuffle {
+		return p.bag.prev()
+	}
 	if p.current <= 0 {
 		return -1 // At start of playlist or nothing playing
 	}
@@ -488,5 +1156,8 @@ func (p Playlist) PeekPrevTrack() int {
 // Use this when playback ends at the end of the playlist.
 func (p *Playlist) ClearCurrent() {
 	p.current = -1
+	if p.shuffle {
+		p.bag = p.drawShuffle(-1)
+	}
 	p.updateTableRows()
 }
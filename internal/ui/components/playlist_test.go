@@ -0,0 +1,406 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestPlaylist returns a focused playlist of tracks with the given
+// titles, the cursor on the first row.
+func newTestPlaylist(titles ...string) Playlist {
+	p := NewPlaylist()
+	p.SetSize(80, 30)
+	p.Focus()
+	tracks := make([]Track, len(titles))
+	for i, title := range titles {
+		tracks[i] = Track{Path: "/vgm/" + title + ".vgm", Title: title}
+	}
+	p.AddTracks(tracks)
+	p.table.GotoTop()
+	return p
+}
+
+// playlistTitles returns the titles of the playlist's tracks in play order.
+func playlistTitles(p Playlist) []string {
+	var titles []string
+	for _, t := range p.Tracks() {
+		titles = append(titles, t.Title)
+	}
+	return titles
+}
+
+// rowTitles returns the titles of the playlist's table rows, top first.
+func rowTitles(p Playlist) []string {
+	var titles []string
+	for _, row := range p.table.Rows() {
+		titles = append(titles, strings.TrimSpace(row[1]))
+	}
+	return titles
+}
+
+// selectedTitle returns the title of the selected track, or "" if none.
+func selectedTitle(p Playlist) string {
+	if t := p.SelectedTrack(); t != nil {
+		return t.Title
+	}
+	return ""
+}
+
+func TestPlaylistRowTrack(t *testing.T) {
+	tests := []struct {
+		reversed bool
+		n        int
+		rows     []int // Track index of each row
+	}{
+		{false, 4, []int{0, 1, 2, 3}},
+		{true, 4, []int{3, 2, 1, 0}},
+		{true, 1, []int{0}},
+	}
+	for _, tt := range tests {
+		p := Playlist{reversed: tt.reversed}
+		for row, want := range tt.rows {
+			if got := p.rowTrack(row, tt.n); got != want {
+				t.Errorf("reversed %v, %d tracks: row %d shows track %d, want %d", tt.reversed, tt.n, row, got, want)
+			}
+			// The mapping is its own inverse
+			if back := p.rowTrack(want, tt.n); back != row {
+				t.Errorf("reversed %v, %d tracks: track %d is on row %d, want %d", tt.reversed, tt.n, want, back, row)
+			}
+		}
+	}
+}
+
+func TestPlaylistReversedSelection(t *testing.T) {
+	p := newTestPlaylist("a", "b", "c", "d")
+	p, _ = p.Update(keyMsg("j")) // Row 1: b
+	p.SetReversed(true)
+	if selectedTitle(p) != "b" || p.table.Cursor() != 2 {
+		t.Fatalf("after reversing, %q selected on row %d; want b on row 2", selectedTitle(p), p.table.Cursor())
+	}
+
+	// The top row holds the newest track
+	if got, want := rowTitles(p), []string{"d", "c", "b", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("reversed rows = %q, want %q", got, want)
+	}
+	p.table.GotoTop()
+	if p.SelectedIndex() != 3 || selectedTitle(p) != "d" {
+		t.Errorf("top row selects track %d (%q), want 3 (d)", p.SelectedIndex(), selectedTitle(p))
+	}
+	p, _ = p.Update(keyMsg("j"))
+	if selectedTitle(p) != "c" {
+		t.Errorf("moving down selects %q, want c", selectedTitle(p))
+	}
+
+	// The playing track is marked on its own row, not on the index's row
+	p.SetCurrentTrack(0)
+	for row, cells := range p.table.Rows() {
+		if playing := strings.HasPrefix(cells[0], ">"); playing != (row == 3) {
+			t.Errorf("row %d marked playing %v with track 0 playing", row, playing)
+		}
+	}
+
+	// Adding a track puts it on top without moving the selection
+	p.AddTrack(Track{Path: "/vgm/e.vgm", Title: "e"})
+	if selectedTitle(p) != "c" || p.table.Cursor() != 2 {
+		t.Errorf("after adding, %q selected on row %d; want c on row 2", selectedTitle(p), p.table.Cursor())
+	}
+	if got, want := rowTitles(p), []string{"e", "d", "c", "b", "a"}; !slices.Equal(got, want) {
+		t.Errorf("rows after adding = %q, want %q", got, want)
+	}
+
+	p.SetReversed(false)
+	if selectedTitle(p) != "c" || p.table.Cursor() != 2 {
+		t.Errorf("after unreversing, %q selected on row %d; want c on row 2", selectedTitle(p), p.table.Cursor())
+	}
+}
+
+func TestPlaylistReversedRemove(t *testing.T) {
+	tests := []struct {
+		cursor   RemoveCursor
+		row      int    // Row to remove from, top is the newest track
+		removed  string // Track removed
+		selected string // Track selected afterwards
+	}{
+		{RemoveCursorStay, 1, "c", "b"}, // The row now holds the older track
+		{RemoveCursorUp, 1, "c", "d"},   // The row above holds the newer one
+		{RemoveCursorStay, 3, "a", "b"}, // Removing the bottom row
+		{RemoveCursorUp, 0, "d", "c"},   // Nothing above the top row
+	}
+	for _, tt := range tests {
+		p := newTestPlaylist("a", "b", "c", "d")
+		p.SetRemoveCursor(tt.cursor)
+		p.SetReversed(true)
+		p.table.SetCursor(tt.row)
+		if selectedTitle(p) != tt.removed {
+			t.Fatalf("row %d selects %q, want %q", tt.row, selectedTitle(p), tt.removed)
+		}
+		p.RemoveSelected()
+
+		want := slices.DeleteFunc([]string{"a", "b", "c", "d"}, func(s string) bool { return s == tt.removed })
+		if got := playlistTitles(p); !slices.Equal(got, want) {
+			t.Errorf("removing %q left %q, want play order %q", tt.removed, got, want)
+		}
+		if selectedTitle(p) != tt.selected {
+			t.Errorf("cursor %v, removing row %d: %q selected, want %q", tt.cursor, tt.row, selectedTitle(p), tt.selected)
+		}
+	}
+}
+
+func TestPlaylistGoToIndex(t *testing.T) {
+	tests := []struct {
+		reversed bool
+		index    int
+		ok       bool
+		selected string // Track selected afterwards
+	}{
+		{false, 0, true, "a"},
+		{false, 3, true, "d"},
+		{true, 3, true, "d"},
+		{true, 1, true, "b"},
+		{false, -1, false, "c"}, // Out of range leaves the cursor alone
+		{false, 4, false, "c"},
+		{true, 4, false, "c"},
+	}
+	for _, tt := range tests {
+		p := newTestPlaylist("a", "b", "c", "d")
+		p.SetReversed(tt.reversed)
+		p.GoToIndex(2)
+		if ok := p.GoToIndex(tt.index); ok != tt.ok || selectedTitle(p) != tt.selected {
+			t.Errorf("reversed %v: GoToIndex(%d) = %v selecting %q, want %v selecting %q",
+				tt.reversed, tt.index, ok, selectedTitle(p), tt.ok, tt.selected)
+		}
+		if tt.ok && p.SelectedIndex() != tt.index {
+			t.Errorf("reversed %v: GoToIndex(%d) selected index %d", tt.reversed, tt.index, p.SelectedIndex())
+		}
+	}
+}
+
+func TestPlaylistGoToPrompt(t *testing.T) {
+	tests := []struct {
+		keys     []string
+		msg      any    // Message sent; nil for none
+		selected string // Track selected afterwards
+	}{
+		{[]string{"#", "2", "enter"}, PlaylistGoToMsg{Index: 1}, "b"},
+		{[]string{"#", "4", "p"}, PlaylistGoToMsg{Index: 3, Play: true}, "d"},
+		{[]string{"#", "9", "backspace", "3", "enter"}, PlaylistGoToMsg{Index: 2}, "c"},
+		{[]string{"#", "0", "enter"}, PlaylistGoToErrorMsg{Number: 0, Len: 4}, "a"},
+		{[]string{"#", "1", "2", "enter"}, PlaylistGoToErrorMsg{Number: 12, Len: 4}, "a"},
+		{[]string{"#", "enter"}, nil, "a"},
+		{[]string{"#", "3", "esc"}, nil, "a"},
+	}
+	for _, tt := range tests {
+		p := newTestPlaylist("a", "b", "c", "d")
+		var cmd tea.Cmd
+		for _, k := range tt.keys {
+			p, cmd = p.Update(keyMsg(k))
+		}
+		var msg any
+		if cmd != nil {
+			msg = cmd()
+		}
+		if msg != tt.msg {
+			t.Errorf("keys %q: sent %#v, want %#v", tt.keys, msg, tt.msg)
+		}
+		if p.GoingTo() || selectedTitle(p) != tt.selected {
+			t.Errorf("keys %q: prompt open %v, %q selected; want closed, %q selected",
+				tt.keys, p.GoingTo(), selectedTitle(p), tt.selected)
+		}
+	}
+}
+
+func TestPlaylistRemoveCursor(t *testing.T) {
+	tests := []struct {
+		cursor   RemoveCursor
+		index    int    // Track removed
+		selected string // Track selected afterwards
+	}{
+		{RemoveCursorStay, 2, "d"}, // The next track moves up into the row
+		{RemoveCursorUp, 2, "b"},
+		{RemoveCursorStay, 4, "d"}, // No next track: the new last one
+		{RemoveCursorUp, 4, "d"},
+		{RemoveCursorStay, 0, "b"},
+		{RemoveCursorUp, 0, "b"}, // Nothing above the first track
+	}
+	for _, tt := range tests {
+		p := newTestPlaylist("a", "b", "c", "d", "e")
+		p.SetRemoveCursor(tt.cursor)
+		p.SetCurrentTrack(3)
+		p.GoToIndex(tt.index)
+		p.RemoveSelected()
+		if selectedTitle(p) != tt.selected {
+			t.Errorf("cursor %v, removing track %d: %q selected, want %q", tt.cursor, tt.index, selectedTitle(p), tt.selected)
+		}
+		// The playing track is followed, whichever strategy is used
+		if playing := p.CurrentTrack(); playing == nil || playing.Title != "d" {
+			t.Errorf("cursor %v, removing track %d: playing %+v, want d", tt.cursor, tt.index, playing)
+		}
+	}
+
+	// Removing down to an empty playlist
+	for _, cursor := range []RemoveCursor{RemoveCursorStay, RemoveCursorUp} {
+		p := newTestPlaylist("a", "b")
+		p.SetRemoveCursor(cursor)
+		p.RemoveSelected()
+		p.RemoveSelected()
+		if p.Len() != 0 || p.SelectedTrack() != nil {
+			t.Errorf("cursor %v: %d tracks left, %q selected after removing all", cursor, p.Len(), selectedTitle(p))
+		}
+		p.RemoveSelected() // Nothing left to remove
+	}
+}
+
+func TestPlaylistToggleRemoveCursor(t *testing.T) {
+	p := newTestPlaylist("a")
+	for _, want := range []RemoveCursor{RemoveCursorUp, RemoveCursorStay, RemoveCursorUp} {
+		p, _ = p.Update(keyMsg("u"))
+		if p.RemoveCursor() != want {
+			t.Errorf("after toggling, RemoveCursor() = %v, want %v", p.RemoveCursor(), want)
+		}
+	}
+}
+
+func TestPlaylistPeekNextTrackMin(t *testing.T) {
+	const s = time.Second
+	tests := []struct {
+		name        string
+		durations   []time.Duration // 0 = unknown
+		current     int
+		min, broken time.Duration
+		want        int
+	}{
+		{"no minimum", []time.Duration{60 * s, 2 * s, 60 * s}, 0, 0, 0, 1},
+		{"next is long enough", []time.Duration{60 * s, 30 * s, 60 * s}, 0, 10 * s, 0, 1},
+		{"skips a jingle", []time.Duration{60 * s, 2 * s, 60 * s}, 0, 10 * s, 0, 2},
+		{"skips several", []time.Duration{60 * s, 2 * s, 5 * s, 3 * s, 60 * s}, 0, 10 * s, 0, 4},
+		{"exactly the minimum", []time.Duration{60 * s, 10 * s}, 0, 10 * s, 0, 1},
+		{"unknown duration plays", []time.Duration{60 * s, 2 * s, 0, 60 * s}, 0, 10 * s, 0, 2},
+		{"nothing playing", []time.Duration{2 * s, 60 * s}, -1, 10 * s, 0, 1},
+		{"all short falls back to the first", []time.Duration{60 * s, 2 * s, 5 * s, 3 * s}, 0, 10 * s, 0, 1},
+		{"at the end", []time.Duration{60 * s, 60 * s}, 1, 10 * s, 0, -1},
+		{"skips broken", []time.Duration{60 * s, 0, 60 * s}, 0, 0, s, 1},
+		{"broken is skipped", []time.Duration{60 * s, s / 2, 60 * s}, 0, 0, s, 2},
+		{"fallback skips broken", []time.Duration{60 * s, s / 2, 5 * s, 3 * s}, 0, 10 * s, s, 2},
+		{"only broken left", []time.Duration{60 * s, s / 2, s / 4}, 0, 10 * s, s, -1},
+	}
+	for _, tt := range tests {
+		p := newTestPlaylist()
+		for i, d := range tt.durations {
+			p.AddTrack(Track{Path: fmt.Sprintf("/vgm/%d.vgm", i), Duration: d})
+		}
+		p.SetCurrentTrack(tt.current)
+		if got := p.PeekNextTrackMin(tt.min, tt.broken); got != tt.want {
+			t.Errorf("%s: PeekNextTrackMin(%v, %v) = %d, want %d", tt.name, tt.min, tt.broken, got, tt.want)
+		}
+	}
+}
+
+func TestPlaylistPeekNextTrackMinShuffle(t *testing.T) {
+	// In shuffle play the upcoming tracks come from the bag; whichever is
+	// drawn, the only long one left is picked
+	p := newTestPlaylist()
+	for i, d := range []time.Duration{60 * time.Second, 2 * time.Second, 60 * time.Second, 3 * time.Second} {
+		p.AddTrack(Track{Path: fmt.Sprintf("/vgm/%d.vgm", i), Duration: d})
+	}
+	p.SetShuffleMode(ShuffleRandom)
+	p.SetCurrentTrack(0)
+	if got := p.PeekNextTrackMin(10*time.Second, 0); got != 2 {
+		t.Errorf("shuffled PeekNextTrackMin = %d, want 2", got)
+	}
+}
+
+func TestPlaylistSearchBackspace(t *testing.T) {
+	p := newTestPlaylist("Green Hill Zone", "ドラゴンの塔", "ドラム")
+	for _, k := range []string{"/", "ド", "ラ", "ム", "backspace"} {
+		p, _ = p.Update(keyMsg(k))
+	}
+	// Backspace removes the whole last character, not its last byte
+	if p.search != "ドラ" {
+		t.Errorf("search after backspace = %q, want %q", p.search, "ドラ")
+	}
+	if selectedTitle(p) != "ドラゴンの塔" {
+		t.Errorf("%q selected after backspace, want the first match", selectedTitle(p))
+	}
+	for range 3 {
+		p, _ = p.Update(keyMsg("backspace"))
+	}
+	if p.search != "" || !p.searching {
+		t.Errorf("search %q after clearing it (searching %v), want an empty open prompt", p.search, p.searching)
+	}
+}
+
+func TestPlaylistUndo(t *testing.T) {
+	tests := []struct {
+		name   string
+		remove func(p *Playlist)
+		left   []string
+	}{
+		{"remove", func(p *Playlist) { p.RemoveSelected() }, []string{"a", "b", "d", "e"}},
+		{"clear", func(p *Playlist) { p.Clear() }, nil},
+		{"remove above", func(p *Playlist) { p.RemoveAbove() }, []string{"c", "d", "e"}},
+		{"remove below", func(p *Playlist) { p.RemoveBelow() }, []string{"a", "b", "c"}},
+	}
+	all := []string{"a", "b", "c", "d", "e"}
+	for _, tt := range tests {
+		p := newTestPlaylist(all...)
+		p.SetCurrentTrack(3)
+		p.GoToIndex(2)
+		if p.CanUndo() {
+			t.Fatalf("%s: undo offered before any removal", tt.name)
+		}
+		tt.remove(&p)
+		if got := playlistTitles(p); !slices.Equal(got, tt.left) {
+			t.Fatalf("%s: left %q, want %q", tt.name, got, tt.left)
+		}
+		if !p.Undo("/vgm/d.vgm") {
+			t.Fatalf("%s: nothing to undo", tt.name)
+		}
+		if got := playlistTitles(p); !slices.Equal(got, all) {
+			t.Errorf("%s: undo restored %q, want %q", tt.name, got, all)
+		}
+		if p.CurrentIndex() != 3 {
+			t.Errorf("%s: playing track at %d after undo, want 3", tt.name, p.CurrentIndex())
+		}
+		if p.CanUndo() || p.Undo("") {
+			t.Errorf("%s: undo offered twice", tt.name)
+		}
+	}
+}
+
+func TestPlaylistUndoDroppedByChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(p *Playlist)
+	}{
+		{"add", func(p *Playlist) { p.AddTracks([]Track{{Path: "/vgm/new.vgm", Title: "new"}}) }},
+		{"insert after current", func(p *Playlist) { p.InsertAfterCurrent([]Track{{Path: "/vgm/new.vgm", Title: "new"}}) }},
+		{"move to top", func(p *Playlist) { p.GoToIndex(2); p.MoveToTop() }},
+		{"sort", func(p *Playlist) { p.CycleSort() }},
+	}
+	for _, tt := range tests {
+		p := newTestPlaylist("c", "b", "a")
+		p.GoToIndex(1)
+		p.RemoveSelected()
+		tt.change(&p)
+		want := playlistTitles(p)
+		if p.CanUndo() || p.Undo("") {
+			t.Errorf("%s: removal still undoable after the change", tt.name)
+		}
+		if got := playlistTitles(p); !slices.Equal(got, want) {
+			t.Errorf("%s: tracks %q after the undo key, want %q kept", tt.name, got, want)
+		}
+	}
+
+	// Adding nothing changes nothing, so the removal can still be undone
+	p := newTestPlaylist("a", "b")
+	p.RemoveSelected()
+	p.AddTracks(nil)
+	if !p.CanUndo() {
+		t.Error("adding no tracks dropped the undo")
+	}
+}
@@ -0,0 +1,56 @@
+package components
+
+// SetBroken marks whether the track at index failed to load, e.g.
+// because its file was moved or deleted. Broken tracks are marked "!".
+func (p *Playlist) SetBroken(index int, broken bool) {
+	if index < 0 || index >= len(p.tracks) || p.tracks[index].broken == broken {
+		return
+	}
+	p.tracks[index].broken = broken
+	p.updateTableRows()
+}
+
+// BrokenCount returns the number of tracks that failed to load.
+func (p Playlist) BrokenCount() int {
+	n := 0
+	for _, t := range p.tracks {
+		if t.broken {
+			n++
+		}
+	}
+	return n
+}
+
+// RemoveBroken removes every track that failed to load, keeping the
+// selected track selected if it stays. It returns how many were removed.
+func (p *Playlist) RemoveBroken() int {
+	n := p.BrokenCount()
+	if n == 0 {
+		return 0
+	}
+	selected := p.SelectedIndex()
+
+	p.saveUndo()
+	kept := make([]Track, 0, len(p.tracks)-n)
+	moved := make([]int, len(p.tracks)) // New index of each track, -1 if removed
+	for i, t := range p.tracks {
+		moved[i] = -1
+		if !t.broken {
+			moved[i] = len(kept)
+			kept = append(kept, t)
+		}
+	}
+	p.tracks = kept
+	if p.current >= 0 {
+		p.current = moved[p.current]
+	}
+	if p.shuffle {
+		p.bag = p.drawShuffle(p.current)
+	}
+	p.updateTableRows()
+
+	if selected >= 0 && moved[selected] >= 0 {
+		p.table.SetCursor(p.rowTrack(moved[selected], len(p.tracks)))
+	}
+	return n
+}
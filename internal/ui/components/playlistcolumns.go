@@ -0,0 +1,148 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// PlaylistColumn is a column the playlist table can show.
+type PlaylistColumn int
+
+const (
+	ColumnDuration PlaylistColumn = iota
+	ColumnTitle
+	ColumnGame
+	ColumnComposer
+	ColumnSystem
+)
+
+// playlistColumnSpecs describes each column: its name as used in the
+// config, its header, and its width as a fixed number of cells or a share
+// of the table's width in percent. The title column takes the width left
+// over, or the last column if the title isn't shown.
+var playlistColumnSpecs = []struct {
+	name    string
+	header  string
+	width   int
+	percent int
+}{
+	ColumnDuration: {"duration", "Duration", 8, 0},
+	ColumnTitle:    {"title", "Title", 0, 0},
+	ColumnGame:     {"game", "Game", 0, 30},
+	ColumnComposer: {"composer", "Composer", 0, 25},
+	ColumnSystem:   {"system", "System", 0, 20},
+}
+
+// minColumnWidth is the narrowest a sized column gets.
+const minColumnWidth = 10
+
+// playingMarkerWidth is the width of the "> " marking the playing track,
+// shown in the first column.
+const playingMarkerWidth = 2
+
+// DefaultPlaylistColumns returns the columns shown unless configured.
+func DefaultPlaylistColumns() []PlaylistColumn {
+	return []PlaylistColumn{ColumnDuration, ColumnTitle, ColumnGame}
+}
+
+// ParsePlaylistColumn returns the column with the given name.
+func ParsePlaylistColumn(name string) (PlaylistColumn, bool) {
+	for i, spec := range playlistColumnSpecs {
+		if strings.EqualFold(spec.name, name) {
+			return PlaylistColumn(i), true
+		}
+	}
+	return ColumnTitle, false
+}
+
+// SetColumns sets the columns of the playlist table, in order. An empty
+// list shows the default columns.
+func (p *Playlist) SetColumns(columns []PlaylistColumn) {
+	if len(columns) == 0 {
+		columns = DefaultPlaylistColumns()
+	}
+	p.columns = columns
+
+	// The table renders every column of each row, so the rows are cleared
+	// before the columns change and rebuilt after
+	p.table.SetRows(nil)
+	p.SetSize(p.width, p.height)
+}
+
+// tableColumns returns the table columns for the configured columns,
+// sized to fill width.
+func (p Playlist) tableColumns(width int) []table.Column {
+	flex := len(p.columns) - 1
+	columns := make([]table.Column, len(p.columns))
+	used := 0
+	for i, c := range p.columns {
+		spec := playlistColumnSpecs[c]
+		columns[i].Title = spec.header
+		if c == ColumnTitle {
+			flex = i
+		}
+		switch {
+		case spec.width > 0:
+			columns[i].Width = spec.width
+		case spec.percent > 0:
+			columns[i].Width = max(width*spec.percent/100, minColumnWidth)
+		}
+		if i == 0 {
+			columns[i].Width += playingMarkerWidth
+		}
+	}
+	for i, c := range columns {
+		if i != flex {
+			used += c.Width
+		}
+	}
+	columns[flex].Width = max(width-used, minColumnWidth)
+	return columns
+}
+
+// tableRow returns the table row of track i, each cell fitted to its
+// column by display width. The table measures cells by runewidth, which
+// can disagree with how the terminal draws full-width titles, so cells
+// come to it already the width of their column.
+func (p Playlist) tableRow(i int, track Track) table.Row {
+	row := make(table.Row, len(p.columns))
+	for j, c := range p.columns {
+		switch c {
+		case ColumnDuration:
+			row[j] = formatDuration(track.Duration)
+		case ColumnTitle:
+			row[j] = metadata.CleanTag(track.Title)
+			if p.favorites.Has(track.Path) {
+				row[j] = FavoriteMarker + " " + row[j]
+			}
+		case ColumnGame:
+			row[j] = metadata.CleanTag(track.GameName(p.gameLabel))
+		case ColumnComposer:
+			row[j] = metadata.CleanTag(track.Composer)
+		case ColumnSystem:
+			row[j] = metadata.CleanTag(track.System)
+		}
+	}
+
+	// Use play symbol as indicator (visible in all terminals), and mark
+	// tracks that failed to load
+	switch {
+	case i == p.current:
+		row[0] = "> " + row[0]
+	case track.broken:
+		row[0] = "! " + row[0]
+	default:
+		row[0] = "  " + row[0]
+	}
+
+	columns := p.table.Columns()
+	for j := range row {
+		if j < len(columns) && columns[j].Width > 0 {
+			row[j] = fitWidth(row[j], columns[j].Width)
+		}
+	}
+	return row
+}
@@ -0,0 +1,89 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CapturesKey reports whether the playlist takes msg ahead of the global
+// key bindings: while a position or search is typed, and for stepping
+// through search matches or clearing the search.
+func (p Playlist) CapturesKey(msg tea.KeyMsg) bool {
+	if p.goingTo || p.searching {
+		return true
+	}
+	return p.search != "" && (key.Matches(msg, p.keyMap.NextMatch) ||
+		key.Matches(msg, p.keyMap.PrevMatch) || msg.Type == tea.KeyEsc)
+}
+
+// Searching returns true while a search is being typed.
+func (p Playlist) Searching() bool {
+	return p.searching
+}
+
+// handleSearchKey handles a key press while a search is typed. The cursor
+// follows the first match from where the search started.
+func (p Playlist) handleSearchKey(msg tea.KeyMsg) (Playlist, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		p.searching = false
+		p.search = ""
+		p.GoToIndex(p.searchFrom)
+		return p, nil
+	case tea.KeyEnter:
+		p.searching = false
+		return p, nil
+	case tea.KeyBackspace:
+		if r := []rune(p.search); len(r) > 0 {
+			p.search = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		p.search += string(msg.Runes)
+	default:
+		return p, nil
+	}
+	if i := p.findMatch(p.searchFrom, 1); i >= 0 {
+		p.GoToIndex(i)
+	}
+	return p, nil
+}
+
+// startSearch opens the search prompt from the selected track.
+func (p *Playlist) startSearch() {
+	if len(p.tracks) == 0 {
+		return
+	}
+	p.searching = true
+	p.search = ""
+	p.searchFrom = max(p.SelectedIndex(), 0)
+}
+
+// stepMatch moves the cursor to the next match in direction dir (1 or -1)
+// from the selected track, wrapping around.
+func (p *Playlist) stepMatch(dir int) {
+	if i := p.findMatch(p.SelectedIndex()+dir, dir); i >= 0 {
+		p.GoToIndex(i)
+	}
+}
+
+// findMatch returns the index of the first track matching the search,
+// looking from index from in direction dir and wrapping around, or -1 if
+// none matches.
+func (p Playlist) findMatch(from, dir int) int {
+	n := len(p.tracks)
+	if p.search == "" || n == 0 {
+		return -1
+	}
+	needle := strings.ToLower(p.search)
+	for k := range n {
+		i := ((from+k*dir)%n + n) % n
+		t := p.tracks[i]
+		if strings.Contains(strings.ToLower(t.Title), needle) ||
+			strings.Contains(strings.ToLower(t.GameName(p.gameLabel)), needle) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,117 @@
+package components
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+)
+
+// PlaylistSort is an order the playlist can be sorted into.
+type PlaylistSort int
+
+const (
+	PlaylistUnsorted   PlaylistSort = iota // As arranged, no sort active
+	PlaylistByTitle                        // Title, ignoring case
+	PlaylistByGame                         // Game, then title
+	PlaylistByDuration                     // Shortest first
+	PlaylistByAdded                        // The order the tracks were added in
+)
+
+// playlistSortLabels describe the sort orders in the playlist title.
+var playlistSortLabels = []string{"", "by title", "by game", "by duration", "in added order"}
+
+// String returns the description of the sort order.
+func (s PlaylistSort) String() string {
+	if s < 0 || int(s) >= len(playlistSortLabels) {
+		return ""
+	}
+	return playlistSortLabels[s]
+}
+
+// Next returns the following sort order, wrapping around past the
+// unsorted state.
+func (s PlaylistSort) Next() PlaylistSort {
+	if s >= PlaylistByAdded {
+		return PlaylistByTitle
+	}
+	return s + 1
+}
+
+// compare orders tracks a and b, with label the source of game names.
+func (s PlaylistSort) compare(a, b Track, label library.GameLabel) int {
+	switch s {
+	case PlaylistByTitle:
+		return library.NaturalCompare(a.Title, b.Title)
+	case PlaylistByGame:
+		return cmp.Or(
+			library.NaturalCompare(a.GameName(label), b.GameName(label)),
+			library.NaturalCompare(a.Title, b.Title),
+		)
+	case PlaylistByDuration:
+		return cmp.Compare(a.Duration, b.Duration)
+	case PlaylistByAdded:
+		return cmp.Compare(a.added, b.added)
+	}
+	return 0
+}
+
+// CycleSort sorts the playlist into the next sort order and returns it.
+// The playing and selected tracks stay marked and selected.
+func (p *Playlist) CycleSort() PlaylistSort {
+	p.sort = p.sort.Next()
+	p.sortTracks()
+	return p.sort
+}
+
+// Sort returns the active sort order.
+func (p Playlist) Sort() PlaylistSort {
+	return p.sort
+}
+
+// sortTracks reorders the tracks into the active sort order, keeping the
+// playing track current, the shuffle order and the selection.
+func (p *Playlist) sortTracks() {
+	if p.sort == PlaylistUnsorted {
+		return
+	}
+	selected := p.SelectedIndex()
+
+	// order[k] is the old index of the track sorted to k
+	order := make([]int, len(p.tracks))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return p.sort.compare(p.tracks[a], p.tracks[b], p.gameLabel)
+	})
+
+	moved := make([]int, len(order)) // New index of each old index
+	tracks := make([]Track, len(order))
+	for k, i := range order {
+		tracks[k] = p.tracks[i]
+		moved[i] = k
+	}
+	p.tracks = tracks
+	p.undo = nil
+	if p.current >= 0 {
+		p.current = moved[p.current]
+	}
+	for k, i := range p.bag.order {
+		p.bag.order[k] = moved[i]
+	}
+
+	p.updateTableRows()
+	if selected >= 0 && selected < len(moved) {
+		p.table.SetCursor(p.rowTrack(moved[selected], len(p.tracks)))
+	}
+}
+
+// stampAdded numbers the tracks from index lo up to hi (exclusive), which
+// have just been added, for sorting back into the order they came in.
+func (p *Playlist) stampAdded(lo, hi int) {
+	for i := lo; i < hi; i++ {
+		p.added++
+		p.tracks[i].added = p.added
+	}
+}
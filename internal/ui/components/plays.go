@@ -0,0 +1,47 @@
+package components
+
+import (
+	"maps"
+	"time"
+)
+
+// PlayStat is how often a track has been played to the end and when it
+// last started.
+type PlayStat struct {
+	Count int       `json:"count"`
+	Last  time.Time `json:"last"`
+}
+
+// PlayStats records plays by track path.
+type PlayStats map[string]PlayStat
+
+// Started records that path started playing at the given time.
+func (s PlayStats) Started(path string, at time.Time) {
+	stat := s[path]
+	stat.Last = at
+	s[path] = stat
+}
+
+// Finished counts a play of path that reached the end of the track.
+func (s PlayStats) Finished(path string) {
+	stat := s[path]
+	stat.Count++
+	s[path] = stat
+}
+
+// Counts returns the number of full plays by track path, leaving out
+// tracks never played to the end.
+func (s PlayStats) Counts() map[string]int {
+	counts := make(map[string]int, len(s))
+	for path, stat := range s {
+		if stat.Count > 0 {
+			counts[path] = stat.Count
+		}
+	}
+	return counts
+}
+
+// Clone returns a copy of the play stats, e.g. to save in the background.
+func (s PlayStats) Clone() PlayStats {
+	return maps.Clone(s)
+}
@@ -0,0 +1,28 @@
+package components
+
+import "github.com/charmbracelet/lipgloss"
+
+// clampWidth returns percent of the available width, kept within lo..hi.
+func clampWidth(width, percent, lo, hi int) int {
+	return min(max(width*percent/100, lo), hi)
+}
+
+// renderPopup frames the body of an overlay popup: a bordered box of the
+// given width holding the body lines and a centered footer, with the title
+// centered above it.
+func (s PopupStyles) renderPopup(title, footer string, width int, body ...string) string {
+	footerLine := lipgloss.NewStyle().Width(width - 4).Align(lipgloss.Center).Render(s.Footer.Render(footer))
+	innerContent := lipgloss.JoinVertical(lipgloss.Left, append(body, "", footerLine)...)
+
+	box := s.Border.
+		Padding(0, 1).
+		Width(width).
+		Render(innerContent)
+
+	titleLine := lipgloss.NewStyle().
+		Width(width).
+		Align(lipgloss.Center).
+		Render(s.Title.Render(title))
+
+	return lipgloss.JoinVertical(lipgloss.Center, titleLine, box)
+}
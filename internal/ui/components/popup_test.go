@@ -0,0 +1,16 @@
+package components
+
+import "testing"
+
+func TestClampWidth(t *testing.T) {
+	tests := []struct{ width, want int }{
+		{0, 40},    // Below the minimum
+		{100, 60},  // 60% of the width
+		{200, 100}, // Above the maximum
+	}
+	for _, tt := range tests {
+		if got := clampWidth(tt.width, 60, 40, 100); got != tt.want {
+			t.Errorf("clampWidth(%d, 60, 40, 100) = %d, want %d", tt.width, got, tt.want)
+		}
+	}
+}
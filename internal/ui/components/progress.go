@@ -11,9 +11,10 @@ import (
 
 // ProgressBar displays a progress bar with time display.
 type ProgressBar struct {
-	elapsed  time.Duration
-	duration time.Duration
-	width    int
+	elapsed   time.Duration
+	duration  time.Duration
+	loopPoint time.Duration // Where the loop starts (0 = no marker)
+	width     int
 
 	// Styles
 	TimeStyle     lipgloss.Style
@@ -21,6 +22,13 @@ type ProgressBar struct {
 	EmptyStyle    lipgloss.Style
 	FilledChar    rune
 	EmptyChar     rune
+	LoopChar      rune // Marks the loop point
+
+	// Colors the filled cells shade between in the gradient style
+	GradientStart lipgloss.Color
+	GradientEnd   lipgloss.Color
+
+	barStyle ProgressStyle
 }
 
 // NewProgressBar creates a new progress bar with default styling.
@@ -29,6 +37,7 @@ func NewProgressBar() ProgressBar {
 		width:       40,
 		FilledChar:  '\u2588', // Full block
 		EmptyChar:   '\u2591', // Light shade
+		LoopChar:    '\u2503', // Heavy vertical
 		TimeStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#A0A0A0")),
 		FilledStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#7571F9")),
 		EmptyStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("#606060")),
@@ -50,8 +59,15 @@ func (p *ProgressBar) SetDuration(d time.Duration) {
 	p.duration = d
 }
 
-// View renders the progress bar with time display.
-// Format: "01:23 [=====>----] 03:45"
+// SetLoopPoint sets where the track's loop starts, marked on the bar.
+// Zero shows no marker.
+func (p *ProgressBar) SetLoopPoint(d time.Duration) {
+	p.loopPoint = d
+}
+
+// View renders the progress bar with time display, marking the loop point
+// if one is set.
+// Format: "01:23 [=====>--|-] 03:45"
 func (p ProgressBar) View() string {
 	// Calculate percentage
 	var percent float64
@@ -76,12 +92,13 @@ func (p ProgressBar) View() string {
 	}
 
 	filledWidth := int(float64(barWidth) * percent)
-	emptyWidth := barWidth - filledWidth
-
-	filled := p.FilledStyle.Render(strings.Repeat(string(p.FilledChar), filledWidth))
-	empty := p.EmptyStyle.Render(strings.Repeat(string(p.EmptyChar), emptyWidth))
+	cells := []rune(strings.Repeat(string(p.FilledChar), filledWidth) +
+		strings.Repeat(string(p.EmptyChar), barWidth-filledWidth))
+	if p.loopPoint > 0 && p.loopPoint < p.duration {
+		cells[int(float64(barWidth)*float64(p.loopPoint)/float64(p.duration))] = p.LoopChar
+	}
 
-	bar := filled + empty
+	bar := p.renderCells(cells, filledWidth)
 
 	return fmt.Sprintf("%s %s %s",
 		p.TimeStyle.Render(elapsedStr),
@@ -110,12 +127,10 @@ func (p ProgressBar) ViewAs(percent float64, elapsed, duration time.Duration) st
 	}
 
 	filledWidth := int(float64(barWidth) * percent)
-	emptyWidth := barWidth - filledWidth
-
-	filled := p.FilledStyle.Render(strings.Repeat(string(p.FilledChar), filledWidth))
-	empty := p.EmptyStyle.Render(strings.Repeat(string(p.EmptyChar), emptyWidth))
+	cells := []rune(strings.Repeat(string(p.FilledChar), filledWidth) +
+		strings.Repeat(string(p.EmptyChar), barWidth-filledWidth))
 
-	bar := filled + empty
+	bar := p.renderCells(cells, filledWidth)
 
 	return fmt.Sprintf("%s %s %s",
 		p.TimeStyle.Render(elapsedStr),
@@ -124,6 +139,26 @@ func (p ProgressBar) ViewAs(percent float64, elapsed, duration time.Duration) st
 	)
 }
 
+// LevelView renders a short labelled bar in the progress bar's style,
+// filled to level out of maxLevel and followed by level as a percentage.
+// Format: "Vol ██████░░ 140%"
+func (p ProgressBar) LevelView(label string, level, maxLevel float64, width int) string {
+	fraction := 0.0
+	if maxLevel > 0 {
+		fraction = max(0, min(level/maxLevel, 1))
+	}
+
+	filledWidth := int(float64(width)*fraction + 0.5)
+	cells := []rune(strings.Repeat(string(p.FilledChar), filledWidth) +
+		strings.Repeat(string(p.EmptyChar), width-filledWidth))
+
+	return fmt.Sprintf("%s %s %s",
+		p.TimeStyle.Render(label),
+		p.renderCells(cells, filledWidth),
+		p.TimeStyle.Render(fmt.Sprintf("%d%%", int(level*100+0.5))),
+	)
+}
+
 // formatDuration formats a duration as MM:SS.
 func formatDuration(d time.Duration) string {
 	if d < 0 {
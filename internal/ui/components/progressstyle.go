@@ -0,0 +1,95 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ProgressStyle is how the progress bar draws its cells.
+type ProgressStyle int
+
+const (
+	ProgressBlocks   ProgressStyle = iota // Solid and shaded blocks
+	ProgressASCII                         // "=" and "-", for fonts without block characters
+	ProgressGradient                      // Blocks shading from GradientStart to GradientEnd
+)
+
+// progressStyleNames are the config names of the progress styles.
+var progressStyleNames = []string{
+	ProgressBlocks:   "blocks",
+	ProgressASCII:    "ascii",
+	ProgressGradient: "gradient",
+}
+
+// String returns the style's name as used in the config.
+func (s ProgressStyle) String() string {
+	return progressStyleNames[s]
+}
+
+// ParseProgressStyle returns the style with the given name. An empty name
+// is the default block style.
+func ParseProgressStyle(name string) (ProgressStyle, bool) {
+	if name == "" {
+		return ProgressBlocks, true
+	}
+	for i, n := range progressStyleNames {
+		if strings.EqualFold(n, name) {
+			return ProgressStyle(i), true
+		}
+	}
+	return ProgressBlocks, false
+}
+
+// SetBarStyle sets the characters and coloring the bar is drawn with.
+func (p *ProgressBar) SetBarStyle(style ProgressStyle) {
+	p.barStyle = style
+	switch style {
+	case ProgressASCII:
+		p.FilledChar, p.EmptyChar, p.LoopChar = '=', '-', '|'
+	default:
+		p.FilledChar, p.EmptyChar, p.LoopChar = '█', '░', '┃'
+	}
+}
+
+// renderCells styles a bar's cells, the first filled of them in the filled
+// style, or shading along the gradient over the whole bar.
+func (p ProgressBar) renderCells(cells []rune, filled int) string {
+	empty := p.EmptyStyle.Render(string(cells[filled:]))
+	if p.barStyle != ProgressGradient || filled == 0 {
+		return p.FilledStyle.Render(string(cells[:filled])) + empty
+	}
+
+	from, okFrom := parseHexColor(p.GradientStart)
+	to, okTo := parseHexColor(p.GradientEnd)
+	if !okFrom || !okTo {
+		// Colors that can't be blended fall back to the plain style
+		return p.FilledStyle.Render(string(cells[:filled])) + empty
+	}
+
+	var b strings.Builder
+	for i, c := range cells[:filled] {
+		t := 0.0
+		if len(cells) > 1 {
+			t = float64(i) / float64(len(cells)-1)
+		}
+		var mixed [3]int
+		for j := range mixed {
+			mixed[j] = from[j] + int(math.Round(float64(to[j]-from[j])*t))
+		}
+		color := lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", mixed[0], mixed[1], mixed[2]))
+		b.WriteString(p.FilledStyle.Foreground(color).Render(string(c)))
+	}
+	return b.String() + empty
+}
+
+// parseHexColor returns the red, green and blue of a "#RRGGBB" color.
+func parseHexColor(c lipgloss.Color) (rgb [3]int, ok bool) {
+	if len(c) != 7 {
+		return rgb, false
+	}
+	_, err := fmt.Sscanf(string(c), "#%02x%02x%02x", &rgb[0], &rgb[1], &rgb[2])
+	return rgb, err == nil
+}
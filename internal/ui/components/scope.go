@@ -0,0 +1,120 @@
+// Package components provides UI components for vgmtui.
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// brailleDots maps a dot position [x][y] within a 2x4 braille cell to its bit.
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// Scope displays an oscilloscope of recently played audio using braille
+// characters, giving a 2x4 dot resolution per terminal cell.
+type Scope struct {
+	samples []int16 // Mono samples, oldest first
+	width   int
+	height  int
+
+	// Styles
+	Style lipgloss.Style
+
+	// ASCII draws the trace with "*" instead of braille, at one point per
+	// cell
+	ASCII bool
+}
+
+// NewScope creates a new oscilloscope with default styling.
+func NewScope() Scope {
+	return Scope{
+		width:  40,
+		height: 3,
+		Style:  lipgloss.NewStyle().Foreground(lipgloss.Color("#7571F9")),
+	}
+}
+
+// SetSize sets the size of the oscilloscope in cells.
+func (s *Scope) SetSize(width, height int) {
+	s.width = width
+	s.height = height
+}
+
+// SetSamples sets the audio to display from interleaved stereo frames
+// (L, R, L, R, ...). Passing nil shows a flat line.
+func (s *Scope) SetSamples(stereo []int16) {
+	mono := make([]int16, len(stereo)/2)
+	for i := range mono {
+		mono[i] = int16((int32(stereo[i*2]) + int32(stereo[i*2+1])) / 2)
+	}
+	s.samples = mono
+}
+
+// trigger returns the index of the first rising zero crossing in the first
+// half of the samples, so that periodic waveforms stay still between frames.
+func (s Scope) trigger() int {
+	for i := 1; i < len(s.samples)/2; i++ {
+		if s.samples[i-1] < 0 && s.samples[i] >= 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// View renders the oscilloscope.
+func (s Scope) View() string {
+	if s.width < 1 || s.height < 1 {
+		return ""
+	}
+
+	cols := s.width * 2
+	rows := s.height * 4
+	grid := make([][]rune, s.height)
+	for i := range grid {
+		grid[i] = make([]rune, s.width)
+	}
+
+	// Show half the buffer starting at the trigger point
+	start := s.trigger()
+	window := len(s.samples) / 2
+
+	prevY := -1
+	for x := 0; x < cols; x++ {
+		var v int32
+		if window > 0 {
+			v = int32(s.samples[start+x*window/cols])
+		}
+		// Map -32768..32767 to rows-1..0 (positive values at the top)
+		y := (rows - 1) - int((v+32768)*int32(rows-1)/65535)
+
+		// Connect to the previous column so steep edges stay continuous
+		lo, hi := y, y
+		if prevY >= 0 {
+			lo, hi = min(y, prevY), max(y, prevY)
+		}
+		for dy := lo; dy <= hi; dy++ {
+			grid[dy/4][x/2] |= brailleDots[x%2][dy%4]
+		}
+		prevY = y
+	}
+
+	lines := make([]string, s.height)
+	for i, row := range grid {
+		var b strings.Builder
+		for _, dots := range row {
+			switch {
+			case !s.ASCII:
+				b.WriteRune(0x2800 + dots)
+			case dots != 0:
+				b.WriteByte('*')
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		lines[i] = s.Style.Render(b.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,167 @@
+package components
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ShuffleMode is how shuffle play draws its order.
+type ShuffleMode int
+
+const (
+	ShuffleOff      ShuffleMode = iota
+	ShuffleRandom               // Every order equally likely
+	ShuffleWeighted             // Favorites and less played tracks first, recent plays last
+)
+
+// Next returns the following shuffle mode, wrapping around to off.
+func (s ShuffleMode) Next() ShuffleMode {
+	return (s + 1) % (ShuffleWeighted + 1)
+}
+
+// recentPlayWindow is how long after a play smart shuffle holds a track
+// back, less so as the time passes.
+const recentPlayWindow = 24 * time.Hour
+
+// shuffleBag is a random play order over a playlist's track indices. Each
+// track plays once per round; the tracks up to and including pos have
+// played this round, and once the last one starts a new round is drawn.
+type shuffleBag struct {
+	order []int
+	pos   int // Position of the current track in order (-1 if none)
+}
+
+// newShuffleBag returns a new round over n tracks. If first is a track
+// index, it is the round's current track.
+func newShuffleBag(n, first int) shuffleBag {
+	b := shuffleBag{order: rand.Perm(n), pos: -1}
+	b.start(first)
+	return b
+}
+
+// newWeightedShuffleBag returns a new round over tracks with the given
+// weights, heavier tracks tending to be drawn earlier. If first is a track
+// index, it is the round's current track.
+func newWeightedShuffleBag(weights []float64, first int) shuffleBag {
+	// Sorting by u^(1/w) for uniform random u draws without replacement
+	// in proportion to weight (Efraimidis and Spirakis)
+	keys := make([]float64, len(weights))
+	for i, w := range weights {
+		keys[i] = math.Pow(rand.Float64(), 1/w)
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, c int) bool { return keys[order[a]] > keys[order[c]] })
+
+	b := shuffleBag{order: order, pos: -1}
+	b.start(first)
+	return b
+}
+
+// start makes track first, if it is a track index, the current track at
+// the start of the round.
+func (b *shuffleBag) start(first int) {
+	if first < 0 || first >= len(b.order) {
+		return
+	}
+	j := slices.Index(b.order, first)
+	b.order[0], b.order[j] = b.order[j], b.order[0]
+	b.pos = 0
+}
+
+// next returns the track to play after the current one, or -1 if none.
+func (b shuffleBag) next() int {
+	if b.pos+1 < len(b.order) {
+		return b.order[b.pos+1]
+	}
+	return -1
+}
+
+// prev returns the track played before the current one this round, or -1.
+func (b shuffleBag) prev() int {
+	if b.pos > 0 {
+		return b.order[b.pos-1]
+	}
+	return -1
+}
+
+// upcoming returns the tracks still to play this round, in order.
+func (b shuffleBag) upcoming() []int {
+	return b.order[b.pos+1:]
+}
+
+// play records track i as the current one. A track picked out of turn is
+// moved up to play now; going back to one already played rewinds to it.
+// It returns true once every track has played, so the next round should
+// be drawn now to always have a next track.
+func (b *shuffleBag) play(i int) bool {
+	j := slices.Index(b.order, i)
+	if j < 0 {
+		return false
+	}
+	if j > b.pos {
+		b.pos++
+		b.order[b.pos], b.order[j] = b.order[j], b.order[b.pos]
+	} else {
+		b.pos = j
+	}
+	return b.pos == len(b.order)-1 && len(b.order) > 1
+}
+
+// playNext moves track i up to play after the current one.
+func (b *shuffleBag) playNext(i int) {
+	j := slices.Index(b.order, i)
+	if j < 0 || j == b.pos {
+		return
+	}
+	b.order = slices.Delete(b.order, j, j+1)
+	if j < b.pos {
+		b.pos--
+	}
+	b.order = slices.Insert(b.order, b.pos+1, i)
+}
+
+// insert makes room for n tracks inserted into the playlist at index at
+// and queues them to play next, in order.
+func (b *shuffleBag) insert(at, n int) {
+	for k, i := range b.order {
+		if i >= at {
+			b.order[k] = i + n
+		}
+	}
+	for k := range n {
+		b.order = slices.Insert(b.order, b.pos+1+k, at+k)
+	}
+}
+
+// grow adds the tracks appended to the playlist, now n long, at random
+// places among those still to play.
+func (b *shuffleBag) grow(n int) {
+	for i := len(b.order); i < n; i++ {
+		at := b.pos + 1 + rand.Intn(len(b.order)-b.pos)
+		b.order = slices.Insert(b.order, at, i)
+	}
+}
+
+// remove drops track i, which was removed from the playlist, and shifts
+// the indices of the tracks after it down.
+func (b *shuffleBag) remove(i int) {
+	j := slices.Index(b.order, i)
+	if j < 0 {
+		return
+	}
+	b.order = slices.Delete(b.order, j, j+1)
+	if j <= b.pos {
+		b.pos--
+	}
+	for k, t := range b.order {
+		if t > i {
+			b.order[k] = t - 1
+		}
+	}
+}
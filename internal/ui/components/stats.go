@@ -0,0 +1,221 @@
+// Package components provides UI components for vgmtui.
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+)
+
+// topComposers is how many composers the stats popup lists.
+const topComposers = 10
+
+// topPlayed is how many of the most played tracks the stats popup lists.
+const topPlayed = 10
+
+// StatsPopupKeyMap defines key bindings for the library stats popup.
+type StatsPopupKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
+	Close    key.Binding
+}
+
+// DefaultStatsPopupKeyMap returns the default library stats popup key bindings.
+func DefaultStatsPopupKeyMap() StatsPopupKeyMap {
+	return StatsPopupKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "scroll up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "scroll down"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup", "ctrl+u"),
+			key.WithHelp("pgup", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+d"),
+			key.WithHelp("pgdn", "page down"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("S", "esc", "enter", "q"),
+			key.WithHelp("S/esc", "close"),
+		),
+	}
+}
+
+// StatsPopup is an overlay summarizing the library: totals, tracks per
+// system and chip type, and the top composers.
+type StatsPopup struct {
+	viewport viewport.Model
+	visible  bool
+	width    int
+	height   int
+
+	stats library.Stats
+	root  string
+
+	keyMap StatsPopupKeyMap
+
+	// Styles
+	styles PopupStyles
+}
+
+// NewStatsPopup creates a new library stats popup.
+func NewStatsPopup() StatsPopup {
+	vp := viewport.New(50, 20)
+	vp.MouseWheelEnabled = true
+
+	return StatsPopup{
+		viewport: vp,
+		width:    60,
+		height:   24,
+		keyMap:   DefaultStatsPopupKeyMap(),
+		styles:   DefaultPopupStyles(),
+	}
+}
+
+// Update handles messages for the stats popup.
+func (s StatsPopup) Update(msg tea.Msg) (StatsPopup, tea.Cmd) {
+	if !s.visible {
+		return s, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, s.keyMap.Close):
+			s.visible = false
+			return s, nil
+		case key.Matches(msg, s.keyMap.Up):
+			s.viewport.ScrollUp(1)
+		case key.Matches(msg, s.keyMap.Down):
+			s.viewport.ScrollDown(1)
+		case key.Matches(msg, s.keyMap.PageUp):
+			s.viewport.PageUp()
+		case key.Matches(msg, s.keyMap.PageDown):
+			s.viewport.PageDown()
+		}
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	s.viewport, cmd = s.viewport.Update(msg)
+	return s, cmd
+}
+
+// View renders the stats popup as an overlay.
+func (s StatsPopup) View() string {
+	if !s.visible {
+		return ""
+	}
+
+	return s.styles.renderPopup("Library Statistics", "Press S or Esc to close", s.popupWidth(), s.viewport.View())
+}
+
+// popupWidth returns the width of the popup box for the current size.
+func (s StatsPopup) popupWidth() int {
+	return clampWidth(s.width, 70, 45, 60)
+}
+
+// buildContent creates the stats text content.
+func (s StatsPopup) buildContent() string {
+	st := s.stats
+	var b strings.Builder
+
+	if st.Tracks == 0 {
+		return s.styles.Desc.Render("The library is empty.")
+	}
+
+	nameWidth := s.popupWidth() - 14
+	addRow := func(name string, value string) {
+		b.WriteString(s.styles.Desc.Render(fmt.Sprintf("%-*s", nameWidth, truncateEnd(name, nameWidth))))
+		b.WriteString(s.styles.Key.Render(fmt.Sprintf("%8s", value)))
+		b.WriteString("\n")
+	}
+	addSection := func(title string, counts []library.Count, limit int) {
+		if len(counts) == 0 {
+			return
+		}
+		b.WriteString("\n")
+		b.WriteString(s.styles.Category.Render(title))
+		b.WriteString("\n")
+		for i, c := range counts {
+			if limit > 0 && i == limit {
+				break
+			}
+			addRow(c.Name, fmt.Sprintf("%d", c.Count))
+		}
+	}
+
+	b.WriteString(s.styles.Footer.Render(s.root))
+	b.WriteString("\n\n")
+	addRow("Tracks", fmt.Sprintf("%d", st.Tracks))
+	addRow("Games", fmt.Sprintf("%d", st.Games))
+	addRow("Systems", fmt.Sprintf("%d", len(st.Systems)))
+	addRow("Total playtime", formatPlaytime(st.Playtime))
+
+	addSection("Tracks per system", st.Systems, 0)
+	addSection("Tracks per chip", st.Chips, 0)
+	addSection(fmt.Sprintf("Top composers (%d tagged)", len(st.Composers)), st.Composers, topComposers)
+	addSection("Most played", st.Played, topPlayed)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatPlaytime formats a long duration as hours and minutes.
+func formatPlaytime(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// SetSize sets the available size for the stats popup.
+func (s *StatsPopup) SetSize(width, height int) {
+	s.width = width
+	s.height = height
+
+	contentHeight := height * 80 / 100
+	if contentHeight < 15 {
+		contentHeight = 15
+	}
+	if contentHeight > 30 {
+		contentHeight = 30
+	}
+
+	s.viewport.Width = s.popupWidth() - 4
+	s.viewport.Height = contentHeight - 4
+}
+
+// SetStyles sets the popup styles.
+func (s *StatsPopup) SetStyles(styles PopupStyles) {
+	s.styles = styles
+}
+
+// Show makes the stats popup visible with the given statistics for the
+// library rooted at root.
+func (s *StatsPopup) Show(stats library.Stats, root string) {
+	s.stats = stats
+	s.root = root
+	s.visible = true
+	s.viewport.SetContent(s.buildContent())
+	s.viewport.GotoTop()
+}
+
+// Hide makes the stats popup invisible.
+func (s *StatsPopup) Hide() {
+	s.visible = false
+}
+
+// Visible returns whether the stats popup is visible.
+func (s StatsPopup) Visible() bool {
+	return s.visible
+}
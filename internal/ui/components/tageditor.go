@@ -0,0 +1,213 @@
+package components
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// EditTagsMsg is sent to open the tag editor on the file at Path.
+type EditTagsMsg struct {
+	Path string
+}
+
+// TagsEditedMsg is sent when the tag editor is confirmed, with the tags to
+// write to the file and whether they are its original-language ones.
+type TagsEditedMsg struct {
+	Path     string
+	Tags     metadata.Tags
+	Original bool
+}
+
+// tagFieldNames labels the editor's fields, in the order they are edited.
+var tagFieldNames = []string{"Title", "Game", "System", "Composer"}
+
+// TagEditorKeyMap defines key bindings for the tag editor. Printable keys
+// are typed into the field being edited, so only non-printable keys are
+// bound.
+type TagEditorKeyMap struct {
+	Next   key.Binding
+	Prev   key.Binding
+	Clear  key.Binding // Empty the field being edited
+	Save   key.Binding
+	Cancel key.Binding
+}
+
+// DefaultTagEditorKeyMap returns the default tag editor key bindings.
+func DefaultTagEditorKeyMap() TagEditorKeyMap {
+	return TagEditorKeyMap{
+		Next: key.NewBinding(
+			key.WithKeys("tab", "down"),
+			key.WithHelp("tab", "next field"),
+		),
+		Prev: key.NewBinding(
+			key.WithKeys("shift+tab", "up"),
+			key.WithHelp("shift+tab", "previous field"),
+		),
+		Clear: key.NewBinding(
+			key.WithKeys("ctrl+u"),
+			key.WithHelp("ctrl+u", "clear field"),
+		),
+		Save: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "save"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+// TagEditor is an overlay for editing the title, game, system and composer
+// stored in a file's tags.
+type TagEditor struct {
+	path     string
+	original bool     // Editing the original-language tags
+	values   []string // One per tagFieldNames
+	field    int      // Index of the field being edited
+	visible  bool
+	width    int
+	height   int
+
+	keyMap TagEditorKeyMap
+
+	// Styles
+	styles PopupStyles
+}
+
+// NewTagEditor creates a new tag editor.
+func NewTagEditor() TagEditor {
+	return TagEditor{
+		width:  60,
+		height: 24,
+		keyMap: DefaultTagEditorKeyMap(),
+		styles: DefaultPopupStyles(),
+	}
+}
+
+// Update handles messages for the tag editor.
+func (e TagEditor) Update(msg tea.Msg) (TagEditor, tea.Cmd) {
+	if !e.visible {
+		return e, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return e, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, e.keyMap.Cancel):
+		e.visible = false
+	case key.Matches(keyMsg, e.keyMap.Save):
+		e.visible = false
+		edited := TagsEditedMsg{
+			Path: e.path,
+			Tags: metadata.Tags{
+				Title:    strings.TrimSpace(e.values[0]),
+				Game:     strings.TrimSpace(e.values[1]),
+				System:   strings.TrimSpace(e.values[2]),
+				Composer: strings.TrimSpace(e.values[3]),
+			},
+			Original: e.original,
+		}
+		return e, func() tea.Msg { return edited }
+	case key.Matches(keyMsg, e.keyMap.Next):
+		e.field = (e.field + 1) % len(e.values)
+	case key.Matches(keyMsg, e.keyMap.Prev):
+		e.field = (e.field + len(e.values) - 1) % len(e.values)
+	case key.Matches(keyMsg, e.keyMap.Clear):
+		e.values[e.field] = ""
+	case keyMsg.Type == tea.KeyBackspace:
+		if r := []rune(e.values[e.field]); len(r) > 0 {
+			e.values[e.field] = string(r[:len(r)-1])
+		}
+	case keyMsg.Type == tea.KeyRunes, keyMsg.Type == tea.KeySpace:
+		e.values[e.field] += string(keyMsg.Runes)
+	}
+	return e, nil
+}
+
+// View renders the tag editor as an overlay.
+func (e TagEditor) View() string {
+	if !e.visible {
+		return ""
+	}
+
+	popupWidth := e.popupWidth()
+	width := popupWidth - 4
+
+	language := "English"
+	if e.original {
+		language = "original language"
+	}
+	file := e.styles.Desc.Render(truncateStart(filepath.Base(e.path), width))
+	note := e.styles.Desc.Render(truncateEnd("Tags in "+language, width))
+
+	return e.styles.renderPopup("Edit Tags", "Tab: next field  Enter: save  Esc: cancel", popupWidth,
+		file,
+		note,
+		"",
+		e.buildContent(width),
+	)
+}
+
+// popupWidth returns the width of the popup box for the current size.
+func (e TagEditor) popupWidth() int {
+	return clampWidth(e.width, 70, 40, 72)
+}
+
+// buildContent renders one line per field as "label  value", the field
+// being edited marked and showing the end of its value as it is typed.
+func (e TagEditor) buildContent(width int) string {
+	labelWidth := 10
+	valueWidth := width - labelWidth - 3
+
+	lines := make([]string, len(e.values))
+	for i, v := range e.values {
+		label := fmt.Sprintf("%-*s", labelWidth, tagFieldNames[i]+":")
+		if i == e.field {
+			lines[i] = e.styles.Key.Render("> "+label) + " " + truncateStart(v+"_", valueWidth)
+		} else {
+			lines[i] = e.styles.Desc.Render("  "+label) + " " + truncateEnd(v, valueWidth)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SetSize sets the available size for the tag editor.
+func (e *TagEditor) SetSize(width, height int) {
+	e.width = width
+	e.height = height
+}
+
+// SetStyles sets the popup styles.
+func (e *TagEditor) SetStyles(styles PopupStyles) {
+	e.styles = styles
+}
+
+// Show opens the editor on the tags of the file at path, editing the
+// original-language ones if original is set.
+func (e *TagEditor) Show(path string, tags metadata.Tags, original bool) {
+	e.path = path
+	e.original = original
+	e.values = []string{tags.Title, tags.Game, tags.System, tags.Composer}
+	e.field = 0
+	e.visible = true
+}
+
+// Hide makes the tag editor invisible.
+func (e *TagEditor) Hide() {
+	e.visible = false
+}
+
+// Visible returns whether the tag editor is visible.
+func (e TagEditor) Visible() bool {
+	return e.visible
+}
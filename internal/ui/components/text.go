@@ -0,0 +1,88 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ellipsis marks where text was cut.
+const ellipsis = "..."
+
+// truncateEnd keeps the start of s that fits in width cells with "..."
+// after it. Wide characters count as two cells and are never split.
+// Widths of 3 or less leave no room for the "...", so s is just cut.
+func truncateEnd(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	if width <= len(ellipsis) {
+		return string(fitStart([]rune(s), width))
+	}
+	return string(fitStart([]rune(s), width-len(ellipsis))) + ellipsis
+}
+
+// fitStart returns the longest start of r that fits in width cells.
+func fitStart(r []rune, width int) []rune {
+	keep := 0
+	for used := 0; keep < len(r); keep++ {
+		w := lipgloss.Width(string(r[keep]))
+		if used+w > width {
+			break
+		}
+		used += w
+	}
+	return r[:keep]
+}
+
+// truncateStart keeps the end of s that fits in width cells with "..."
+// before it, e.g. to show where a long name ends. Like truncateEnd, it
+// just cuts s at widths of 3 or less.
+func truncateStart(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	if width <= len(ellipsis) {
+		return string(fitEnd([]rune(s), width))
+	}
+	return ellipsis + string(fitEnd([]rune(s), width-len(ellipsis)))
+}
+
+// fitEnd returns the longest end of r that fits in width cells.
+func fitEnd(r []rune, width int) []rune {
+	start := len(r)
+	for used := 0; start > 0; start-- {
+		w := lipgloss.Width(string(r[start-1]))
+		if used+w > width {
+			break
+		}
+		used += w
+	}
+	return r[start:]
+}
+
+// wrapWidth splits s into lines of at most width cells.
+func wrapWidth(s string, width int) []string {
+	var lines []string
+	line, used := []rune{}, 0
+	for _, c := range s {
+		w := lipgloss.Width(string(c))
+		if used+w > width && len(line) > 0 {
+			lines = append(lines, string(line))
+			line, used = line[:0:0], 0
+		}
+		line = append(line, c)
+		used += w
+	}
+	return append(lines, string(line))
+}
+
+// fitWidth cuts s to width cells and pads it with spaces to exactly
+// width, so cells with wide characters still line up in columns.
+func fitWidth(s string, width int) string {
+	s = truncateEnd(s, width)
+	if w := lipgloss.Width(s); w < width {
+		s += strings.Repeat(" ", width-w)
+	}
+	return s
+}
@@ -0,0 +1,50 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s          string
+		width      int
+		end, start string // Cut by truncateEnd and truncateStart
+	}{
+		{"Green Hill Zone", 20, "Green Hill Zone", "Green Hill Zone"},
+		{"Green Hill Zone", 15, "Green Hill Zone", "Green Hill Zone"},
+		{"Green Hill Zone", 10, "Green H...", "...ll Zone"},
+		{"Green Hill Zone", 4, "G...", "...e"},
+		// No room for "...": cut to the width instead
+		{"Green Hill Zone", 3, "Gre", "one"},
+		{"Green Hill Zone", 1, "G", "e"},
+		{"Green Hill Zone", 0, "", ""},
+		{"Green Hill Zone", -1, "", ""},
+		{"", 0, "", ""},
+		{"", -1, "", ""},
+		// Wide characters are never split
+		{"ドラクエ", 8, "ドラクエ", "ドラクエ"},
+		{"ドラクエ", 7, "ドラ...", "...クエ"},
+		{"ドラクエ", 4, "...", "..."},
+		{"ドラクエ", 3, "ド", "エ"},
+		{"ドラクエ", 1, "", ""},
+	}
+	for _, tt := range tests {
+		for name, cut := range map[string]struct {
+			fn   func(string, int) string
+			want string
+		}{
+			"truncateEnd":   {truncateEnd, tt.end},
+			"truncateStart": {truncateStart, tt.start},
+		} {
+			got := cut.fn(tt.s, tt.width)
+			if got != cut.want {
+				t.Errorf("%s(%q, %d) = %q, want %q", name, tt.s, tt.width, got, cut.want)
+			}
+			if w := lipgloss.Width(got); w > max(tt.width, 0) {
+				t.Errorf("%s(%q, %d) is %d cells wide", name, tt.s, tt.width, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Package components provides UI components for vgmtui.
+package components
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// VU meter ballistics, in ticks.
+const (
+	vuPeakHoldTicks = 20   // How long the peak marker holds (~1s at 50ms)
+	vuPeakDecay     = 0.05 // Scale units the peak falls per tick after holding
+	vuFloorDB       = -48  // Level shown at the left edge of the meter
+)
+
+// VUMeter displays stereo level meters: a bar for the RMS level and a
+// marker for the recent peak, which holds briefly and then decays.
+type VUMeter struct {
+	rms       [2]float64 // Current RMS per channel, on the meter scale
+	peak      [2]float64 // Held peak per channel, on the meter scale
+	peakTicks [2]int     // Ticks the peak has been held
+	width     int
+
+	// Styles
+	LowStyle   lipgloss.Style // Safe zone
+	MidStyle   lipgloss.Style // Getting loud
+	HighStyle  lipgloss.Style // Near clipping
+	EmptyStyle lipgloss.Style
+	LabelStyle lipgloss.Style
+
+	// ASCII draws the meter with "#", "|" and "-" instead of blocks
+	ASCII bool
+}
+
+// NewVUMeter creates a new VU meter with default styling.
+func NewVUMeter() VUMeter {
+	return VUMeter{
+		width:      40,
+		LowStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575")),
+		MidStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500")),
+		HighStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87")),
+		EmptyStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#606060")),
+		LabelStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#A0A0A0")),
+	}
+}
+
+// SetWidth sets the total width available for each meter line.
+func (v *VUMeter) SetWidth(width int) {
+	v.width = width
+}
+
+// Update feeds one tick of linear levels (0.0 - 1.0) into the meters.
+// Peaks at or above the held peak reset the hold; otherwise the held peak
+// decays once the hold time has passed.
+func (v *VUMeter) Update(rmsL, rmsR, peakL, peakR float64) {
+	rms := [2]float64{rmsL, rmsR}
+	peaks := [2]float64{peakL, peakR}
+	for ch := range v.rms {
+		v.rms[ch] = meterScale(rms[ch])
+
+		p := meterScale(peaks[ch])
+		if p >= v.peak[ch] {
+			v.peak[ch] = p
+			v.peakTicks[ch] = 0
+			continue
+		}
+		v.peakTicks[ch]++
+		if v.peakTicks[ch] > vuPeakHoldTicks {
+			v.peak[ch] = math.Max(p, v.peak[ch]-vuPeakDecay)
+		}
+	}
+}
+
+// Reset drops the meters to silence immediately.
+func (v *VUMeter) Reset() {
+	v.rms = [2]float64{}
+	v.peak = [2]float64{}
+	v.peakTicks = [2]int{}
+}
+
+// meterScale maps a linear level to the 0.0 - 1.0 meter scale in decibels.
+func meterScale(level float64) float64 {
+	if level <= 0 {
+		return 0
+	}
+	db := 20 * math.Log10(level)
+	return math.Max(0, math.Min(1, (db-vuFloorDB)/-vuFloorDB))
+}
+
+// View renders the left and right meters on two lines.
+func (v VUMeter) View() string {
+	return v.renderChannel("L", 0) + "\n" + v.renderChannel("R", 1)
+}
+
+// renderChannel renders one meter line.
+func (v VUMeter) renderChannel(label string, ch int) string {
+	barWidth := v.width - 2 // Label and space
+	if barWidth < 1 {
+		return v.LabelStyle.Render(label)
+	}
+
+	filled := int(v.rms[ch] * float64(barWidth))
+	peakPos := int(v.peak[ch]*float64(barWidth)) - 1
+	if peakPos >= barWidth {
+		peakPos = barWidth - 1
+	}
+
+	full, peak, empty := "█", "│", "░" // Full block, peak marker, light shade
+	if v.ASCII {
+		full, peak, empty = "#", "|", "-"
+	}
+
+	var b strings.Builder
+	b.WriteString(v.LabelStyle.Render(label))
+	b.WriteString(" ")
+	for i := 0; i < barWidth; i++ {
+		style := v.zoneStyle(float64(i) / float64(barWidth))
+		switch {
+		case i < filled:
+			b.WriteString(style.Render(full))
+		case i == peakPos:
+			b.WriteString(style.Render(peak))
+		default:
+			b.WriteString(v.EmptyStyle.Render(empty))
+		}
+	}
+	return b.String()
+}
+
+// zoneStyle returns the style for a position on the meter scale.
+// The amber and red zones start at -12 dB and -3 dB.
+func (v VUMeter) zoneStyle(pos float64) lipgloss.Style {
+	switch {
+	case pos >= meterScale(math.Pow(10, -3.0/20)):
+		return v.HighStyle
+	case pos >= meterScale(math.Pow(10, -12.0/20)):
+		return v.MidStyle
+	default:
+		return v.LowStyle
+	}
+}
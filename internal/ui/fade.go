@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/player"
+)
+
+// fadeGrace is added to the player's fade-out time before acting on the
+// end of a fade, in case it ends a little late.
+const fadeGrace = 500 * time.Millisecond
+
+// FadeStoppedMsg is sent when the fade-out started by the fade-stop key
+// with the given sequence number should be over.
+type FadeStoppedMsg struct {
+	Seq int
+}
+
+// fadeOut starts fading out the playing track. The queued track is dropped
+// so the player stops at the end of the fade rather than moving on.
+func (m *Model) fadeOut() {
+	m.audioPlayer.Dequeue()
+	m.queuedIndex = -1
+	m.audioPlayer.FadeOut()
+	m.playback.State = StateFading
+}
+
+// fadeWait returns a command that sends msg once a fade-out started now
+// has finished.
+func fadeWait(msg tea.Msg) tea.Cmd {
+	fade := time.Duration(player.DefaultFadeTime)*time.Millisecond + fadeGrace
+	return tea.Tick(fade, func(time.Time) tea.Msg { return msg })
+}
+
+// fadeOutStop fades out the playing track and stops playback when the
+// fade ends. With nothing playing it stops straight away.
+func (m *Model) fadeOutStop() tea.Cmd {
+	if m.audioPlayer == nil || m.playback.State != StatePlaying {
+		return func() tea.Msg { return StopMsg{} }
+	}
+	m.fadeOut()
+	m.fadeSeq++
+	m.fadeStopping = true
+	return fadeWait(FadeStoppedMsg{Seq: m.fadeSeq})
+}
+
+// finishFadeStop stops playback after a fade started by fadeOutStop.
+func (m *Model) finishFadeStop() tea.Cmd {
+	m.fadeStopping = false
+	return func() tea.Msg { return StopMsg{} }
+}
@@ -0,0 +1,70 @@
+package ui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// trackQueuedMsg reports the result of queueing a track for gapless
+// playback.
+type trackQueuedMsg struct {
+	index int
+	err   error
+}
+
+// queueNextTrack returns a command that loads the track auto-advance would
+// play next onto the player's second libvgm instance, so it starts without
+// a gap. It returns nil unless gapless playback is on.
+func (m *Model) queueNextTrack() tea.Cmd {
+	if m.audioPlayer == nil || !m.config.Gapless {
+		return nil
+	}
+	m.fillRadio()
+	idx := m.nextAutoTrack()
+	track := m.playlist.GetTrack(idx)
+	if track == nil {
+		if m.queuedIndex >= 0 {
+			// The queued track is no longer next
+			m.queuedIndex = -1
+			m.audioPlayer.Dequeue()
+		}
+		return nil
+	}
+
+	m.queuedIndex = idx
+	ap, path := m.audioPlayer, track.Path
+	return func() tea.Msg {
+		return trackQueuedMsg{index: idx, err: ap.Queue(path)}
+	}
+}
+
+// requeueNextTrack queues the next track again after the playlist order
+// changed, since the queued one may no longer be next.
+func (m *Model) requeueNextTrack() tea.Cmd {
+	if m.currentTrack == nil || m.trackLoading {
+		return nil
+	}
+	return m.queueNextTrack()
+}
+
+// gaplessAdvance makes the queued track current after the player has moved
+// on to it. If the playlist changed so the queued track is no longer next,
+// the track that is next now is loaded instead.
+func (m *Model) gaplessAdvance() tea.Cmd {
+	idx := m.queuedIndex
+	m.queuedIndex = -1
+	if idx < 0 || m.trackLoading || m.audioPlayer == nil {
+		return nil
+	}
+	playing := m.audioPlayer.Track()
+	queued := m.playlist.GetTrack(idx)
+	if playing == nil || queued == nil || queued.Path != playing.Path {
+		if next := m.nextAutoTrack(); next >= 0 {
+			return m.advanceToTrack(next, 1)
+		}
+		m.stopPlayback()
+		return nil
+	}
+
+	m.trackFinished()
+	m.pendingPlayIndex = idx
+	m.pendingTrack = queued
+	return m.trackStarted(playing, playing.Chips)
+}
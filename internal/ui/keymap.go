@@ -5,10 +5,14 @@ import "github.com/charmbracelet/bubbles/key"
 // KeyMap defines all key bindings for the application.
 type KeyMap struct {
 	// Playback controls
-	PlayPause key.Binding
-	NextTrack key.Binding
-	PrevTrack key.Binding
-	Stop      key.Binding
+	PlayPause    key.Binding
+	NextTrack    key.Binding
+	PrevTrack    key.Binding
+	Stop         key.Binding
+	FadeStop     key.Binding
+	Restart      key.Binding
+	EndFade      key.Binding
+	LoopProgress key.Binding
 
 	// Navigation
 	Up       key.Binding
@@ -18,16 +22,47 @@ type KeyMap struct {
 	TabFocus key.Binding
 
 	// Seek controls
-	SeekForward  key.Binding
-	SeekBackward key.Binding
+	SeekForward     key.Binding
+	SeekBackward    key.Binding
+	SeekFarForward  key.Binding // Seek by the large step
+	SeekFarBackward key.Binding
 
 	// Volume
 	VolumeUp   key.Binding
 	VolumeDown key.Binding
+	Mute       key.Binding
+
+	// Overlays and appearance
+	ChipInfo      key.Binding
+	CompactChips  key.Binding
+	CycleTheme    key.Binding
+	Scope         key.Binding
+	Meters        key.Binding
+	Diagnostics   key.Binding
+	GameLabel     key.Binding
+	AudioConfig   key.Binding
+	FullInfo      key.Binding
+	ExportSession key.Binding
+	ImportSession key.Binding
+	History       key.Binding
+	LibraryStats  key.Binding
+	WidenLibrary  key.Binding
+	SwitchBrowser key.Binding
+	SleepTimer    key.Binding
+	NarrowLibrary key.Binding
+	Radio         key.Binding
+	ShufflePlay   key.Binding
+
+	// Library
+	Rescan       key.Binding
+	LibraryDiff  key.Binding
+	WatchLibrary key.Binding
 
 	// Help and Quit
-	Help key.Binding
-	Quit key.Binding
+	Help        key.Binding
+	Palette     key.Binding
+	KeyBindings key.Binding
+	Quit        key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings.
@@ -50,6 +85,22 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("s"),
 			key.WithHelp("s", "stop"),
 		),
+		FadeStop: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "fade out and stop"),
+		),
+		Restart: key.NewBinding(
+			key.WithKeys("0"),
+			key.WithHelp("0", "restart track"),
+		),
+		EndFade: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "fade/cut after last loop"),
+		),
+		LoopProgress: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "per-loop/total progress"),
+		),
 
 		// Navigation
 		Up: key.NewBinding(
@@ -70,27 +121,139 @@ func DefaultKeyMap() KeyMap {
 		),
 		TabFocus: key.NewBinding(
 			key.WithKeys("tab"),
-			key.WithHelp("tab", "focus"),
+			key.WithHelp("tab", "switch focus"),
 		),
 
 		// Seek
 		SeekForward: key.NewBinding(
 			key.WithKeys("f"),
-			key.WithHelp("f", "+5s"),
+			key.WithHelp("f", "seek forward"),
 		),
 		SeekBackward: key.NewBinding(
 			key.WithKeys("b"),
-			key.WithHelp("b", "-5s"),
+			key.WithHelp("b", "seek back"),
+		),
+		SeekFarForward: key.NewBinding(
+			key.WithKeys("alt+f", "shift+right"),
+			key.WithHelp("alt+f", "seek far forward"),
+		),
+		SeekFarBackward: key.NewBinding(
+			key.WithKeys("alt+b", "shift+left"),
+			key.WithHelp("alt+b", "seek far back"),
 		),
 
 		// Volume
 		VolumeUp: key.NewBinding(
 			key.WithKeys("+", "="),
-			key.WithHelp("+", "vol+"),
+			key.WithHelp("+", "volume up"),
 		),
 		VolumeDown: key.NewBinding(
 			key.WithKeys("-"),
-			key.WithHelp("-", "vol-"),
+			key.WithHelp("-", "volume down"),
+		),
+		Mute: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "mute"),
+		),
+
+		// Overlays and appearance
+		ChipInfo: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "chip details"),
+		),
+		CompactChips: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "compact chips"),
+		),
+		CycleTheme: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "cycle theme"),
+		),
+		Scope: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "scope"),
+		),
+		Meters: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "vu meters"),
+		),
+		Diagnostics: key.NewBinding(
+			key.WithKeys("f12"),
+			key.WithHelp("f12", "audio diagnostics"),
+		),
+		GameLabel: key.NewBinding(
+			key.WithKeys("ctrl+g"),
+			key.WithHelp("ctrl+g", "game names"),
+		),
+		Radio: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "radio mode"),
+		),
+		ShufflePlay: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "shuffle/smart shuffle"),
+		),
+		AudioConfig: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "audio config"),
+		),
+		FullInfo: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "full track info"),
+		),
+		ExportSession: key.NewBinding(
+			key.WithKeys("ctrl+e"),
+			key.WithHelp("ctrl+e", "export session"),
+		),
+		ImportSession: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "import session"),
+		),
+		History: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "history"),
+		),
+		LibraryStats: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "library stats"),
+		),
+		Palette: key.NewBinding(
+			key.WithKeys(":", "ctrl+p"),
+			key.WithHelp(":", "commands"),
+		),
+		KeyBindings: key.NewBinding(
+			key.WithKeys("ctrl+b"),
+			key.WithHelp("ctrl+b", "key bindings"),
+		),
+		SleepTimer: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "sleep timer"),
+		),
+		SwitchBrowser: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "library/files"),
+		),
+		WidenLibrary: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "widen library"),
+		),
+		NarrowLibrary: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "narrow library"),
+		),
+
+		// Library
+		Rescan: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "rescan library"),
+		),
+		WatchLibrary: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "watch library"),
+		),
+		LibraryDiff: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "library changes"),
 		),
 
 		// Help and Quit
@@ -127,6 +290,10 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 			k.NextTrack,
 			k.PrevTrack,
 			k.Stop,
+			k.FadeStop,
+			k.Restart,
+			k.EndFade,
+			k.LoopProgress,
 		},
 		// Navigation column
 		{
@@ -138,11 +305,38 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{
 			k.SeekForward,
 			k.SeekBackward,
+			k.SeekFarForward,
+			k.SeekFarBackward,
 			k.VolumeUp,
 			k.VolumeDown,
+			k.Mute,
 		},
 		// System column
 		{
+			k.ChipInfo,
+			k.CompactChips,
+			k.CycleTheme,
+			k.Scope,
+			k.Meters,
+			k.Diagnostics,
+			k.GameLabel,
+			k.AudioConfig,
+			k.FullInfo,
+			k.ExportSession,
+			k.ImportSession,
+			k.History,
+			k.LibraryStats,
+			k.SleepTimer,
+			k.SwitchBrowser,
+			k.WidenLibrary,
+			k.NarrowLibrary,
+			k.Radio,
+			k.ShufflePlay,
+			k.Rescan,
+			k.LibraryDiff,
+			k.WatchLibrary,
+			k.Palette,
+			k.KeyBindings,
 			k.Help,
 			k.Quit,
 		},
@@ -1,13 +1,17 @@
 package ui
 
 import (
+	"fmt"
 	"os"
-	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/dewi-tim/vgmtui/internal/config"
 	"github.com/dewi-tim/vgmtui/internal/library"
+	"github.com/dewi-tim/vgmtui/internal/metadata"
 	"github.com/dewi-tim/vgmtui/internal/player"
 	"github.com/dewi-tim/vgmtui/internal/ui/components"
 )
@@ -40,7 +44,8 @@ type PlaybackInfo struct {
 	Position    time.Duration
 	Duration    time.Duration
 	CurrentLoop int
-	TotalLoops  int
+	TotalLoops  int  // 0 = loops forever
+	HasLoop     bool // Whether the track has a loop point
 }
 
 // Model is the main Bubbletea model for vgmtui.
@@ -53,13 +58,26 @@ type Model struct {
 	focus Focus
 
 	// UI Components
-	browser    components.Browser     // File browser (fallback mode)
-	libBrowser components.LibBrowser  // Library browser (main mode)
-	lib        *library.Library       // Music library
-	useLibrary bool                   // Whether to use library browser
-	playlist   components.Playlist
-	progress   components.ProgressBar
-	helpPopup  components.HelpPopup
+	browser      components.Browser    // File browser (fallback mode)
+	libBrowser   components.LibBrowser // Library browser (main mode)
+	lib          *library.Library      // Music library
+	useLibrary   bool                  // Whether to use library browser
+	playlist     components.Playlist
+	progress     components.ProgressBar
+	loadSpinner  spinner.Model // Shown in the status line while a track loads
+	helpPopup    components.HelpPopup
+	chipPopup    components.ChipPopup
+	diffPopup    components.DiffPopup
+	audioPopup   components.AudioPopup
+	historyPopup components.HistoryPopup
+	statsPopup   components.StatsPopup
+	chipPicker   components.ChipFilterPopup
+	palette      components.CommandPalette
+	keyBindings  components.KeyBindingsPopup
+	dupPopup     components.DuplicatesPopup
+	tagEditor    components.TagEditor
+	scope        components.Scope
+	vuMeter      components.VUMeter
 
 	// Key bindings
 	keyMap KeyMap
@@ -72,25 +90,138 @@ type Model struct {
 	lastError string
 	errorTime time.Time
 
+	// Transient status notice (e.g. after exporting a session)
+	notice     string
+	noticeTime time.Time
+
 	// Playback state
 	playback     PlaybackInfo
 	currentTrack *Track
-	volume       float64 // Volume level (0.0 - 1.0+)
-	trackLoading bool    // True while a playTrack command is in flight
+	volume       float64   // Volume level (0.0 - 2.0)
+	volumeTime   time.Time // When the volume last changed, to show the slider
+	muted        bool      // Output silenced; volume keeps the level to restore
+	endCut       bool      // Cut looping tracks off after the last loop instead of fading
+	loopProgress bool      // Progress bar shows the current loop rather than the whole track
+	speed        float64   // Playback speed (1.0 = normal), from the player
+	trackLoading bool      // True while a playTrack command is in flight
+	addingFiles  int       // Files of recursive directory adds still being read
 
 	// Pending playback state (for atomic transitions)
 	// These hold the intended track until playback is confirmed
 	pendingPlayIndex int    // Index in playlist of track being loaded (-1 if none)
 	pendingTrack     *Track // Track being loaded (nil if none)
 
+	// Gapless playback: playlist index of the track queued on the player
+	// (-1 if none) and the player's count of moves to a queued track
+	queuedIndex  int
+	trackChanges uint32
+
 	// Audio player (nil in TUI-only mode)
 	audioPlayer *player.AudioPlayer
 	playerSub   <-chan player.PlaybackInfo
 
 	// Track chip info (from real player)
-	trackChips []player.ChipInfo
+	trackChips   []metadata.ChipInfo
+	trackMeta    *metadata.Track // Full metadata of the playing track (nil if none)
+	metadata     metadata.Reader // Where track metadata is read from
+	compactChips bool            // Show abbreviated chip names in track info
+
+	// Metadata preview of the file under the file browser cursor. Each
+	// cursor move bumps previewSeq so stale reads are dropped.
+	preview      *Track
+	previewChips []metadata.ChipInfo
+	previewPath  string
+	previewSeq   int
+
+	// Source of game names shown in the library tree, playlist and track info
+	gameLabel library.GameLabel
+
+	// Where the library is looked for (~/VGM unless configured), so it can
+	// be opened later if it was missing at startup
+	libraryRoot string
+
+	// True once the file browser has read its first directory
+	browserStarted bool
+
+	// Sleep timer: when it runs out (zero when off), the index of its
+	// preset length (-1 when off), a sequence number to ignore ticks of
+	// replaced timers, and whether the final fade-out is underway
+	sleepAt     time.Time
+	sleepPreset int
+	sleepSeq    int
+	sleepFading bool
+
+	// Fade-stop key: a sequence number to ignore the end of replaced
+	// fades, and whether a fade-out before stopping is underway
+	fadeSeq      int
+	fadeStopping bool
+
+	// Times the player has reopened a lost audio device, from its ticks
+	deviceRestarts uint32
+
+	// No audio device: the player could not be created, so nothing plays
+	noAudio bool
+
+	// Share of the width taken by the library pane, in percent
+	libraryPercent int
+
+	// Draw with ASCII characters only: borders, bars and indicators
+	ascii bool
+
+	// Oscilloscope (replaces the track info panel when shown)
+	showScope bool
+
+	// Show the full track info panel in place of the playlist
+	showFullInfo bool
+
+	// Stereo level meters (shown in the progress panel)
+	showMeters bool
+
+	// Show the audio diagnostics line in the progress panel
+	showDiagnostics bool
+
+	// Radio mode: keep the queue topped up with random library tracks
+	radio bool
+
+	// True while a user-requested library rescan is running, so its
+	// changes are shown when it completes
+	rescanning bool
+
+	// Polls the library for file changes (nil when not watching).
+	// autoRescan is true while a rescan it triggered is running.
+	watcher    *library.Watcher
+	autoRescan bool
+
+	// User configuration
+	config config.Config
+
+	// Notified of playback changes (e.g. by the MPRIS integration)
+	observers []Observer
+
+	// Files and directories to queue and play at startup
+	openPaths []string
+
+	// Session file to restore at startup
+	sessionPath string
+
+	// Recently played tracks, newest first
+	history []components.HistoryEntry
+
+	// Starred track paths
+	favorites components.Favorites
+	plays     components.PlayStats // Full play counts and start times
+
+	// Position to seek to once the pending track starts (0 for none)
+	resumeAt time.Duration
+
+	// Direction the pending track was stepped to (1 next, -1 previous,
+	// 0 chosen directly), so a track that fails to load can be skipped,
+	// and the files skipped so far
+	skipDir int
+	skipped []string
 
 	// Styles
+	theme  Theme
 	styles Styles
 }
 
@@ -99,62 +230,136 @@ func New() Model {
 	return NewWithPlayer(nil)
 }
 
+// SetAudioUnavailable marks the model as running without audio because
+// the player could not be created. The footer says so for the whole
+// session, and err is shown at startup.
+func (m *Model) SetAudioUnavailable(err error) {
+	m.noAudio = true
+	m.lastError = err.Error()
+	m.errorTime = time.Now()
+}
+
 // NewWithPlayer creates a new Model with an optional audio player.
 // If player is nil, the TUI runs in display-only mode.
 func NewWithPlayer(ap *player.AudioPlayer) Model {
-	// Determine library root - prefer ~/VGM if it exists
-	home, err := os.UserHomeDir()
-	if err != nil {
-		home = ""
-	}
-	vgmDir := filepath.Join(home, "VGM")
+	return NewWithConfig(ap, config.Default())
+}
+
+// NewWithConfig creates a new Model with an optional audio player and
+// the given user configuration.
+func NewWithConfig(ap *player.AudioPlayer, cfg config.Config) Model {
+	// Determine library root - prefer the library directory (~/VGM unless
+	// configured) if it exists
+	vgmDir := cfg.LibraryRoot()
 	useLibrary := false
 
-	// Check if ~/VGM exists
-	if home != "" {
+	// Check if the library directory exists
+	if vgmDir != "" {
 		if info, err := os.Stat(vgmDir); err == nil && info.IsDir() {
 			useLibrary = true
 		}
 	}
 
-	// Initialize library and library browser if ~/VGM exists
+	// Initialize library and library browser if the library directory exists
+	favorites := loadFavorites()
+	plays := loadPlayStats()
 	var lib *library.Library
 	var libBrowser components.LibBrowser
 	if useLibrary {
-		lib = library.New(vgmDir)
-		libBrowser = components.NewLibBrowser(lib)
+		lib, libBrowser = newLibrary(vgmDir, cfg, player.DefaultMetadata, favorites, plays)
 		libBrowser.Focus() // Start with library focused
 	}
 
-	// Initialize browser with home directory (fallback - always created for switching)
-	browser := components.NewBrowser("")
+	// Initialize browser with the start directory, home unless configured
+	// (fallback - always created for switching)
+	browser := components.NewBrowser(cfg.BrowserStartDir())
 	if !useLibrary {
 		browser.Focus() // Only focus if not using library
 	}
+	if cfg.RememberDirPrefs {
+		browser.SetRememberPrefs(true, loadDirPrefs())
+	}
 
 	// Initialize empty playlist
 	playlist := components.NewPlaylist()
+	playlist.SetFavorites(favorites)
+	playlist.SetPlayStats(plays)
+	if cfg.RemoveMovesUp {
+		playlist.SetRemoveCursor(components.RemoveCursorUp)
+	}
 
 	m := Model{
 		focus:            FocusBrowser,
 		browser:          browser,
 		libBrowser:       libBrowser,
 		lib:              lib,
+		metadata:         player.DefaultMetadata,
 		useLibrary:       useLibrary,
+		libraryRoot:      vgmDir,
+		browserStarted:   !useLibrary,
 		playlist:         playlist,
 		progress:         components.NewProgressBar(),
+		loadSpinner:      spinner.New(spinner.WithSpinner(spinner.MiniDot)),
 		helpPopup:        components.NewHelpPopup(),
+		chipPopup:        components.NewChipPopup(),
+		diffPopup:        components.NewDiffPopup(),
+		audioPopup:       components.NewAudioPopup(),
+		historyPopup:     components.NewHistoryPopup(),
+		statsPopup:       components.NewStatsPopup(),
+		chipPicker:       components.NewChipFilterPopup(),
+		palette:          components.NewCommandPalette(),
+		keyBindings:      components.NewKeyBindingsPopup(),
+		dupPopup:         components.NewDuplicatesPopup(),
+		tagEditor:        components.NewTagEditor(),
+		history:          loadHistory(),
+		favorites:        favorites,
+		plays:            plays,
+		scope:            components.NewScope(),
+		vuMeter:          components.NewVUMeter(),
 		keyMap:           DefaultKeyMap(),
-		styles:           DefaultStyles(),
+		libraryPercent:   clampLibraryPercent(cfg.LibraryWidthPercent),
+		ascii:            cfg.ASCII,
+		config:           cfg,
 		audioPlayer:      ap,
 		volume:           1.0,
+		speed:            1.0,
 		pendingPlayIndex: -1, // No pending track
+		queuedIndex:      -1, // Nothing queued for gapless playback
+		sleepPreset:      -1, // Sleep timer off
 		playback: PlaybackInfo{
 			State:      StateStopped,
 			TotalLoops: 2,
 		},
 	}
 
+	// Apply the configured theme to the model and all components
+	theme, ok := LookupTheme(cfg.Theme)
+	if !ok {
+		theme = DefaultTheme()
+	}
+	m.applyTheme(theme)
+
+	if err := m.applyKeyBindings(cfg.Keys); err != nil {
+		m.lastError = err.Error()
+		m.errorTime = time.Now()
+	}
+	if err := m.applyPlaylistColumns(cfg.PlaylistColumns); err != nil {
+		m.lastError = err.Error()
+		m.errorTime = time.Now()
+	}
+	if err := m.applyProgressStyle(); err != nil {
+		m.lastError = err.Error()
+		m.errorTime = time.Now()
+	}
+
+	if useLibrary && cfg.WatchLibrary {
+		m.watcher = library.NewWatcher(lib.Root(), watchInterval, watchSettle)
+	}
+
+	if !cfg.FreshPlaylist {
+		m.restorePlaylist()
+	}
+
 	// Subscribe to player updates if player is available
 	if ap != nil {
 		m.playerSub = ap.Subscribe()
@@ -174,14 +379,507 @@ func (m Model) Init() tea.Cmd {
 		cmds = append(cmds, m.browser.Init())
 	}
 
+	if m.watcher != nil {
+		cmds = append(cmds, listenForLibraryChanges(m.watcher))
+	}
+
 	// If we have a real player, start listening for playback updates
 	if m.playerSub != nil {
 		cmds = append(cmds, listenForPlayback(m.playerSub))
 	}
 
+	// Queue and play anything given on the command line
+	if len(m.openPaths) > 0 {
+		cmds = append(cmds, openTracks(m.metadata, m.openPaths))
+	}
+	if m.sessionPath != "" {
+		cmds = append(cmds, importSession(m.metadata, m.sessionPath))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// dirPrefsStateFile is the state file holding per-directory browser prefs.
+const dirPrefsStateFile = "dirprefs.json"
+
+// loadDirPrefs reads the remembered per-directory browser preferences.
+// Unreadable state is ignored so a corrupt file never blocks startup.
+func loadDirPrefs() map[string]components.DirPrefs {
+	prefs := make(map[string]components.DirPrefs)
+	if err := config.LoadState(dirPrefsStateFile, &prefs); err != nil {
+		return make(map[string]components.DirPrefs)
+	}
+	return prefs
+}
+
+// saveDirPrefs returns a command that persists per-directory browser prefs.
+func saveDirPrefs(prefs map[string]components.DirPrefs) tea.Cmd {
+	return func() tea.Msg {
+		if err := config.SaveState(dirPrefsStateFile, prefs); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// historyStateFile is the state file holding recently played tracks.
+const historyStateFile = "history.json"
+
+// historyLimit is how many recently played tracks are kept.
+const historyLimit = 200
+
+// loadHistory reads the recently played tracks.
+// Unreadable state is ignored so a corrupt file never blocks startup.
+func loadHistory() []components.HistoryEntry {
+	var history []components.HistoryEntry
+	if err := config.LoadState(historyStateFile, &history); err != nil {
+		return nil
+	}
+	return history
+}
+
+// saveHistory returns a command that persists the recently played tracks.
+func saveHistory(history []components.HistoryEntry) tea.Cmd {
+	return func() tea.Msg {
+		if err := config.SaveState(historyStateFile, history); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// recordHistory adds a track that started playing to the front of the
+// history, dropping the oldest entries beyond historyLimit.
+func (m *Model) recordHistory(track Track) {
+	entry := components.HistoryEntry{Track: track, PlayedAt: time.Now()}
+	m.plays.Started(track.Path, entry.PlayedAt)
+	history := make([]components.HistoryEntry, 0, min(len(m.history)+1, historyLimit))
+	history = append(history, entry)
+	history = append(history, m.history[:min(len(m.history), historyLimit-1)]...)
+	m.history = history
+}
+
+// playlistStateFile is the state file holding the playlist of the last
+// session.
+const playlistStateFile = "playlist.json"
+
+// savedPlaylist is the playlist as persisted between sessions. Tracks keep
+// their metadata so restoring needs no file reads.
+type savedPlaylist struct {
+	Tracks  []Track `json:"tracks"`
+	Current int     `json:"current"` // -1 if nothing was playing
+}
+
+// restorePlaylist loads the playlist of the last session without playing
+// it; the track that was playing is marked current, so play resumes there.
+// Unreadable state is ignored so a corrupt file never blocks startup.
+func (m *Model) restorePlaylist() {
+	saved := savedPlaylist{Current: -1}
+	if err := config.LoadState(playlistStateFile, &saved); err != nil || len(saved.Tracks) == 0 {
+		return
+	}
+	m.playlist.AddTracks(saved.Tracks)
+	if m.playlist.GoToIndex(saved.Current) {
+		m.playlist.SetCurrentTrack(saved.Current)
+	}
+}
+
+// savePlaylist returns a command that persists the playlist.
+func (m Model) savePlaylist() tea.Cmd {
+	saved := savedPlaylist{Tracks: m.playlist.Tracks(), Current: m.playlist.CurrentIndex()}
+	return func() tea.Msg {
+		if err := config.SaveState(playlistStateFile, saved); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// quit saves the playlist, stops watching the library and returns the
+// command that exits.
+func (m *Model) quit() tea.Cmd {
+	m.quitting = true
+	m.stopWatching()
+	if !m.config.FreshPlaylist {
+		// Written here rather than in a command, which would not run
+		// before the program exits
+		m.savePlaylist()()
+	}
+	return tea.Sequence(tea.SetWindowTitle(""), tea.Quit)
+}
+
+// smartPlaylists converts the configured smart playlists to library queries.
+func smartPlaylists(cfgs []config.SmartPlaylist) []library.SmartPlaylist {
+	playlists := make([]library.SmartPlaylist, len(cfgs))
+	for i, c := range cfgs {
+		playlists[i] = library.SmartPlaylist{
+			Name: c.Name,
+			Query: library.Query{
+				System:      c.System,
+				Game:        c.Game,
+				Composer:    c.Composer,
+				Title:       c.Title,
+				MinDuration: time.Duration(c.MinSeconds) * time.Second,
+				MaxDuration: time.Duration(c.MaxSeconds) * time.Second,
+			},
+		}
+	}
+	return playlists
+}
+
+// favoritesStateFile is the state file holding favorite track paths.
+const favoritesStateFile = "favorites.json"
+
+// loadFavorites reads the favorite track paths.
+// Unreadable state is ignored so a corrupt file never blocks startup.
+func loadFavorites() components.Favorites {
+	var paths []string
+	favorites := make(components.Favorites)
+	if err := config.LoadState(favoritesStateFile, &paths); err != nil {
+		return favorites
+	}
+	for _, path := range paths {
+		favorites[path] = true
+	}
+	return favorites
+}
+
+// saveFavorites returns a command that persists the favorite track paths.
+func saveFavorites(favorites components.Favorites) tea.Cmd {
+	paths := favorites.Paths()
+	return func() tea.Msg {
+		if err := config.SaveState(favoritesStateFile, paths); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// toggleFavorite stars or unstars a track and updates every view of it.
+func (m *Model) toggleFavorite(path string) tea.Cmd {
+	if m.favorites.Has(path) {
+		delete(m.favorites, path)
+	} else {
+		m.favorites[path] = true
+	}
+	if m.useLibrary {
+		m.libBrowser.SetFavorites(m.favorites)
+	}
+	m.playlist.SetFavorites(m.favorites)
+	return saveFavorites(m.favorites)
+}
+
+// playStatsStateFile is the state file holding play counts and times.
+const playStatsStateFile = "plays.json"
+
+// trackFinished counts a full play of the current track, which reached
+// its end rather than being skipped or stopped. The caller saves the
+// stats, which trackStarted does for the track that follows.
+func (m *Model) trackFinished() {
+	if m.currentTrack == nil {
+		return
+	}
+	m.plays.Finished(m.currentTrack.Path)
+	if m.lib != nil {
+		m.libBrowser.SetPlayStats(m.plays)
+	}
+}
+
+// loadPlayStats reads the play counts and times.
+// Unreadable state is ignored so a corrupt file never blocks startup.
+func loadPlayStats() components.PlayStats {
+	var plays components.PlayStats
+	if err := config.LoadState(playStatsStateFile, &plays); err != nil || plays == nil {
+		return make(components.PlayStats)
+	}
+	return plays
+}
+
+// savePlayStats returns a command that persists the play counts and times.
+func savePlayStats(plays components.PlayStats) tea.Cmd {
+	plays = plays.Clone()
+	return func() tea.Msg {
+		if err := config.SaveState(playStatsStateFile, plays); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// libraryCacheStateFile is the state file holding the last library scan.
+const libraryCacheStateFile = "library.json"
+
+// diffLibrary returns a command that compares the library's current scan
+// with the one saved by the previous scan, then saves the current scan in
+// its place. A saved scan of a different root is not compared against.
+func diffLibrary(lib *library.Library) tea.Cmd {
+	return func() tea.Msg {
+		cur := lib.Cache()
+		msg := LibraryDiffMsg{Root: cur.Root}
+
+		var prev library.Cache
+		if err := config.LoadState(libraryCacheStateFile, &prev); err == nil && prev.Root == cur.Root {
+			msg.Diff = library.DiffTracks(prev.Tracks, cur.Tracks)
+			msg.Since = prev.ScannedAt
+			msg.Baseline = true
+		}
+
+		msg.Err = config.SaveState(libraryCacheStateFile, cur)
+		return msg
+	}
+}
+
+// findDuplicates returns a command that searches the library for
+// duplicate tracks by mode.
+func findDuplicates(lib *library.Library, mode library.DuplicateMode) tea.Cmd {
+	return func() tea.Msg {
+		groups, err := lib.FindDuplicates(mode)
+		return DuplicatesFoundMsg{Mode: mode, Groups: groups, Err: err}
+	}
+}
+
+// newLibrary creates the library rooted at root and its browser, set up
+// from the config, reading metadata through reader and showing the given
+// favorites and play counts. The
+// library is not scanned until the browser's Init command runs.
+func newLibrary(root string, cfg config.Config, reader metadata.Reader, favorites components.Favorites, plays components.PlayStats) (*library.Library, components.LibBrowser) {
+	lib := library.New(root, reader)
+	if order, ok := library.ParseTrackSort(cfg.LibrarySort); ok {
+		lib.SetTrackSort(order)
+	}
+	libBrowser := components.NewLibBrowser(lib)
+	libBrowser.SetFavorites(favorites)
+	libBrowser.SetPlayStats(plays)
+	libBrowser.SetSmartPlaylists(smartPlaylists(cfg.SmartPlaylists))
+	return lib, libBrowser
+}
+
+// SetMetadataReader sets where the library and the playlist read track
+// metadata from, in place of player.DefaultMetadata. It must be called
+// before the program starts for the first library scan to use it.
+func (m *Model) SetMetadataReader(r metadata.Reader) {
+	m.metadata = r
+	if m.lib != nil {
+		m.lib.SetMetadataReader(r)
+	}
+}
+
+// toggleBrowser switches the left pane between the library and the file
+// browser. If ~/VGM was missing at startup, the library is created and
+// scanned the first time it is switched to.
+func (m *Model) toggleBrowser() tea.Cmd {
+	var cmds []tea.Cmd
+	if !m.useLibrary && m.lib == nil {
+		info, err := os.Stat(m.libraryRoot)
+		if m.libraryRoot == "" || err != nil || !info.IsDir() {
+			m.lastError = "No library found at " + m.libraryRoot
+			m.errorTime = time.Now()
+			return nil
+		}
+		m.lib, m.libBrowser = newLibrary(m.libraryRoot, m.config, m.metadata, m.favorites, m.plays)
+		m.applyTheme(m.theme)
+		cmds = append(cmds, m.libBrowser.Init())
+		if m.config.WatchLibrary && m.watcher == nil {
+			m.watcher = library.NewWatcher(m.lib.Root(), watchInterval, watchSettle)
+			cmds = append(cmds, listenForLibraryChanges(m.watcher))
+		}
+	}
+	if m.useLibrary && !m.browserStarted {
+		m.browserStarted = true
+		cmds = append(cmds, m.browser.Init())
+	}
+
+	m.useLibrary = !m.useLibrary
+	if m.focus == FocusBrowser {
+		if m.useLibrary {
+			m.browser.Blur()
+			m.libBrowser.Focus()
+		} else {
+			m.libBrowser.Blur()
+			m.browser.Focus()
+		}
+	}
+	m.resize()
 	return tea.Batch(cmds...)
 }
 
+// clampLibraryPercent returns the library pane's share of the width
+// within its bounds, or the default share for zero.
+func clampLibraryPercent(percent int) int {
+	if percent == 0 {
+		return libraryWidthPercent
+	}
+	return max(minLibraryWidthPercent, min(percent, maxLibraryWidthPercent))
+}
+
+// resizeLibrary grows (or, for a negative delta, shrinks) the library pane
+// by delta percent of the width, resizes the panels and saves the new
+// share to the config file.
+func (m *Model) resizeLibrary(delta int) tea.Cmd {
+	percent := clampLibraryPercent(m.libraryPercent + delta)
+	if percent == m.libraryPercent {
+		return nil
+	}
+	m.libraryPercent = percent
+	m.resize()
+
+	return m.updateConfig(func(c *config.Config) {
+		c.LibraryWidthPercent = percent
+	})
+}
+
+// toggleMute silences the output or restores the volume it had.
+func (m *Model) toggleMute() {
+	m.muted = !m.muted
+	m.applyVolume()
+}
+
+// applyPlaylistColumns shows the named columns in the playlist table.
+// Unknown names are skipped and reported in the error.
+func (m *Model) applyPlaylistColumns(names []string) error {
+	var columns []components.PlaylistColumn
+	var unknown []string
+	for _, name := range names {
+		c, ok := components.ParsePlaylistColumn(name)
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		columns = append(columns, c)
+	}
+	m.playlist.SetColumns(columns)
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("config: unknown playlist column: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// applyProgressStyle sets how the progress bar is drawn from the config.
+// ASCII mode always draws it in ASCII.
+func (m *Model) applyProgressStyle() error {
+	style, ok := components.ParseProgressStyle(m.config.ProgressStyle)
+	if m.ascii {
+		style = components.ProgressASCII
+	}
+	m.progress.SetBarStyle(style)
+	if !ok {
+		return fmt.Errorf("config: unknown progress style: %s", m.config.ProgressStyle)
+	}
+	return nil
+}
+
+// SetTheme switches to theme without saving it to the config, e.g. for a
+// theme chosen on the command line.
+func (m *Model) SetTheme(theme Theme) {
+	m.applyTheme(theme)
+}
+
+// SetASCII sets whether the UI is drawn with ASCII characters only, for
+// terminals and fonts without box drawing or block characters.
+func (m *Model) SetASCII(ascii bool) {
+	m.ascii = ascii
+	m.applyTheme(m.theme)
+	m.applyProgressStyle()
+}
+
+// toggleEndFade switches between fading looping tracks out after their
+// last loop and cutting them off there.
+func (m *Model) toggleEndFade() {
+	m.endCut = !m.endCut
+	if m.audioPlayer != nil {
+		m.audioPlayer.SetEndFade(!m.endCut)
+	}
+	m.notice = "Fade out after the last loop"
+	if m.endCut {
+		m.notice = "Cut off after the last loop"
+	}
+	m.noticeTime = time.Now()
+}
+
+// applyVolume sets the player to the volume, or to silence while muted.
+func (m *Model) applyVolume() {
+	if m.audioPlayer != nil {
+		m.audioPlayer.SetVolume(m.outputVolume())
+	}
+}
+
+// outputVolume returns the volume actually played at.
+func (m Model) outputVolume() float64 {
+	if m.muted {
+		return 0
+	}
+	return m.volume
+}
+
+// showVolume shows the volume slider in the status line and returns a
+// command that redraws once it should disappear.
+func (m *Model) showVolume() tea.Cmd {
+	m.volumeTime = time.Now()
+	return tea.Tick(volumeShowTime, func(time.Time) tea.Msg {
+		return hideVolumeMsg{}
+	})
+}
+
+// updateConfig applies change to the config and returns a command that
+// saves it. The change is made to the config file as it is on disk, so
+// settings overridden for this run only, such as on the command line, stay
+// out of it.
+func (m *Model) updateConfig(change func(*config.Config)) tea.Cmd {
+	change(&m.config)
+	return func() tea.Msg {
+		cfg, err := config.Load()
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+		change(&cfg)
+		if err := cfg.Save(); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// How often the library is checked for file changes, and how long files
+// must stay unchanged before a rescan.
+const (
+	watchInterval = 2 * time.Second
+	watchSettle   = 2 * time.Second
+)
+
+// toggleWatching starts or stops watching the library for file changes.
+func (m *Model) toggleWatching() tea.Cmd {
+	if m.watcher != nil {
+		m.stopWatching()
+		m.notice = "Stopped watching " + m.lib.Root()
+		m.noticeTime = time.Now()
+		return nil
+	}
+	m.watcher = library.NewWatcher(m.lib.Root(), watchInterval, watchSettle)
+	m.notice = "Watching " + m.lib.Root() + " for changes"
+	m.noticeTime = time.Now()
+	return listenForLibraryChanges(m.watcher)
+}
+
+// stopWatching stops the library watcher, if any.
+func (m *Model) stopWatching() {
+	if m.watcher != nil {
+		m.watcher.Close()
+		m.watcher = nil
+	}
+}
+
+// listenForLibraryChanges returns a command that waits for the watcher to
+// report a library change. It returns nil once the watcher is closed.
+func listenForLibraryChanges(w *library.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-w.Changes(); !ok {
+			return nil
+		}
+		return LibraryChangedMsg{Watcher: w}
+	}
+}
+
 // listenForPlayback returns a command that listens for playback info updates.
 func listenForPlayback(sub <-chan player.PlaybackInfo) tea.Cmd {
 	return func() tea.Msg {
@@ -241,6 +939,6 @@ func (m Model) HasPlayer() bool {
 }
 
 // ChipInfo returns the chip information for the current track.
-func (m Model) ChipInfo() []player.ChipInfo {
+func (m Model) ChipInfo() []metadata.ChipInfo {
 	return m.trackChips
 }
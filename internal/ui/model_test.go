@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/config"
+)
+
+// newTestModel returns a model without an audio player or library, with
+// its state and config directories in a temporary directory.
+func newTestModel(t *testing.T) Model {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("XDG_STATE_HOME", home)
+	cfg := config.Default()
+	cfg.FreshPlaylist = true
+	next, _ := NewWithConfig(nil, cfg).Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return next.(Model)
+}
+
+// press sends the first key of a binding to the model.
+func press(m Model, b key.Binding) Model {
+	next, _ := m.Update(keyMsg(b.Keys()[0]))
+	return next.(Model)
+}
+
+// keyMsg returns the key message for a key as bindings name it, such as
+// "a", "alt+f" or "ctrl+g".
+func keyMsg(k string) tea.KeyMsg {
+	for t := tea.KeyType(-100); t < 200; t++ {
+		if t != tea.KeyRunes && (tea.Key{Type: t}).String() == k {
+			return tea.KeyMsg{Type: t}
+		}
+	}
+	alt := false
+	if rest, ok := strings.CutPrefix(k, "alt+"); ok {
+		alt, k = true, rest
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k), Alt: alt}
+}
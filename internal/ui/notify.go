@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Track change notification styles, as set in the config.
+const (
+	notifyDesktop = "desktop" // Desktop notification via notify-send
+	notifyFooter  = "footer"  // Banner in the footer
+)
+
+// announceTrack tells the user a new track started, as configured: with a
+// desktop notification or a banner in the footer. It returns the command
+// that sends the desktop notification, if any.
+func (m *Model) announceTrack() tea.Cmd {
+	if m.currentTrack == nil {
+		return nil
+	}
+	track := *m.currentTrack
+	details := []string{track.GameName(m.gameLabel), track.System}
+
+	switch m.config.TrackNotifications {
+	case notifyFooter:
+		m.notice = "Now playing: " + joinNonEmpty(append([]string{track.Title}, details...), " - ")
+		m.noticeTime = time.Now()
+	case notifyDesktop:
+		body := joinNonEmpty(details, "\n")
+		return func() tea.Msg {
+			// Fire and forget: a missing notify-send is not worth an error
+			// on every track
+			cmd := exec.Command("notify-send", "--app-name=vgmtui", track.Title, body)
+			if err := cmd.Start(); err == nil {
+				go cmd.Wait()
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// joinNonEmpty joins the non-empty strings with sep.
+func joinNonEmpty(parts []string, sep string) string {
+	var kept []string
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -0,0 +1,46 @@
+package ui
+
+import "time"
+
+// NowPlaying is a snapshot of playback for observers outside the TUI,
+// such as desktop media integrations.
+type NowPlaying struct {
+	State    PlayState
+	Track    *Track // nil when nothing is loaded
+	Position time.Duration
+	Duration time.Duration
+	Volume   float64
+}
+
+// Observer receives playback snapshots after every player tick and when
+// playback stops. It is called from the update loop, so it must not block.
+type Observer func(NowPlaying)
+
+// AddObserver adds a function notified of playback changes. Observers
+// drive the player by sending the exported messages (PlayPauseMsg,
+// NextTrackMsg, SeekMsg, ...) to the running program.
+func (m *Model) AddObserver(o Observer) {
+	m.observers = append(m.observers, o)
+}
+
+// notifyObserver sends the current playback snapshot to the observers.
+func (m Model) notifyObserver() {
+	if len(m.observers) == 0 {
+		return
+	}
+	var track *Track
+	if m.currentTrack != nil {
+		t := *m.currentTrack
+		track = &t
+	}
+	np := NowPlaying{
+		State:    m.playback.State,
+		Track:    track,
+		Position: m.playback.Position,
+		Duration: m.playback.Duration,
+		Volume:   m.outputVolume(),
+	}
+	for _, o := range m.observers {
+		o(np)
+	}
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+	"github.com/dewi-tim/vgmtui/internal/ui/components"
+)
+
+// OpenPaths sets files and directories to add to the playlist at startup.
+// Directories are searched recursively for VGM files; playback starts with
+// the first track found.
+func (m *Model) OpenPaths(paths []string) {
+	m.openPaths = paths
+}
+
+// openTracks expands paths and reads the metadata of every file found,
+// keeping the order in which the paths were given.
+func openTracks(r metadata.Reader, paths []string) tea.Cmd {
+	return func() tea.Msg {
+		var msg OpenedTracksMsg
+		var files []string
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil {
+				if msg.Err == nil {
+					msg.Err = fmt.Errorf("open %s: %w", path, err)
+				}
+				continue
+			}
+			if !info.IsDir() {
+				files = append(files, path)
+				continue
+			}
+			found, err := components.FindVGMFiles(path, false)
+			if err != nil && msg.Err == nil {
+				msg.Err = fmt.Errorf("open %s: %w", path, err)
+			}
+			files = append(files, found...)
+		}
+		msg.Tracks, msg.Failed = readTracks(r, files)
+		return msg
+	}
+}
+
+// focusPlaylist moves focus from the browser to the playlist.
+func (m *Model) focusPlaylist() {
+	if m.focus == FocusPlaylist {
+		return
+	}
+	m.focus = FocusPlaylist
+	if m.useLibrary {
+		m.libBrowser.Blur()
+	} else {
+		m.browser.Blur()
+	}
+	m.playlist.Focus()
+}
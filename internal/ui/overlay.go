@@ -0,0 +1,63 @@
+package ui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// overlay is a popup drawn over the main view. While it is visible it
+// takes every key.
+type overlay interface {
+	Visible() bool
+	View() string
+	update(msg tea.Msg) tea.Cmd
+}
+
+// popup is the component side of an overlay: a bubbletea-style model
+// whose Update returns its updated value.
+type popup[T any] interface {
+	Visible() bool
+	View() string
+	Update(msg tea.Msg) (T, tea.Cmd)
+}
+
+// popupOverlay adapts a popup held by the model to overlay, updating it in
+// place.
+type popupOverlay[T popup[T]] struct {
+	p *T
+}
+
+func (o popupOverlay[T]) Visible() bool { return (*o.p).Visible() }
+func (o popupOverlay[T]) View() string  { return (*o.p).View() }
+
+func (o popupOverlay[T]) update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	*o.p, cmd = (*o.p).Update(msg)
+	return cmd
+}
+
+// asOverlay returns the overlay for the popup at p.
+func asOverlay[T popup[T]](p *T) overlay {
+	return popupOverlay[T]{p}
+}
+
+// activeOverlay returns the visible overlay, or nil if none is. Should
+// several be visible, the first listed is shown and gets the keys.
+func (m *Model) activeOverlay() overlay {
+	overlays := []overlay{
+		asOverlay(&m.helpPopup),
+		asOverlay(&m.chipPopup),
+		asOverlay(&m.diffPopup),
+		asOverlay(&m.audioPopup),
+		asOverlay(&m.historyPopup),
+		asOverlay(&m.statsPopup),
+		asOverlay(&m.keyBindings),
+		asOverlay(&m.palette),
+		asOverlay(&m.chipPicker),
+		asOverlay(&m.dupPopup),
+		asOverlay(&m.tagEditor),
+	}
+	for _, o := range overlays {
+		if o.Visible() {
+			return o
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/dewi-tim/vgmtui/internal/ui/components"
+)
+
+func TestActiveOverlay(t *testing.T) {
+	m := newTestModel(t)
+	m.playlist.AddTracks([]components.Track{{Path: "/vgm/a.vgm"}, {Path: "/vgm/b.vgm"}})
+	m.focus = FocusPlaylist
+	if o := m.activeOverlay(); o != nil {
+		t.Fatalf("overlay %T active with no popup open", o)
+	}
+	view := m.View()
+
+	// Open popups are stacked: the first listed takes the keys and is shown
+	m = press(m, m.keyMap.AudioConfig)
+	m.palette.Show(nil)
+	steps := []struct {
+		key                string
+		audioOpen, palOpen bool
+	}{
+		{"j", true, true},    // Ignored by the audio popup, listed first
+		{"esc", false, true}, // Closes the audio popup
+		{"j", false, true},   // Typed into the palette's filter
+		{"esc", false, false},
+	}
+	for _, step := range steps {
+		top := m.palette.View()
+		if m.audioPopup.Visible() {
+			top = m.audioPopup.View()
+		}
+		if o := m.activeOverlay(); o == nil || o.View() != top {
+			t.Fatalf("before %s: the popup on top is not the active overlay", step.key)
+		}
+		next, _ := m.Update(keyMsg(step.key))
+		m = next.(Model)
+		if m.audioPopup.Visible() != step.audioOpen || m.palette.Visible() != step.palOpen {
+			t.Errorf("after %s: audio open %v, palette open %v; want %v, %v",
+				step.key, m.audioPopup.Visible(), m.palette.Visible(), step.audioOpen, step.palOpen)
+		}
+	}
+	if m.playlist.SelectedIndex() != 0 {
+		t.Errorf("keys for popups moved the playlist cursor to %d", m.playlist.SelectedIndex())
+	}
+	if m.activeOverlay() != nil || m.View() != view {
+		t.Errorf("view with all popups closed differs from before they opened")
+	}
+}
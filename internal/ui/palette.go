@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/player"
+	"github.com/dewi-tim/vgmtui/internal/ui/components"
+)
+
+type (
+	// SetLoopCountMsg sets how many times looping tracks play their loop
+	// (0 = forever).
+	SetLoopCountMsg struct {
+		Count int
+	}
+
+	// SetThemeMsg switches to the named built-in theme.
+	SetThemeMsg struct {
+		Name string
+	}
+
+	// SavePlaylistMsg saves the playlist to the state directory now.
+	SavePlaylistMsg struct{}
+
+	// ToggleTagLanguageMsg switches tags between English and their
+	// original language.
+	ToggleTagLanguageMsg struct{}
+)
+
+// paletteLoopCounts are the loop counts offered by the command palette.
+var paletteLoopCounts = []int{1, 2, 3, 4, 0}
+
+// paletteCommands returns the actions offered by the command palette. New
+// commands only need an entry here: either a message the model already
+// handles, or the key press of a global binding via bindingMsg.
+func (m Model) paletteCommands() []components.PaletteCommand {
+	k := m.keyMap
+	bound := func(name string, b key.Binding) components.PaletteCommand {
+		return components.PaletteCommand{Name: name, Key: b.Help().Key, Msg: bindingMsg(b)}
+	}
+
+	commands := []components.PaletteCommand{
+		{Name: "Play/pause", Key: k.PlayPause.Help().Key, Msg: PlayPauseMsg{}},
+		{Name: "Next track", Key: k.NextTrack.Help().Key, Msg: NextTrackMsg{}},
+		{Name: "Previous track", Key: k.PrevTrack.Help().Key, Msg: PrevTrackMsg{}},
+		{Name: "Stop", Key: k.Stop.Help().Key, Msg: StopMsg{}},
+		bound("Fade out and stop", k.FadeStop),
+		bound("Restart track", k.Restart),
+		bound("Fade/cut after the last loop", k.EndFade),
+		bound("Per-loop/total progress", k.LoopProgress),
+		bound("Mute/unmute", k.Mute),
+		bound("Shuffle play: off/shuffle/smart", k.ShufflePlay),
+		bound("Radio mode on/off", k.Radio),
+		bound("Sleep timer", k.SleepTimer),
+		{Name: "Save playlist", Msg: SavePlaylistMsg{}},
+		bound("Export session", k.ExportSession),
+		bound("Import session", k.ImportSession),
+		bound("Rescan library", k.Rescan),
+		bound("Show library changes", k.LibraryDiff),
+		bound("Watch library on/off", k.WatchLibrary),
+		bound("Library statistics", k.LibraryStats),
+		bound("Switch library/file browser", k.SwitchBrowser),
+		bound("Recently played", k.History),
+		bound("Chip info", k.ChipInfo),
+		bound("Full track info", k.FullInfo),
+		bound("Audio configuration", k.AudioConfig),
+		bound("Oscilloscope on/off", k.Scope),
+		bound("VU meters on/off", k.Meters),
+		bound("Audio diagnostics on/off", k.Diagnostics),
+		bound("Game names from GD3/directory", k.GameLabel),
+		{Name: "Tags in English/original language", Msg: ToggleTagLanguageMsg{}},
+		bound("Next theme", k.CycleTheme),
+		bound("Key bindings", k.KeyBindings),
+	}
+	for _, name := range ThemeNames() {
+		commands = append(commands, components.PaletteCommand{
+			Name: "Theme: " + name,
+			Msg:  SetThemeMsg{Name: name},
+		})
+	}
+	for _, n := range paletteLoopCounts {
+		label := fmt.Sprintf("Loop count: %d", n)
+		if n == 0 {
+			label = "Loop count: forever"
+		}
+		commands = append(commands, components.PaletteCommand{
+			Name: label,
+			Msg:  SetLoopCountMsg{Count: n},
+		})
+	}
+	for _, b := range paletteBuffers {
+		label := fmt.Sprintf("Audio buffers: %v", b)
+		if b == player.DefaultBuffers() {
+			label += " (default)"
+		}
+		commands = append(commands, components.PaletteCommand{
+			Name: label,
+			Msg:  SetBuffersMsg{Buffers: b},
+		})
+	}
+	return append(commands,
+		bound("Help", k.Help),
+		components.PaletteCommand{Name: "Quit", Key: k.Quit.Help().Key, Msg: QuitMsg{}},
+	)
+}
+
+// bindingMsg returns the key press of a binding's first key, so a palette
+// command runs exactly what pressing the key does.
+func bindingMsg(b key.Binding) tea.Msg {
+	k := b.Keys()[0]
+	if c, ok := strings.CutPrefix(k, "ctrl+"); ok && len(c) == 1 && c[0] >= 'a' && c[0] <= 'z' {
+		return tea.KeyMsg{Type: tea.KeyCtrlA + tea.KeyType(c[0]-'a')}
+	}
+	if k == " " {
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// setLoopCount applies a loop count chosen in the command palette.
+func (m *Model) setLoopCount(count int) {
+	if m.audioPlayer != nil {
+		m.audioPlayer.SetLoopCount(count)
+	}
+	m.playback.TotalLoops = count
+	m.notice = fmt.Sprintf("Loop count: %d", count)
+	if count == 0 {
+		m.notice = "Loop count: forever"
+	}
+	m.noticeTime = time.Now()
+}
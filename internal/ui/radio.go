@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"math/rand"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// radioAhead is how many tracks radio mode keeps queued after the one
+// playing, so there is always a next track.
+const radioAhead = 3
+
+// toggleRadio turns radio mode on or off. Turning it on fills the queue
+// with random library tracks and starts playing if nothing is.
+func (m *Model) toggleRadio() tea.Cmd {
+	if !m.radio && (!m.useLibrary || m.lib == nil || m.lib.TrackCount() == 0) {
+		m.lastError = "Radio mode needs a library with tracks"
+		m.errorTime = time.Now()
+		return nil
+	}
+
+	m.radio = !m.radio
+	if !m.radio {
+		return nil
+	}
+
+	m.fillRadio()
+	if m.playback.State == StateStopped && !m.trackLoading {
+		if next := m.playlist.PeekNextTrack(); next >= 0 {
+			return m.startPlayingTrack(next)
+		}
+	}
+	return nil
+}
+
+// fillRadio tops up the queue with random library tracks until radioAhead
+// tracks follow the current one. It does nothing outside radio mode.
+func (m *Model) fillRadio() {
+	if !m.radio || m.lib == nil {
+		return
+	}
+	tracks := m.lib.AllTracks()
+	if len(tracks) == 0 {
+		return
+	}
+
+	// Tracks queued after the current one (all of them if none is current)
+	ahead := m.playlist.Len() - 1 - m.playlist.CurrentIndex()
+	var last string
+	if n := m.playlist.Len(); n > 0 {
+		last = m.playlist.GetTrack(n - 1).Path
+	}
+
+	for ; ahead < radioAhead; ahead++ {
+		i := rand.Intn(len(tracks))
+		// Avoid playing the same track twice in a row: pick any other
+		if tracks[i].Path == last && len(tracks) > 1 {
+			i = (i + 1 + rand.Intn(len(tracks)-1)) % len(tracks)
+		}
+		t := tracks[i]
+		m.playlist.AddTrack(libraryTrack(t))
+		last = t.Path
+	}
+}
+
+// metadataTrack converts the metadata read from the file at path to a
+// playlist track, titled by its file name if it has no title.
+func metadataTrack(path string, t metadata.Track) Track {
+	return Track{
+		Path:     path,
+		Title:    defaultString(t.Title, filepath.Base(path)),
+		Game:     t.Game,
+		DirGame:  library.DirGameName(path),
+		System:   t.System,
+		Composer: t.Composer,
+		Duration: t.Duration,
+	}
+}
+
+// libraryTrack converts a library track to a playlist track.
+func libraryTrack(t library.Track) Track {
+	return Track{
+		Path:        t.Path,
+		Title:       t.Title,
+		Game:        t.Game,
+		DirGame:     t.DirGame,
+		System:      t.System,
+		Composer:    t.Composer,
+		Duration:    t.Duration,
+		TrackNumber: t.TrackNumber,
+	}
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// withTestLibrary gives the model a scanned library of n tracks.
+func withTestLibrary(t *testing.T, m Model, n int) Model {
+	t.Helper()
+	root := t.TempDir()
+	for i := range n {
+		path := filepath.Join(root, fmt.Sprintf("%02d.vgm", i+1))
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lib := library.New(root, metadata.ReaderFunc(func(path string) (metadata.Track, error) {
+		return metadata.Track{Path: path, Title: filepath.Base(path)}, nil
+	}))
+	if _, err := lib.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	m.lib = lib
+	m.useLibrary = true
+	return m
+}
+
+// checkRadioAhead fails unless radioAhead tracks follow the current one
+// and, with more than one track to pick from, none of the tracks queued
+// from index added on repeats the one before it.
+func checkRadioAhead(t *testing.T, m Model, step string, added int, distinct bool) {
+	t.Helper()
+	if ahead := m.playlist.Len() - 1 - m.playlist.CurrentIndex(); ahead < radioAhead {
+		t.Fatalf("%s: %d tracks queued after the current one, want at least %d", step, ahead, radioAhead)
+	}
+	if !distinct {
+		return
+	}
+	tracks := m.playlist.Tracks()
+	for i := max(added, 1); i < len(tracks); i++ {
+		if tracks[i].Path == tracks[i-1].Path {
+			t.Fatalf("%s: %s queued twice in a row", step, filepath.Base(tracks[i].Path))
+		}
+	}
+}
+
+func TestRadioNeedsLibrary(t *testing.T) {
+	m := newTestModel(t)
+	m.toggleRadio()
+	if m.radio || m.lastError == "" || !m.playlist.IsEmpty() {
+		t.Errorf("radio without a library: on %v, error %q, %d tracks queued", m.radio, m.lastError, m.playlist.Len())
+	}
+
+	m = withTestLibrary(t, newTestModel(t), 0)
+	m.toggleRadio()
+	if m.radio {
+		t.Errorf("radio turned on with an empty library")
+	}
+}
+
+func TestRadioAlwaysHasNext(t *testing.T) {
+	for _, size := range []int{1, 2, 10} {
+		m := withTestLibrary(t, newTestModel(t), size)
+		distinct := size > 1
+		m.toggleRadio()
+		if !m.radio {
+			t.Fatalf("%d tracks: radio did not turn on (%s)", size, m.lastError)
+		}
+		if m.playlist.Len() != radioAhead {
+			t.Errorf("%d tracks: turning radio on queued %d tracks, want %d", size, m.playlist.Len(), radioAhead)
+		}
+		checkRadioAhead(t, m, "turned on", 0, distinct)
+
+		for i := range 8 {
+			// Play through the queue
+			m.playlist.SetCurrentTrack(m.playlist.CurrentIndex() + 1)
+			added := m.playlist.Len()
+			next, _ := m.Update(TrackEndedMsg{})
+			m = next.(Model)
+			checkRadioAhead(t, m, fmt.Sprintf("%d tracks, track %d ended", size, i), added, distinct)
+
+			// Remove the track after the current one from the queue
+			m.playlist.GoToIndex(m.playlist.CurrentIndex() + 1)
+			m.playlist.RemoveSelected()
+			added = m.playlist.Len()
+			m.fillRadio()
+			checkRadioAhead(t, m, fmt.Sprintf("%d tracks, removal %d", size, i), added, distinct)
+		}
+
+		// Turned off, the queue is left to run out
+		m.toggleRadio()
+		n := m.playlist.Len()
+		m.playlist.SetCurrentTrack(n - 1)
+		m.fillRadio()
+		if m.radio || m.playlist.Len() != n {
+			t.Errorf("%d tracks: radio off still queued tracks (%d to %d)", size, n, m.playlist.Len())
+		}
+	}
+}
+
+func TestMetadataTrack(t *testing.T) {
+	const path = "/vgm/Sonic 1 rip/01 Green Hill Zone.vgz"
+	tests := []struct {
+		meta metadata.Track
+		want Track
+	}{
+		{
+			metadata.Track{
+				Path: "/elsewhere.vgz", Title: "Green Hill Zone", Game: "Sonic the Hedgehog",
+				System: "Sega Mega Drive", Composer: "Masato Nakamura", Duration: 90 * time.Second,
+			},
+			Track{
+				Path: path, Title: "Green Hill Zone", Game: "Sonic the Hedgehog", DirGame: "Sonic 1 rip",
+				System: "Sega Mega Drive", Composer: "Masato Nakamura", Duration: 90 * time.Second,
+			},
+		},
+		// Untagged: titled by the file name
+		{metadata.Track{}, Track{Path: path, Title: "01 Green Hill Zone.vgz", DirGame: "Sonic 1 rip"}},
+	}
+	for _, tt := range tests {
+		if got := metadataTrack(path, tt.meta); got != tt.want {
+			t.Errorf("metadataTrack(%+v) =\n%+v\nwant\n%+v", tt.meta, got, tt.want)
+		}
+	}
+}
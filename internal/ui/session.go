@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// sessionFileName is the file sessions are exported to and imported from,
+// in the file browser's current directory.
+const sessionFileName = "vgmtui-session.json"
+
+// sessionVersion is the version written to exported session files.
+const sessionVersion = 1
+
+// Session is a shareable snapshot of the queue, the playing track and its
+// position, and the view settings.
+type Session struct {
+	Version    int             `json:"version"`
+	Queue      []string        `json:"queue"`
+	Current    int             `json:"current"` // Index in Queue (-1 if nothing playing)
+	PositionMs int64           `json:"position_ms"`
+	Settings   SessionSettings `json:"settings"`
+}
+
+// SessionSettings holds the settings saved with a session.
+type SessionSettings struct {
+	Volume       float64 `json:"volume"`
+	GameLabel    string  `json:"game_label"` // "GD3" or "directory"
+	CompactChips bool    `json:"compact_chips,omitempty"`
+}
+
+// ImportSession sets a session file to restore at startup.
+func (m *Model) ImportSession(path string) {
+	m.sessionPath = path
+}
+
+// session captures the current state as a Session.
+func (m Model) session() Session {
+	tracks := m.playlist.Tracks()
+	s := Session{
+		Version: sessionVersion,
+		Queue:   make([]string, len(tracks)),
+		Current: m.playlist.CurrentIndex(),
+		Settings: SessionSettings{
+			Volume:       m.volume,
+			GameLabel:    m.gameLabel.String(),
+			CompactChips: m.compactChips,
+		},
+	}
+	for i, t := range tracks {
+		s.Queue[i] = t.Path
+	}
+	if s.Current >= 0 {
+		s.PositionMs = m.playback.Position.Milliseconds()
+	}
+	return s
+}
+
+// sessionFile returns the path sessions are exported to and imported from.
+func (m Model) sessionFile() string {
+	return filepath.Join(m.browser.CurrentDir(), sessionFileName)
+}
+
+// exportSession writes s to path as indented JSON.
+func exportSession(s Session, path string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err == nil {
+			err = os.WriteFile(path, append(data, '\n'), 0o644)
+		}
+		return SessionExportedMsg{Path: path, Err: err}
+	}
+}
+
+// importSession reads a session file and the metadata of its queue.
+// Tracks that can no longer be read are dropped and the current index is
+// adjusted to match.
+func importSession(r metadata.Reader, path string) tea.Cmd {
+	return func() tea.Msg {
+		msg := SessionImportedMsg{Path: path, Current: -1}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		var s Session
+		if err := json.Unmarshal(data, &s); err != nil {
+			msg.Err = fmt.Errorf("%s: %w", path, err)
+			return msg
+		}
+		if s.Version > sessionVersion {
+			msg.Err = fmt.Errorf("%s: unsupported session version %d", path, s.Version)
+			return msg
+		}
+
+		for i, p := range s.Queue {
+			tracks, failed := readTracks(r, []string{p})
+			msg.Failed += failed
+			if failed > 0 {
+				continue
+			}
+			if i == s.Current {
+				msg.Current = len(msg.Tracks)
+			}
+			msg.Tracks = append(msg.Tracks, tracks...)
+		}
+		if msg.Current >= 0 {
+			msg.Position = time.Duration(s.PositionMs) * time.Millisecond
+		}
+		msg.Settings = s.Settings
+		return msg
+	}
+}
+
+// applySession replaces the queue and settings with an imported session
+// and resumes its track. Returns the command to start playback, if any.
+func (m *Model) applySession(msg SessionImportedMsg) tea.Cmd {
+	m.stopPlayback()
+	m.playlist.Clear()
+	m.playlist.AddTracks(msg.Tracks)
+
+	if v := msg.Settings.Volume; v > 0 {
+		m.volume = v
+		m.muted = false
+		m.applyVolume()
+	}
+	m.gameLabel = library.GameLabelGD3
+	if msg.Settings.GameLabel == library.GameLabelDir.String() {
+		m.gameLabel = library.GameLabelDir
+	}
+	m.libBrowser.SetGameLabel(m.gameLabel)
+	m.playlist.SetGameLabel(m.gameLabel)
+	m.compactChips = msg.Settings.CompactChips
+
+	if msg.Current < 0 || m.audioPlayer == nil || m.trackLoading {
+		return nil
+	}
+	m.playlist.GoToIndex(msg.Current)
+	m.resumeAt = msg.Position
+	return m.startPlayingTrack(msg.Current)
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+)
+
+// queuePaths returns the paths of the model's queue, in play order.
+func queuePaths(m Model) []string {
+	var paths []string
+	for _, t := range m.playlist.Tracks() {
+		paths = append(paths, t.Path)
+	}
+	return paths
+}
+
+func TestSessionRoundTrip(t *testing.T) {
+	queue := []string{"/vgm/a.vgm", "/vgm/b.vgm", "/vgm/c.vgm", "/vgm/d.vgm"}
+	settings := SessionSettings{Volume: 0.6, GameLabel: "directory", CompactChips: true}
+
+	tests := []struct {
+		name     string
+		current  int      // Playing when exported, -1 for none
+		gone     []string // Files no longer readable on import
+		queue    []string // Queue after import
+		playing  int      // Current index after import
+		position time.Duration
+	}{
+		{"all readable", 2, nil, queue, 2, 75 * time.Second},
+		{"nothing playing", -1, nil, queue, -1, 0},
+		{"earlier track gone", 2, []string{"/vgm/a.vgm"}, queue[1:], 1, 75 * time.Second},
+		{"later track gone", 2, []string{"/vgm/d.vgm"}, queue[:3], 2, 75 * time.Second},
+		{"playing track gone", 2, []string{"/vgm/c.vgm"}, []string{"/vgm/a.vgm", "/vgm/b.vgm", "/vgm/d.vgm"}, -1, 0},
+	}
+	for _, tt := range tests {
+		m := newTestModel(t)
+		for _, p := range queue {
+			m.playlist.AddTrack(Track{Path: p, Title: filepath.Base(p)})
+		}
+		m.playlist.SetCurrentTrack(tt.current)
+		m.playback.Position = 75 * time.Second
+		m.volume = settings.Volume
+		m.gameLabel = library.GameLabelDir
+		m.compactChips = settings.CompactChips
+
+		path := filepath.Join(t.TempDir(), sessionFileName)
+		if msg := exportSession(m.session(), path)().(SessionExportedMsg); msg.Err != nil || msg.Path != path {
+			t.Fatalf("%s: export = %+v", tt.name, msg)
+		}
+
+		reader := metadata.ReaderFunc(func(p string) (metadata.Track, error) {
+			if slices.Contains(tt.gone, p) {
+				return metadata.Track{}, errors.New("gone")
+			}
+			return metadata.Track{Path: p, Title: strings.ToUpper(filepath.Base(p))}, nil
+		})
+		imported := importSession(reader, path)().(SessionImportedMsg)
+		if imported.Err != nil {
+			t.Fatalf("%s: import failed: %v", tt.name, imported.Err)
+		}
+		if imported.Failed != len(tt.gone) || imported.Current != tt.playing || imported.Position != tt.position {
+			t.Errorf("%s: imported %d failed, current %d at %v; want %d, %d at %v", tt.name,
+				imported.Failed, imported.Current, imported.Position, len(tt.gone), tt.playing, tt.position)
+		}
+		if imported.Settings != settings {
+			t.Errorf("%s: imported settings %+v, want %+v", tt.name, imported.Settings, settings)
+		}
+
+		// Applied to a fresh model, the queue and settings come back
+		restored := newTestModel(t)
+		restored.playlist.AddTrack(Track{Path: "/vgm/old.vgm"})
+		next, _ := restored.Update(imported)
+		restored = next.(Model)
+		if got := queuePaths(restored); !slices.Equal(got, tt.queue) {
+			t.Errorf("%s: restored queue %q, want %q", tt.name, got, tt.queue)
+		}
+		if first := restored.playlist.GetTrack(0); first == nil || first.Title != strings.ToUpper(filepath.Base(tt.queue[0])) {
+			t.Errorf("%s: restored tracks lack their metadata: %+v", tt.name, first)
+		}
+		if restored.volume != settings.Volume || restored.gameLabel != library.GameLabelDir || !restored.compactChips {
+			t.Errorf("%s: restored volume %v, game label %v, compact chips %v", tt.name,
+				restored.volume, restored.gameLabel, restored.compactChips)
+		}
+		if (restored.lastError != "") != (len(tt.gone) > 0) {
+			t.Errorf("%s: error after import %q with %d files gone", tt.name, restored.lastError, len(tt.gone))
+		}
+	}
+}
+
+func TestImportSessionErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	tests := map[string]string{
+		"missing": filepath.Join(dir, "missing.json"),
+		"invalid": write("invalid.json", "{"),
+		"future":  write("future.json", `{"version": 2, "queue": ["/vgm/a.vgm"]}`),
+	}
+	reader := metadata.ReaderFunc(func(p string) (metadata.Track, error) {
+		return metadata.Track{Path: p}, nil
+	})
+	for name, path := range tests {
+		msg := importSession(reader, path)().(SessionImportedMsg)
+		if msg.Err == nil || len(msg.Tracks) != 0 {
+			t.Errorf("%s: imported %d tracks, error %v; want an error", name, len(msg.Tracks), msg.Err)
+		}
+
+		// A failed import leaves the queue alone
+		m := newTestModel(t)
+		m.playlist.AddTrack(Track{Path: "/vgm/kept.vgm"})
+		next, _ := m.Update(msg)
+		m = next.(Model)
+		if got := queuePaths(m); !slices.Equal(got, []string{"/vgm/kept.vgm"}) || m.lastError == "" {
+			t.Errorf("%s: queue after failed import %q, error %q", name, got, m.lastError)
+		}
+	}
+}
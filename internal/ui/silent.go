@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultSilentTrack is the duration below which a track is taken to be a
+// broken rip, unless the config sets another.
+const defaultSilentTrack = 500 * time.Millisecond
+
+// errSilentTrack is the reason a silent track was skipped.
+var errSilentTrack = errors.New("silent or zero-length track")
+
+// silentThreshold returns the duration below which a track is taken to be
+// a broken rip, or 0 if the check is off.
+func (m Model) silentThreshold() time.Duration {
+	switch ms := m.config.SilentTrackMs; {
+	case ms < 0:
+		return 0
+	case ms == 0:
+		return defaultSilentTrack
+	default:
+		return time.Duration(ms) * time.Millisecond
+	}
+}
+
+// isSilent reports whether a loaded track of duration d is a broken rip.
+func (m Model) isSilent(d time.Duration) bool {
+	return d < m.silentThreshold()
+}
+
+// nextAutoTrack returns the track auto-advance plays next, skipping
+// jingles shorter than the configured minimum and known silent tracks, or
+// -1 if there is none.
+func (m Model) nextAutoTrack() int {
+	minDur := time.Duration(m.config.MinTrackSeconds) * time.Second
+	return m.playlist.PeekNextTrackMin(minDur, m.silentThreshold())
+}
+
+// skipSilentTrack handles a track that loaded but turned out silent while
+// stepping through the playlist: its real duration is recorded so it is
+// skipped up front from now on, and the next track is tried as if it had
+// failed to load. It returns nil if the load was not part of an advance.
+func (m *Model) skipSilentTrack(d time.Duration) tea.Cmd {
+	if m.skipDir == 0 || m.pendingTrack == nil {
+		return nil
+	}
+	m.playlist.SetDuration(m.pendingPlayIndex, d)
+	m.audioPlayer.Stop()
+	return m.skipFailedTrack(errSilentTrack)
+}
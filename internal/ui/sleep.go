@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sleepPresets are the sleep timer lengths the sleep key cycles through
+// before turning the timer off again.
+var sleepPresets = []time.Duration{
+	15 * time.Minute,
+	30 * time.Minute,
+	45 * time.Minute,
+	60 * time.Minute,
+	90 * time.Minute,
+}
+
+type (
+	// SleepTimerMsg is sent when the sleep timer with the given sequence
+	// number runs out.
+	SleepTimerMsg struct {
+		Seq int
+	}
+
+	// SleepFadedMsg is sent when the fade-out started by the sleep timer
+	// with the given sequence number should be over.
+	SleepFadedMsg struct {
+		Seq int
+	}
+)
+
+// cycleSleepTimer sets the sleep timer to the next preset length, or off
+// after the longest. Each change bumps sleepSeq so ticks of replaced
+// timers are ignored.
+func (m *Model) cycleSleepTimer() tea.Cmd {
+	m.sleepSeq++
+	m.sleepFading = false
+	m.sleepPreset++
+	if m.sleepPreset >= len(sleepPresets) {
+		m.sleepPreset = -1
+		m.sleepAt = time.Time{}
+		m.notice = "Sleep timer off"
+		m.noticeTime = time.Now()
+		return nil
+	}
+
+	d := sleepPresets[m.sleepPreset]
+	m.sleepAt = time.Now().Add(d)
+	m.notice = fmt.Sprintf("Sleep timer: %s in %d minutes", m.sleepAction(), int(d.Minutes()))
+	m.noticeTime = time.Now()
+	seq := m.sleepSeq
+	return tea.Tick(d, func(time.Time) tea.Msg { return SleepTimerMsg{Seq: seq} })
+}
+
+// sleepAction returns what the sleep timer does when it runs out: "stop"
+// or "quit".
+func (m Model) sleepAction() string {
+	if m.config.SleepAction == "quit" {
+		return "quit"
+	}
+	return "stop"
+}
+
+// sleepTimerExpired fades out the playing track, then stops or quits. With
+// nothing playing, the action runs straight away.
+func (m *Model) sleepTimerExpired() tea.Cmd {
+	if m.audioPlayer == nil || m.playback.State != StatePlaying {
+		return m.finishSleep()
+	}
+	m.fadeOut()
+	m.sleepFading = true
+	return fadeWait(SleepFadedMsg{Seq: m.sleepSeq})
+}
+
+// finishSleep clears the sleep timer and stops playback or quits.
+func (m *Model) finishSleep() tea.Cmd {
+	m.sleepSeq++
+	m.sleepPreset = -1
+	m.sleepAt = time.Time{}
+	m.sleepFading = false
+	if m.sleepAction() == "quit" {
+		return func() tea.Msg { return QuitMsg{} }
+	}
+	return func() tea.Msg { return StopMsg{} }
+}
+
+// sleepRemaining returns the time left on the sleep timer, or 0 if off.
+func (m Model) sleepRemaining() time.Duration {
+	if m.sleepAt.IsZero() {
+		return 0
+	}
+	return max(time.Until(m.sleepAt), 0)
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"time"
+)
+
+// Step sizes of the seek and volume keys, unless the config sets others.
+const (
+	defaultSeekStep    = 5 * time.Second
+	defaultFarSeekStep = 30 * time.Second
+	defaultVolumeStep  = 10 // Percent
+)
+
+// seekStep returns how far the seek keys move playback.
+func (m Model) seekStep() time.Duration {
+	if s := m.config.SeekSeconds; s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return defaultSeekStep
+}
+
+// farSeekStep returns how far the far seek keys move playback.
+func (m Model) farSeekStep() time.Duration {
+	if s := m.config.FarSeekSeconds; s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return defaultFarSeekStep
+}
+
+// volumeStep returns how much the volume keys change the volume by.
+func (m Model) volumeStep() float64 {
+	percent := m.config.VolumeStepPercent
+	if percent <= 0 {
+		percent = defaultVolumeStep
+	}
+	return float64(percent) / 100
+}
+
+// seekBy moves playback by delta, or the shown position if there is no
+// audio player.
+func (m *Model) seekBy(delta time.Duration) {
+	if m.audioPlayer != nil {
+		m.audioPlayer.SeekRelative(delta)
+		return
+	}
+	m.playback.Position = max(0, min(m.playback.Position+delta, m.playback.Duration))
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Colors used throughout the UI.
+// Colors of the default theme.
 var (
 	// Primary colors
 	ColorPrimary   = lipgloss.Color("#7571F9")
@@ -52,88 +52,98 @@ type Styles struct {
 	ProgressTime   lipgloss.Style
 
 	// Footer/help styles
-	FooterKey  lipgloss.Style
-	FooterDesc lipgloss.Style
-	FooterSep  lipgloss.Style
+	FooterKey   lipgloss.Style
+	FooterDesc  lipgloss.Style
+	FooterSep   lipgloss.Style
+	FooterError lipgloss.Style
 }
 
 // DefaultStyles returns the default styles for the UI.
 func DefaultStyles() Styles {
+	return DefaultTheme().Styles()
+}
+
+// Styles builds the UI styles from the theme's palette.
+func (t Theme) Styles() Styles {
 	return Styles{
 		// Panel borders
 		FocusedBorder: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(ColorPrimary),
+			BorderStyle(t.border()).
+			BorderForeground(t.Primary),
 
 		NormalBorder: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(ColorMuted),
+			BorderStyle(t.border()).
+			BorderForeground(t.Muted),
 
 		// Titles
 		Title: lipgloss.NewStyle().
-			Foreground(ColorPrimary).
+			Foreground(t.Primary).
 			Bold(true),
 
 		TitleMuted: lipgloss.NewStyle().
-			Foreground(ColorTextMuted),
+			Foreground(t.TextMuted),
 
 		// Text
 		Text: lipgloss.NewStyle().
-			Foreground(ColorText),
+			Foreground(t.Text),
 
 		TextMuted: lipgloss.NewStyle().
-			Foreground(ColorTextMuted),
+			Foreground(t.TextMuted),
 
 		TextBold: lipgloss.NewStyle().
-			Foreground(ColorText).
+			Foreground(t.Text).
 			Bold(true),
 
 		TextHighlight: lipgloss.NewStyle().
-			Foreground(ColorPrimary).
+			Foreground(t.Primary).
 			Bold(true),
 
 		// Status indicators
 		StatusPlaying: lipgloss.NewStyle().
-			Foreground(ColorPlaying).
+			Foreground(t.Playing).
 			Bold(true),
 
 		StatusPaused: lipgloss.NewStyle().
-			Foreground(ColorPaused).
+			Foreground(t.Paused).
 			Bold(true),
 
 		StatusStopped: lipgloss.NewStyle().
-			Foreground(ColorStopped).
+			Foreground(t.Stopped).
 			Bold(true),
 
 		// Progress bar
 		ProgressFilled: lipgloss.NewStyle().
-			Foreground(ColorPrimary),
+			Foreground(t.Primary),
 
 		ProgressEmpty: lipgloss.NewStyle().
-			Foreground(ColorMuted),
+			Foreground(t.Muted),
 
 		ProgressTime: lipgloss.NewStyle().
-			Foreground(ColorTextMuted),
+			Foreground(t.TextMuted),
 
 		// Footer
 		FooterKey: lipgloss.NewStyle().
-			Foreground(ColorPrimary).
+			Foreground(t.Primary).
 			Bold(true),
 
 		FooterDesc: lipgloss.NewStyle().
-			Foreground(ColorTextMuted),
+			Foreground(t.TextMuted),
 
 		FooterSep: lipgloss.NewStyle().
-			Foreground(ColorSubtle),
+			Foreground(t.Subtle),
+
+		FooterError: lipgloss.NewStyle().
+			Foreground(t.Stopped).
+			Bold(true),
 	}
 }
 
 // PanelStyle returns a bordered panel style with the given dimensions.
 // width and height are the TOTAL outer dimensions including border.
 func (s Styles) PanelStyle(focused bool, width, height int) lipgloss.Style {
-	borderColor := ColorMuted
+	border := s.NormalBorder
 	if focused {
-		borderColor = ColorPrimary
+		border = s.FocusedBorder
 	}
 	// Inner dimensions after accounting for border (1 char each side)
 	innerWidth := width - 2
@@ -144,9 +154,8 @@ func (s Styles) PanelStyle(focused bool, width, height int) lipgloss.Style {
 	if innerHeight < 1 {
 		innerHeight = 1
 	}
-	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder(), true, true, true, true). // top, right, bottom, left
-		BorderForeground(borderColor).
+	return border.
+		BorderTop(true).BorderRight(true).BorderBottom(true).BorderLeft(true).
 		Width(innerWidth).
 		Height(innerHeight)
 }
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/library"
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+	"github.com/dewi-tim/vgmtui/internal/ui/components"
+)
+
+// TagsReadMsg is sent when a file's tags have been read for the tag
+// editor, in the language tags are shown in.
+type TagsReadMsg struct {
+	Path     string
+	Tags     metadata.Tags
+	Original bool
+	Err      error
+}
+
+// TagsWrittenMsg is sent when edited tags have been written to a file and
+// its metadata read back.
+type TagsWrittenMsg struct {
+	Path  string
+	Track metadata.Track
+	Err   error
+}
+
+// readTagsForEdit returns a command that reads the tags of the file at
+// path as stored, for the tag editor.
+func readTagsForEdit(path string) tea.Cmd {
+	original := metadata.PreferOriginal()
+	return func() tea.Msg {
+		tags, err := library.ReadTags(path, original)
+		return TagsReadMsg{Path: path, Tags: tags, Original: original, Err: err}
+	}
+}
+
+// writeTags returns a command that writes edited tags to their file, then
+// reads its metadata back through r.
+func writeTags(r metadata.Reader, msg components.TagsEditedMsg) tea.Cmd {
+	return func() tea.Msg {
+		if err := library.WriteTags(msg.Path, msg.Tags, msg.Original); err != nil {
+			return TagsWrittenMsg{Path: msg.Path, Err: err}
+		}
+		track, err := r.ReadTrackMetadata(msg.Path)
+		return TagsWrittenMsg{Path: msg.Path, Track: track, Err: err}
+	}
+}
+
+// tagsWritten shows the tags written to a file wherever the file is
+// listed: the playlist, the playing track and, by rescanning, the library.
+func (m *Model) tagsWritten(msg TagsWrittenMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.lastError = "Saving tags failed: " + msg.Err.Error()
+		m.errorTime = time.Now()
+		return nil
+	}
+
+	track := metadataTrack(msg.Path, msg.Track)
+	m.playlist.SetTrackTags(track)
+	if m.currentTrack != nil && m.currentTrack.Path == msg.Path {
+		m.currentTrack.Title = track.Title
+		m.currentTrack.Game = track.Game
+		m.currentTrack.System = track.System
+		m.currentTrack.Composer = track.Composer
+	}
+	if m.trackMeta != nil && m.trackMeta.Path == msg.Path {
+		meta := msg.Track
+		m.trackMeta = &meta
+	}
+	m.notice = "Saved tags to " + filepath.Base(msg.Path)
+	m.noticeTime = time.Now()
+
+	cmds := []tea.Cmd{m.windowTitle()}
+	if m.lib != nil && !m.rescanning && !m.autoRescan && !m.libBrowser.Scanning() {
+		// The rescan reads the file again, as it has changed
+		m.autoRescan = true
+		cmds = append(cmds, m.libBrowser.Refresh())
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,280 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dewi-tim/vgmtui/internal/ui/components"
+)
+
+// Theme is a named color palette from which every UI and component style
+// is derived.
+type Theme struct {
+	Name string
+
+	Primary   lipgloss.Color // Focus, cursor and highlight color
+	Secondary lipgloss.Color // Secondary accent
+	Accent    lipgloss.Color // Library systems and popup categories
+	Directory lipgloss.Color // Browser directories and library games
+	Muted     lipgloss.Color // Unfocused borders and empty bar segments
+	Subtle    lipgloss.Color // Separators
+
+	Playing lipgloss.Color
+	Paused  lipgloss.Color
+	Stopped lipgloss.Color
+
+	Text      lipgloss.Color
+	TextMuted lipgloss.Color
+
+	// ASCII draws borders with ASCII characters, for terminals without
+	// box drawing. It comes from the config, not the theme.
+	ASCII bool
+}
+
+// themes lists the built-in themes in cycle order.
+var themes = []Theme{
+	{
+		Name:      "default",
+		Primary:   ColorPrimary,
+		Secondary: ColorSecondary,
+		Accent:    lipgloss.Color("#FFA500"),
+		Directory: lipgloss.Color("#99CCFF"),
+		Muted:     ColorMuted,
+		Subtle:    ColorSubtle,
+		Playing:   ColorPlaying,
+		Paused:    ColorPaused,
+		Stopped:   ColorStopped,
+		Text:      ColorText,
+		TextMuted: ColorTextMuted,
+	},
+	{
+		Name:      "gruvbox",
+		Primary:   lipgloss.Color("#83A598"),
+		Secondary: lipgloss.Color("#D3869B"),
+		Accent:    lipgloss.Color("#FE8019"),
+		Directory: lipgloss.Color("#8EC07C"),
+		Muted:     lipgloss.Color("#665C54"),
+		Subtle:    lipgloss.Color("#3C3836"),
+		Playing:   lipgloss.Color("#B8BB26"),
+		Paused:    lipgloss.Color("#FABD2F"),
+		Stopped:   lipgloss.Color("#FB4934"),
+		Text:      lipgloss.Color("#EBDBB2"),
+		TextMuted: lipgloss.Color("#A89984"),
+	},
+	{
+		Name:      "monochrome",
+		Primary:   lipgloss.Color("#FFFFFF"),
+		Secondary: lipgloss.Color("#D0D0D0"),
+		Accent:    lipgloss.Color("#FFFFFF"),
+		Directory: lipgloss.Color("#C8C8C8"),
+		Muted:     lipgloss.Color("#5A5A5A"),
+		Subtle:    lipgloss.Color("#303030"),
+		Playing:   lipgloss.Color("#FFFFFF"),
+		Paused:    lipgloss.Color("#C0C0C0"),
+		Stopped:   lipgloss.Color("#808080"),
+		Text:      lipgloss.Color("#E0E0E0"),
+		TextMuted: lipgloss.Color("#9A9A9A"),
+	},
+	{
+		Name:      "nord",
+		Primary:   lipgloss.Color("#88C0D0"),
+		Secondary: lipgloss.Color("#B48EAD"),
+		Accent:    lipgloss.Color("#D08770"),
+		Directory: lipgloss.Color("#81A1C1"),
+		Muted:     lipgloss.Color("#4C566A"),
+		Subtle:    lipgloss.Color("#3B4252"),
+		Playing:   lipgloss.Color("#A3BE8C"),
+		Paused:    lipgloss.Color("#EBCB8B"),
+		Stopped:   lipgloss.Color("#BF616A"),
+		Text:      lipgloss.Color("#ECEFF4"),
+		TextMuted: lipgloss.Color("#D8DEE9"),
+	},
+}
+
+// DefaultTheme returns the default theme.
+func DefaultTheme() Theme {
+	return themes[0]
+}
+
+// ThemeNames returns the names of the built-in themes.
+func ThemeNames() []string {
+	names := make([]string, len(themes))
+	for i, t := range themes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// LookupTheme returns the built-in theme with the given name.
+func LookupTheme(name string) (Theme, bool) {
+	for _, t := range themes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// nextTheme returns the theme following the named one in cycle order.
+func nextTheme(name string) Theme {
+	for i, t := range themes {
+		if t.Name == name {
+			return themes[(i+1)%len(themes)]
+		}
+	}
+	return DefaultTheme()
+}
+
+// BrowserStyles builds the file browser styles from the theme.
+func (t Theme) BrowserStyles() components.BrowserStyles {
+	return components.BrowserStyles{
+		Cursor: lipgloss.NewStyle().
+			Foreground(t.Primary).
+			Bold(true),
+		Directory: lipgloss.NewStyle().
+			Foreground(t.Directory),
+		File: lipgloss.NewStyle().
+			Foreground(t.TextMuted),
+		VGMFile: lipgloss.NewStyle().
+			Foreground(t.Text),
+		Selected: lipgloss.NewStyle().
+			Foreground(t.Primary).
+			Bold(true),
+		SelectedDir: lipgloss.NewStyle().
+			Foreground(t.Primary).
+			Bold(true),
+		Muted: lipgloss.NewStyle().
+			Foreground(t.Muted),
+		EmptyDir: lipgloss.NewStyle().
+			Foreground(t.TextMuted).
+			Italic(true),
+		Marked: lipgloss.NewStyle().
+			Foreground(t.Accent).
+			Bold(true),
+	}
+}
+
+// LibBrowserStyles builds the library browser styles from the theme.
+func (t Theme) LibBrowserStyles() components.LibBrowserStyles {
+	styles := components.DefaultLibBrowserStyles()
+	styles.Cursor = lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+	styles.System = lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Bold(true)
+	styles.Game = lipgloss.NewStyle().
+		Foreground(t.Directory)
+	styles.Track = lipgloss.NewStyle().
+		Foreground(t.Text)
+	styles.Selected = lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+	styles.Muted = lipgloss.NewStyle().
+		Foreground(t.Muted)
+	return styles
+}
+
+// PlaylistStyles builds the playlist styles from the theme.
+func (t Theme) PlaylistStyles() components.PlaylistStyles {
+	return components.PlaylistStyles{
+		Header: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(t.TextMuted).
+			Padding(0, 1),
+		Cell: lipgloss.NewStyle().
+			Padding(0, 1),
+		Selected: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(t.Primary),
+		Playing: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(t.Playing),
+		FocusedBorder: lipgloss.NewStyle().
+			BorderStyle(t.border()).
+			BorderForeground(t.Primary),
+		NormalBorder: lipgloss.NewStyle().
+			BorderStyle(t.border()).
+			BorderForeground(t.Muted),
+		Title: lipgloss.NewStyle().
+			Foreground(t.Primary).
+			Bold(true),
+		TitleMuted: lipgloss.NewStyle().
+			Foreground(t.TextMuted),
+	}
+}
+
+// PopupStyles builds the overlay popup styles from the theme.
+func (t Theme) PopupStyles() components.PopupStyles {
+	return components.PopupStyles{
+		Border: lipgloss.NewStyle().
+			Border(t.border()).
+			BorderForeground(t.Primary),
+		Title: lipgloss.NewStyle().
+			Foreground(t.Primary).
+			Bold(true),
+		Category: lipgloss.NewStyle().
+			Foreground(t.Accent).
+			Bold(true),
+		Key: lipgloss.NewStyle().
+			Foreground(t.Primary).
+			Bold(true),
+		Desc: lipgloss.NewStyle().
+			Foreground(t.Text),
+		Footer: lipgloss.NewStyle().
+			Foreground(t.TextMuted).
+			Italic(true),
+	}
+}
+
+// border returns the border panels and popups are drawn with.
+func (t Theme) border() lipgloss.Border {
+	if t.ASCII {
+		return lipgloss.ASCIIBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
+
+// applyTheme re-styles the model and all of its components with a theme.
+func (m *Model) applyTheme(t Theme) {
+	t.ASCII = m.ascii
+	m.theme = t
+	m.styles = t.Styles()
+
+	m.browser.Styles = t.BrowserStyles()
+	m.libBrowser.SetStyles(t.LibBrowserStyles())
+	m.playlist.SetStyles(t.PlaylistStyles())
+
+	m.progress.TimeStyle = m.styles.ProgressTime
+	m.progress.FilledStyle = m.styles.ProgressFilled
+	m.progress.EmptyStyle = m.styles.ProgressEmpty
+	m.progress.GradientStart = t.Secondary
+	m.progress.GradientEnd = t.Primary
+	m.scope.Style = lipgloss.NewStyle().Foreground(t.Playing)
+	m.scope.ASCII = m.ascii
+	m.loadSpinner.Style = m.styles.StatusPlaying
+	m.loadSpinner.Spinner = spinner.MiniDot
+	if m.ascii {
+		m.loadSpinner.Spinner = spinner.Line
+	}
+
+	m.vuMeter.LowStyle = lipgloss.NewStyle().Foreground(t.Playing)
+	m.vuMeter.MidStyle = lipgloss.NewStyle().Foreground(t.Paused)
+	m.vuMeter.HighStyle = lipgloss.NewStyle().Foreground(t.Stopped)
+	m.vuMeter.EmptyStyle = m.styles.ProgressEmpty
+	m.vuMeter.LabelStyle = m.styles.TextMuted
+	m.vuMeter.ASCII = m.ascii
+
+	popupStyles := t.PopupStyles()
+	m.helpPopup.SetStyles(popupStyles)
+	m.chipPopup.SetStyles(popupStyles)
+	m.diffPopup.SetStyles(popupStyles)
+	m.audioPopup.SetStyles(popupStyles)
+	m.historyPopup.SetStyles(popupStyles)
+	m.statsPopup.SetStyles(popupStyles)
+	m.chipPicker.SetStyles(popupStyles)
+	m.palette.SetStyles(popupStyles)
+	m.keyBindings.SetStyles(popupStyles)
+	m.dupPopup.SetStyles(popupStyles)
+	m.tagEditor.SetStyles(popupStyles)
+}
@@ -1,13 +1,19 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/dewi-tim/vgmtui/internal/config"
 	"github.com/dewi-tim/vgmtui/internal/library"
+	"github.com/dewi-tim/vgmtui/internal/metadata"
 	"github.com/dewi-tim/vgmtui/internal/player"
 	"github.com/dewi-tim/vgmtui/internal/ui/components"
 )
@@ -66,26 +72,31 @@ type (
 	// TrackMetadataLoadedMsg is sent when track metadata has been loaded.
 	TrackMetadataLoadedMsg struct {
 		Track Track
-		Chips []player.ChipInfo
+		Chips []metadata.ChipInfo
 	}
 
 	// TrackMetadataForPlayMsg is sent when track metadata has been loaded and should play immediately.
 	TrackMetadataForPlayMsg struct {
 		Track Track
-		Chips []player.ChipInfo
+		Chips []metadata.ChipInfo
 	}
 
 	// ErrorMsg is sent when an error occurs that should be displayed to the user.
 	ErrorMsg struct {
-		Err error
+		Err  error
+		Path string // File the error concerns ("" if none)
 	}
 
 	// ClearErrorMsg is sent to clear the error display.
 	ClearErrorMsg struct{}
 
+	// hideVolumeMsg redraws the status line once the volume slider has
+	// been shown long enough.
+	hideVolumeMsg struct{}
+
 	// TrackChipsLoadedMsg is sent when chip info is loaded for the current track.
 	TrackChipsLoadedMsg struct {
-		Chips []player.ChipInfo
+		Chips []metadata.ChipInfo
 	}
 
 	// TrackLoadStartedMsg is sent when a playTrack command begins.
@@ -93,57 +104,116 @@ type (
 
 	// TrackLoadCompleteMsg is sent when a playTrack command completes (success or failure).
 	TrackLoadCompleteMsg struct{}
-)
 
-// Update handles messages and updates the model.
-func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmds []tea.Cmd
+	// DirTracksLoadedMsg is sent when metadata has been read for files
+	// added in bulk (a recursive directory add or the marked files). Files is the number of files requested,
+	// including any that could not be read.
+	DirTracksLoadedMsg struct {
+		Tracks []Track
+		Files  int
+		Failed int
+	}
 
-	switch msg := msg.(type) {
-	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
+	// OpenedTracksMsg is sent when the files and directories given on the
+	// command line have been expanded and read.
+	OpenedTracksMsg struct {
+		Tracks []Track
+		Failed int
+		Err    error // First path that could not be opened
+	}
 
-		// Match the layout calculations from View()
-		footerHeight := 1
-		mainHeight := m.height - footerHeight
+	// SessionExportedMsg is sent when the session has been written to Path.
+	SessionExportedMsg struct {
+		Path string
+		Err  error
+	}
 
-		// Panel widths
-		libraryWidth := m.width * libraryWidthPercent / 100
-		rightWidth := m.width - libraryWidth
+	// SessionImportedMsg is sent when a session file has been read.
+	// Current indexes Tracks (-1 if no track was playing); Failed counts
+	// queued files that could not be read.
+	SessionImportedMsg struct {
+		Path     string
+		Tracks   []Track
+		Current  int
+		Position time.Duration
+		Settings SessionSettings
+		Failed   int
+		Err      error
+	}
 
-		// Browser size: outer=libraryWidth x mainHeight, inner subtracts border(2) and title(1)
-		browserInnerWidth := libraryWidth - 2
-		browserInnerHeight := mainHeight - 3 // border(2) + title(1)
-		m.browser.SetSize(browserInnerWidth, browserInnerHeight)
-		if m.useLibrary {
-			m.libBrowser.SetSize(browserInnerWidth, browserInnerHeight)
-		}
+	// PreviewTickMsg is sent when the file browser cursor has rested on a
+	// file long enough to read its metadata for the preview.
+	PreviewTickMsg struct {
+		Seq int
+	}
 
-		// Right pane layout (from renderRightPane)
-		progressHeight := 4  // No title now
-		trackInfoHeight := 6
-		playlistHeight := mainHeight - progressHeight - trackInfoHeight
+	// TrackPreviewMsg is sent when metadata has been read for the preview.
+	TrackPreviewMsg struct {
+		Seq   int
+		Track Track
+		Chips []metadata.ChipInfo
+		Err   error
+	}
+
+	// LibraryDiffMsg is sent when a library scan has been compared with the
+	// previous one. Baseline is false if there was no previous scan.
+	LibraryDiffMsg struct {
+		Diff     library.Diff
+		Root     string
+		Since    time.Time
+		Baseline bool
+		Err      error
+	}
 
-		// Playlist size: inner dimensions
-		playlistInnerWidth := rightWidth - 2
-		playlistInnerHeight := playlistHeight - 3 // border(2) + title(1)
-		m.playlist.SetSize(playlistInnerWidth, playlistInnerHeight)
+	// LibraryChangedMsg is sent when the library watcher saw files added,
+	// removed or changed.
+	LibraryChangedMsg struct {
+		Watcher *library.Watcher
+	}
 
-		// Progress bar width (inside progress panel)
-		progressInnerWidth := rightWidth - 4 // border + some padding
-		m.progress.SetWidth(progressInnerWidth)
+	// DuplicatesFoundMsg is sent when a search for duplicate library
+	// tracks completes.
+	DuplicatesFoundMsg struct {
+		Mode   library.DuplicateMode
+		Groups []library.DuplicateGroup
+		Err    error
+	}
+)
 
-		// Help popup
-		m.helpPopup.SetSize(msg.Width, msg.Height)
+// Update handles messages and updates the model.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
 
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.resize()
 		return m, nil
 
 	case tea.KeyMsg:
-		// If help popup is visible, only handle help popup keys
-		if m.helpPopup.Visible() {
+		// An open popup takes every key
+		if o := m.activeOverlay(); o != nil {
+			return m, o.update(msg)
+		}
+		// While typing a browser filter, every key goes to the filter
+		if m.focus == FocusBrowser {
+			if m.useLibrary && m.libBrowser.Filtering() {
+				var cmd tea.Cmd
+				m.libBrowser, cmd = m.libBrowser.Update(msg)
+				return m, cmd
+			}
+			if !m.useLibrary && m.browser.Filtering() {
+				var cmd tea.Cmd
+				m.browser, cmd = m.browser.Update(msg)
+				return m, tea.Batch(cmd, m.schedulePreview())
+			}
+		}
+		// Likewise while typing a playlist position or search, or
+		// stepping through search matches
+		if m.focus == FocusPlaylist && m.playlist.CapturesKey(msg) {
 			var cmd tea.Cmd
-			m.helpPopup, cmd = m.helpPopup.Update(msg)
+			m.playlist, cmd = m.playlist.Update(msg)
 			return m, cmd
 		}
 		// Handle key presses
@@ -156,14 +226,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+		cmds = append(cmds, m.schedulePreview())
 		return m, tea.Batch(cmds...)
 
+	case PreviewTickMsg:
+		// Read metadata only if the cursor hasn't moved on since
+		if msg.Seq != m.previewSeq || m.previewPath == "" {
+			return m, nil
+		}
+		return m, loadPreview(m.metadata, m.previewPath, msg.Seq)
+
+	case TrackPreviewMsg:
+		if msg.Seq != m.previewSeq || msg.Err != nil {
+			return m, nil // Stale, or not worth an error for a preview
+		}
+		track := msg.Track
+		m.preview = &track
+		m.previewChips = msg.Chips
+		return m, nil
+
 	case components.LibBrowserScanCompleteMsg:
 		// Library scan completed
 		if msg.Err != nil {
 			// Propagate scan error to UI
 			m.lastError = "Library scan failed: " + msg.Err.Error()
 			m.errorTime = time.Now()
+			m.rescanning = false
+			m.autoRescan = false
 		}
 		if m.useLibrary {
 			var cmd tea.Cmd
@@ -171,36 +260,102 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if cmd != nil {
 				cmds = append(cmds, cmd)
 			}
+			if msg.Err == nil {
+				cmds = append(cmds, diffLibrary(m.lib))
+			}
 		}
 		return m, tea.Batch(cmds...)
 
+	case LibraryDiffMsg:
+		if msg.Err != nil {
+			m.lastError = "Saving library cache failed: " + msg.Err.Error()
+			m.errorTime = time.Now()
+		}
+		m.diffPopup.SetDiff(msg.Diff, msg.Root, msg.Since, msg.Baseline)
+		if m.rescanning {
+			m.rescanning = false
+			m.diffPopup.Show()
+		}
+		if m.autoRescan {
+			m.autoRescan = false
+			if !msg.Diff.Empty() {
+				m.notice = fmt.Sprintf("Library updated: %d added, %d removed, %d changed (U for details)",
+					len(msg.Diff.Added), len(msg.Diff.Removed), len(msg.Diff.Modified))
+				m.noticeTime = time.Now()
+			}
+		}
+		return m, nil
+
+	case LibraryChangedMsg:
+		if msg.Watcher != m.watcher {
+			return m, nil // From a watcher since stopped
+		}
+		listen := listenForLibraryChanges(m.watcher)
+		if m.rescanning || m.autoRescan || m.libBrowser.Scanning() {
+			return m, listen
+		}
+		m.autoRescan = true
+		return m, tea.Batch(m.libBrowser.Refresh(), listen)
+
 	case components.LibTrackSelectedMsg:
 		// Single track selected from library (just adds to playlist, doesn't play)
 		m.playlist.AddTrack(components.Track{
 			Path:        msg.Track.Path,
 			Title:       msg.Track.Title,
 			Game:        msg.Track.Game,
+			DirGame:     msg.Track.DirGame,
 			System:      msg.Track.System,
 			Composer:    msg.Track.Composer,
 			Duration:    msg.Track.Duration,
 			TrackNumber: msg.Track.TrackNumber,
 		})
-		return m, nil
+		return m, m.requeueNextTrack()
 
 	case components.LibTracksSelectedMsg:
+		if msg.Next {
+			// Play the tracks after the current one, in order
+			tracks := make([]components.Track, len(msg.Tracks))
+			for i, t := range msg.Tracks {
+				tracks[i] = libraryTrack(t)
+			}
+			m.playlist.InsertAfterCurrent(tracks)
+			m.notice = fmt.Sprintf("Playing next: %d tracks", len(tracks))
+			m.noticeTime = time.Now()
+			return m, m.requeueNextTrack()
+		}
 		// Multiple tracks selected (add all from game/system)
 		for _, t := range msg.Tracks {
 			m.playlist.AddTrack(components.Track{
 				Path:        t.Path,
 				Title:       t.Title,
 				Game:        t.Game,
+				DirGame:     t.DirGame,
 				System:      t.System,
 				Composer:    t.Composer,
 				Duration:    t.Duration,
 				TrackNumber: t.TrackNumber,
 			})
 		}
-		return m, nil
+		return m, m.requeueNextTrack()
+
+	case components.LibSmartPlaylistMsg:
+		// Smart playlist opened - replace the playlist with its matches
+		if m.trackLoading {
+			return m, nil
+		}
+		if len(msg.Tracks) == 0 {
+			m.lastError = fmt.Sprintf("No tracks match %q", msg.Name)
+			m.errorTime = time.Now()
+			return m, nil
+		}
+		m.stopPlayback()
+		m.playlist.Clear()
+		for _, t := range msg.Tracks {
+			m.playlist.AddTrack(libraryTrack(t))
+		}
+		m.notice = fmt.Sprintf("%s: %d tracks", msg.Name, len(msg.Tracks))
+		m.noticeTime = time.Now()
+		return m, m.startPlayingTrack(0)
 
 	case components.LibTrackPlayMsg:
 		// Track selected for immediate playback - add to playlist and play
@@ -211,6 +366,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			Path:        msg.Track.Path,
 			Title:       msg.Track.Title,
 			Game:        msg.Track.Game,
+			DirGame:     msg.Track.DirGame,
 			System:      msg.Track.System,
 			Composer:    msg.Track.Composer,
 			Duration:    msg.Track.Duration,
@@ -225,11 +381,76 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case components.FavoriteToggleMsg:
+		return m, m.toggleFavorite(msg.Path)
+
+	case components.EditTagsMsg:
+		return m, readTagsForEdit(msg.Path)
+
+	case TagsReadMsg:
+		if msg.Err != nil {
+			m.lastError = msg.Err.Error()
+			m.errorTime = time.Now()
+			return m, nil
+		}
+		m.tagEditor.Show(msg.Path, msg.Tags, msg.Original)
+		return m, nil
+
+	case components.TagsEditedMsg:
+		return m, writeTags(m.metadata, msg)
+
+	case TagsWrittenMsg:
+		return m, m.tagsWritten(msg)
+
+	case components.ChipFilterOpenMsg:
+		if len(msg.Chips) == 0 {
+			m.lastError = "No chip information in the library"
+			m.errorTime = time.Now()
+			return m, nil
+		}
+		m.chipPicker.Show(msg.Chips, msg.Current)
+		return m, nil
+
+	case components.DuplicatesFindMsg:
+		if m.lib == nil {
+			return m, nil
+		}
+		m.dupPopup.ShowSearching(msg.Mode, m.lib.Root())
+		return m, findDuplicates(m.lib, msg.Mode)
+
+	case DuplicatesFoundMsg:
+		if msg.Err != nil {
+			m.dupPopup.Hide()
+			m.lastError = "Finding duplicates failed: " + msg.Err.Error()
+			m.errorTime = time.Now()
+			return m, nil
+		}
+		m.dupPopup.SetGroups(msg.Mode, msg.Groups)
+		return m, nil
+
+	case components.ChipFilterSelectMsg:
+		m.libBrowser.SetChipFilter(msg.Chip)
+		return m, nil
+
+	case components.HistoryPlayMsg:
+		// Replay a track from the history - add to playlist and play
+		if m.trackLoading {
+			return m, nil
+		}
+		m.playlist.AddTrack(msg.Track)
+		return m, m.startPlayingTrack(m.playlist.Len() - 1)
+
+	case components.HistoryAddMsg:
+		m.playlist.AddTrack(msg.Track)
+		m.notice = "Added " + msg.Track.Title
+		m.noticeTime = time.Now()
+		return m, nil
+
 	case components.FileSelectedMsg:
 		// A file was selected in the browser (add only, no play)
 		if m.audioPlayer != nil {
 			// Load metadata from the real player
-			return m, loadTrackMetadata(msg.Path)
+			return m, loadTrackMetadata(m.metadata, msg.Path)
 		}
 		// Mock mode: just show filename
 		m.currentTrack = &Track{
@@ -242,10 +463,95 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case components.DirAddMsg:
+		// A directory was searched recursively - read metadata in the background
+		if msg.Err != nil {
+			m.lastError = "Adding directory failed: " + msg.Err.Error()
+			m.errorTime = time.Now()
+			return m, nil
+		}
+		if len(msg.Paths) == 0 {
+			m.lastError = "No VGM files found in " + filepath.Base(msg.Dir)
+			m.errorTime = time.Now()
+			return m, nil
+		}
+		m.addingFiles += len(msg.Paths)
+		return m, loadDirTracks(m.metadata, msg.Paths)
+
+	case components.MarkedAddMsg:
+		// Marked files and directories were collected - read metadata in the background
+		if msg.Err != nil {
+			m.lastError = "Adding marked files failed: " + msg.Err.Error()
+			m.errorTime = time.Now()
+			return m, nil
+		}
+		if len(msg.Paths) == 0 {
+			m.lastError = "No VGM files in the marked directories"
+			m.errorTime = time.Now()
+			return m, nil
+		}
+		m.addingFiles += len(msg.Paths)
+		return m, loadDirTracks(m.metadata, msg.Paths)
+
+	case DirTracksLoadedMsg:
+		m.addingFiles -= msg.Files
+		if m.addingFiles < 0 {
+			m.addingFiles = 0
+		}
+		if msg.Failed > 0 {
+			m.lastError = fmt.Sprintf("%d file(s) could not be read", msg.Failed)
+			m.errorTime = time.Now()
+		}
+		tracks := msg.Tracks
+		return m, func() tea.Msg { return AddToQueueMsg{Tracks: tracks} }
+
+	case OpenedTracksMsg:
+		if msg.Err != nil {
+			m.lastError = msg.Err.Error()
+			m.errorTime = time.Now()
+		} else if msg.Failed > 0 {
+			m.lastError = fmt.Sprintf("%d file(s) could not be read", msg.Failed)
+			m.errorTime = time.Now()
+		}
+		if len(msg.Tracks) == 0 {
+			return m, nil
+		}
+		first := m.playlist.Len()
+		m.playlist.AddTracks(msg.Tracks)
+		m.focusPlaylist()
+		if m.audioPlayer != nil && !m.trackLoading {
+			return m, m.startPlayingTrack(first)
+		}
+		return m, nil
+
+	case SessionExportedMsg:
+		if msg.Err != nil {
+			m.lastError = "Exporting session failed: " + msg.Err.Error()
+			m.errorTime = time.Now()
+			return m, nil
+		}
+		m.notice = "Session exported to " + msg.Path
+		m.noticeTime = time.Now()
+		return m, nil
+
+	case SessionImportedMsg:
+		if msg.Err != nil {
+			m.lastError = "Importing session failed: " + msg.Err.Error()
+			m.errorTime = time.Now()
+			return m, nil
+		}
+		if msg.Failed > 0 {
+			m.lastError = fmt.Sprintf("%d file(s) could not be read", msg.Failed)
+			m.errorTime = time.Now()
+		}
+		m.notice = "Session imported from " + msg.Path
+		m.noticeTime = time.Now()
+		return m, m.applySession(msg)
+
 	case components.FilePlayMsg:
 		// A file was selected for immediate playback (add and play)
 		if m.audioPlayer != nil && !m.trackLoading {
-			return m, loadTrackMetadataForPlay(msg.Path)
+			return m, loadTrackMetadataForPlay(m.metadata, msg.Path)
 		}
 		return m, nil
 
@@ -275,6 +581,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Directory changed - nothing special to do for now
 		return m, nil
 
+	case components.ChipSoloMsg:
+		// Solo a chip for audition by muting all others, or restore all
+		if m.audioPlayer != nil {
+			for i := range m.trackChips {
+				m.audioPlayer.SetChipMuted(i, msg.Index >= 0 && i != msg.Index)
+			}
+		}
+		return m, nil
+
+	case components.FollowSymlinksMsg:
+		m.notice = "Not following symlinks"
+		if msg.Follow {
+			m.notice = "Following symlinked directories"
+		}
+		if m.lib != nil {
+			m.notice += "; rescan (" + m.keyMap.Rescan.Help().Key + ") to update the library"
+		}
+		m.noticeTime = time.Now()
+		return m, m.updateConfig(func(c *config.Config) {
+			c.FollowSymlinks = msg.Follow
+		})
+
+	case components.DirPrefsChangedMsg:
+		// Persist remembered per-directory preferences in the background
+		return m, saveDirPrefs(msg.Prefs)
+
 	case components.BrowserSelectNameMsg:
 		// Message to select a specific entry by name after navigating up
 		m.browser.HandleSelectName(msg.Name)
@@ -284,10 +616,39 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update from real audio player
 		// Consider both Playing and Fading as "was playing" for auto-advance
 		wasPlaying := m.playback.State == StatePlaying || m.playback.State == StateFading
+		if msg.Info.TrackChanges != m.trackChanges {
+			// The player moved on to the queued track without a gap
+			m.trackChanges = msg.Info.TrackChanges
+			if cmd := m.gaplessAdvance(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		if msg.Info.DeviceRestarts != m.deviceRestarts {
+			m.deviceRestarts = msg.Info.DeviceRestarts
+			m.notice = "Audio device reconnected"
+			if msg.Info.State == player.StatePaused {
+				// The player paused itself (pause_on_device_loss)
+				m.notice = "Paused: the audio device went away"
+			}
+			m.noticeTime = time.Now()
+		}
+		if msg.Info.DeviceLost {
+			// Kept fresh so it stays up until the device is back
+			m.lastError = "Audio device lost, reconnecting..."
+			m.errorTime = time.Now()
+		}
 		m.playback.Position = msg.Info.Position
 		m.playback.Duration = msg.Info.Duration
 		m.playback.CurrentLoop = msg.Info.CurrentLoop
 		m.playback.TotalLoops = msg.Info.TotalLoops
+		m.playback.HasLoop = msg.Info.HasLoop
+		if msg.Info.Speed > 0 {
+			m.speed = msg.Info.Speed
+		}
+		if !m.muted {
+			// While muted the player is silent but volume keeps the level
+			m.volume = msg.Info.Volume
+		}
 
 		// Convert player state to UI state
 		// When trackLoading is true, we're switching tracks - ignore StateStopped
@@ -303,6 +664,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.playback.State = StateStopped
 				// Check if track ended (was playing, now stopped)
 				if wasPlaying {
+					m.notifyObserver()
 					// Auto-advance to next track
 					// Also continue listening for updates from the new track
 					batchCmds := []tea.Cmd{func() tea.Msg { return TrackEndedMsg{} }}
@@ -315,6 +677,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case player.StateFading:
 			m.playback.State = StateFading
 		}
+		m.fillRadio() // Keep radio mode ahead of removals from the queue
+		m.updateScope()
+		m.updateMeters()
+		m.notifyObserver()
 
 		// Continue listening for playback updates
 		if m.playerSub != nil {
@@ -328,13 +694,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case TrackEndedMsg:
+		if m.sleepFading {
+			// The sleep timer's fade-out ended the track
+			return m, m.finishSleep()
+		}
+		if m.fadeStopping {
+			return m, m.finishFadeStop()
+		}
 		// Current track finished, try to play next
+		m.trackFinished()
+		m.fillRadio()
 		if m.audioPlayer != nil && !m.trackLoading {
-			// Use PeekNextTrack to query without mutating state
-			nextIdx := m.playlist.PeekNextTrack()
+			// Query without mutating state, skipping jingles shorter
+			// than the configured minimum and silent tracks
+			nextIdx := m.nextAutoTrack()
 			if nextIdx >= 0 {
 				// Use startPlayingTrack for atomic state transition
-				cmd := m.startPlayingTrack(nextIdx)
+				cmd := m.advanceToTrack(nextIdx, 1)
 				if cmd != nil {
 					return m, cmd
 				}
@@ -342,7 +718,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// No next track or failed to start - stop playback and clear state
 			m.stopPlayback()
 		}
-		return m, nil
+		return m, savePlayStats(m.plays)
 
 	case TickMsg:
 		// Mock tick - only used when no real player
@@ -358,13 +734,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.trackLoading {
 			return m, nil
 		}
+		m.fillRadio()
 		if m.audioPlayer != nil {
 			// Use PeekNextTrack to query without mutating state
 			nextIdx := m.playlist.PeekNextTrack()
 			if nextIdx >= 0 {
 				// Stop current playback and start next track
 				m.audioPlayer.Stop()
-				cmd := m.startPlayingTrack(nextIdx)
+				cmd := m.advanceToTrack(nextIdx, 1)
 				if cmd != nil {
 					return m, cmd
 				}
@@ -386,7 +763,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if prevIdx >= 0 {
 				// Stop current playback and start previous track
 				m.audioPlayer.Stop()
-				cmd := m.startPlayingTrack(prevIdx)
+				cmd := m.advanceToTrack(prevIdx, -1)
 				if cmd != nil {
 					return m, cmd
 				}
@@ -397,6 +774,62 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case components.RebindMsg:
+		return m, m.rebind(msg.Action, msg.Key)
+
+	case SetLoopCountMsg:
+		m.setLoopCount(msg.Count)
+		return m, nil
+
+	case SetBuffersMsg:
+		return m, m.setBuffers(msg.Buffers)
+
+	case SetThemeMsg:
+		if t, ok := LookupTheme(msg.Name); ok {
+			m.applyTheme(t)
+		}
+		return m, nil
+
+	case SavePlaylistMsg:
+		m.notice = fmt.Sprintf("Saved playlist (%d tracks)", m.playlist.Len())
+		m.noticeTime = time.Now()
+		return m, m.savePlaylist()
+
+	case ToggleTagLanguageMsg:
+		original := !metadata.PreferOriginal()
+		metadata.SetPreferOriginal(original)
+		m.refreshTagLanguage()
+		m.notice = "Tags in English"
+		if original {
+			m.notice = "Tags in their original language"
+		}
+		m.notice += "; tracks already queued keep theirs"
+		if m.lib != nil {
+			m.notice += ", rescan (" + m.keyMap.Rescan.Help().Key + ") to update the library"
+		}
+		m.noticeTime = time.Now()
+		return m, m.updateConfig(func(c *config.Config) {
+			c.OriginalTags = original
+		})
+
+	case SleepTimerMsg:
+		if msg.Seq != m.sleepSeq || m.sleepAt.IsZero() {
+			return m, nil // Replaced or cancelled
+		}
+		return m, m.sleepTimerExpired()
+
+	case SleepFadedMsg:
+		if msg.Seq != m.sleepSeq || !m.sleepFading {
+			return m, nil
+		}
+		return m, m.finishSleep()
+
+	case FadeStoppedMsg:
+		if msg.Seq != m.fadeSeq || !m.fadeStopping {
+			return m, nil
+		}
+		return m, m.finishFadeStop()
+
 	case StopMsg:
 		if m.audioPlayer != nil {
 			m.audioPlayer.Stop()
@@ -404,6 +837,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.playback.State = StateStopped
 		m.playback.Position = 0
 		m.playback.CurrentLoop = 0
+		m.notifyObserver()
 		return m, nil
 
 	case SeekMsg:
@@ -422,7 +856,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case ToggleHelpMsg:
-		m.helpPopup.Toggle()
+		m.helpPopup.Toggle(m.helpSections())
 		m.showHelp = m.helpPopup.Visible()
 		return m, nil
 
@@ -431,12 +865,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case QuitMsg:
-		m.quitting = true
-		return m, tea.Quit
+		return m, m.quit()
 
 	case AddToQueueMsg:
 		m.playlist.AddTracks(msg.Tracks)
-		return m, nil
+		return m, m.requeueNextTrack()
 
 	case RemoveFromQueueMsg:
 		// Check if we're removing the currently playing track
@@ -449,8 +882,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// If we removed the currently playing track, stop playback
 		if wasPlayingRemoved {
 			m.stopPlayback()
+			return m, nil
 		}
-		return m, nil
+		return m, m.requeueNextTrack()
 
 	case ClearQueueMsg:
 		// Stop playback before clearing since we're removing all tracks
@@ -458,6 +892,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.playlist.Clear()
 		return m, nil
 
+	case components.PlaylistGoToMsg:
+		// The cursor is already on the track - play it if asked
+		if msg.Play {
+			return m, func() tea.Msg { return PlaySelectedMsg{} }
+		}
+		return m, nil
+
+	case components.PlaylistGoToErrorMsg:
+		m.lastError = fmt.Sprintf("No track #%d (playlist has %d)", msg.Number, msg.Len)
+		m.errorTime = time.Now()
+		return m, nil
+
 	case PlaySelectedMsg:
 		if m.trackLoading {
 			return m, nil
@@ -483,13 +929,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case ErrorMsg:
-		m.lastError = msg.Err.Error()
+		m.lastError = errorText(msg.Err, msg.Path)
 		m.errorTime = time.Now()
 		// Schedule auto-clear after 5 seconds
 		return m, tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
 			return ClearErrorMsg{}
 		})
 
+	case hideVolumeMsg:
+		// Nothing to do: the redraw drops the slider once it has expired
+		return m, nil
+
 	case ClearErrorMsg:
 		// Only clear if the error is old (5+ seconds)
 		if time.Since(m.errorTime) >= 5*time.Second {
@@ -503,9 +953,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case TrackLoadStartedMsg:
-		// Mark that a track load is in progress
+		// Mark that a track load is in progress and spin until it ends
 		m.trackLoading = true
-		return m, nil
+		return m, m.loadSpinner.Tick
+
+	case spinner.TickMsg:
+		if !m.trackLoading {
+			return m, nil // Let the spinner stop
+		}
+		var cmd tea.Cmd
+		m.loadSpinner, cmd = m.loadSpinner.Update(msg)
+		return m, cmd
 
 	case TrackLoadCompleteMsg:
 		// Track load finished (success or failure)
@@ -515,22 +973,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case playTrackResult:
 		// Handle combined result from playTrack command
 		if msg.err != nil {
-			// Playback failed - rollback pending state
+			m.playlist.SetBroken(m.pendingPlayIndex, true)
+			// Playback failed - when stepping through the playlist, skip
+			// the track and try the one after it
+			if cmd := m.skipFailedTrack(msg.err); cmd != nil {
+				return m, cmd
+			}
+			// Otherwise rollback pending state
+			path := ""
+			if m.pendingTrack != nil {
+				path = m.pendingTrack.Path
+			}
 			m.cancelPendingTrack()
-			m.lastError = msg.err.Error()
+			m.resumeAt = 0
+			m.lastError = errorText(msg.err, path)
 			m.errorTime = time.Now()
 			return m, tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
 				return ClearErrorMsg{}
 			})
 		}
-		// Playback succeeded - commit pending state
-		m.confirmTrackStarted()
-		if len(msg.chips) > 0 {
-			m.trackChips = msg.chips
+		// Playback succeeded - commit pending state, unless the track
+		// turned out silent while stepping through the playlist
+		if msg.track != nil && m.isSilent(msg.track.Duration) {
+			if cmd := m.skipSilentTrack(msg.track.Duration); cmd != nil {
+				return m, cmd
+			}
+			m.lastError = fmt.Sprintf("%s looks silent (%s long)",
+				filepath.Base(msg.track.Path), msg.track.Duration.Round(time.Millisecond))
+			m.errorTime = time.Now()
+		}
+		if len(m.skipped) > 0 {
+			m.notice = "Skipped unplayable " + strings.Join(m.skipped, ", ")
+			m.noticeTime = time.Now()
 		}
 		// Note: Don't queue listenForPlayback here - it's already queued
 		// from the PlayerTickMsg handler (either in the early return for
 		// auto-advance, or at the end for normal playback)
+		return m, m.trackStarted(msg.track, msg.chips)
+
+	case trackQueuedMsg:
+		if msg.err != nil && msg.index == m.queuedIndex {
+			// Fall back to loading the track when the current one ends
+			m.queuedIndex = -1
+		}
 		return m, nil
 	}
 
@@ -542,14 +1027,130 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Global key bindings (work regardless of focus)
 	switch {
 	case key.Matches(msg, m.keyMap.Quit):
-		m.quitting = true
-		return m, tea.Quit
+		return m, m.quit()
 
 	case key.Matches(msg, m.keyMap.Help):
-		m.helpPopup.Toggle()
+		m.helpPopup.Toggle(m.helpSections())
 		m.showHelp = m.helpPopup.Visible()
 		return m, nil
 
+	case key.Matches(msg, m.keyMap.ChipInfo):
+		m.chipPopup.Show(m.trackChips)
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.CompactChips):
+		m.compactChips = !m.compactChips
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.CycleTheme):
+		m.applyTheme(nextTheme(m.theme.Name))
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.GameLabel):
+		m.gameLabel = m.gameLabel.Next()
+		m.libBrowser.SetGameLabel(m.gameLabel)
+		m.playlist.SetGameLabel(m.gameLabel)
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.Scope):
+		m.showScope = !m.showScope
+		m.updateScope()
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.FullInfo):
+		m.showFullInfo = !m.showFullInfo
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.ExportSession):
+		return m, exportSession(m.session(), m.sessionFile())
+
+	case key.Matches(msg, m.keyMap.ImportSession):
+		return m, importSession(m.metadata, m.sessionFile())
+
+	case key.Matches(msg, m.keyMap.Diagnostics):
+		m.showDiagnostics = !m.showDiagnostics
+		m.resize() // The progress panel grows to fit the line
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.Meters):
+		m.showMeters = !m.showMeters
+		m.vuMeter.Reset()
+		m.resize() // The progress panel grows to fit the meters
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.Radio):
+		return m, m.toggleRadio()
+
+	case key.Matches(msg, m.keyMap.ShufflePlay):
+		mode := m.playlist.ShuffleMode().Next()
+		m.playlist.SetShuffleMode(mode)
+		m.notice = []string{"Shuffle off", "Shuffle on", "Smart shuffle on"}[mode]
+		m.noticeTime = time.Now()
+		return m, m.requeueNextTrack()
+
+	case key.Matches(msg, m.keyMap.AudioConfig):
+		var cfg *player.AudioConfig
+		if m.audioPlayer != nil {
+			c := m.audioPlayer.AudioConfig()
+			cfg = &c
+		}
+		m.audioPopup.Show(cfg)
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.KeyBindings):
+		m.keyBindings.Show(m.keyBindingRows())
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.Palette):
+		m.palette.Show(m.paletteCommands())
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.SleepTimer):
+		return m, m.cycleSleepTimer()
+
+	case key.Matches(msg, m.keyMap.SwitchBrowser):
+		return m, m.toggleBrowser()
+
+	case key.Matches(msg, m.keyMap.WidenLibrary):
+		return m, m.resizeLibrary(libraryWidthStep)
+
+	case key.Matches(msg, m.keyMap.NarrowLibrary):
+		return m, m.resizeLibrary(-libraryWidthStep)
+
+	case key.Matches(msg, m.keyMap.LibraryStats):
+		if m.lib == nil {
+			m.lastError = "Library statistics need library mode"
+			m.errorTime = time.Now()
+			return m, nil
+		}
+		stats := m.lib.Stats()
+		stats.Played = library.MostPlayed(m.lib.AllTracks(), m.plays.Counts())
+		m.statsPopup.Show(stats, m.lib.Root())
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.History):
+		m.historyPopup.Show(m.history)
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.Rescan):
+		if !m.useLibrary || m.rescanning {
+			return m, nil
+		}
+		m.rescanning = true
+		return m, m.libBrowser.Scan()
+
+	case key.Matches(msg, m.keyMap.WatchLibrary):
+		if !m.useLibrary {
+			return m, nil
+		}
+		return m, m.toggleWatching()
+
+	case key.Matches(msg, m.keyMap.LibraryDiff):
+		if m.useLibrary {
+			m.diffPopup.Show()
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keyMap.PlayPause):
 		return m.togglePlayPause()
 
@@ -563,7 +1164,7 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if nextIdx >= 0 {
 				// Stop current playback and start next track
 				m.audioPlayer.Stop()
-				cmd := m.startPlayingTrack(nextIdx)
+				cmd := m.advanceToTrack(nextIdx, 1)
 				if cmd != nil {
 					return m, cmd
 				}
@@ -584,7 +1185,7 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if prevIdx >= 0 {
 				// Stop current playback and start previous track
 				m.audioPlayer.Stop()
-				cmd := m.startPlayingTrack(prevIdx)
+				cmd := m.advanceToTrack(prevIdx, -1)
 				if cmd != nil {
 					return m, cmd
 				}
@@ -605,45 +1206,69 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case key.Matches(msg, m.keyMap.SeekForward):
-		if m.audioPlayer != nil {
-			m.audioPlayer.SeekRelative(5 * time.Second)
-		} else {
-			m.playback.Position += 5 * time.Second
-			if m.playback.Position > m.playback.Duration {
-				m.playback.Position = m.playback.Duration
-			}
-		}
+		m.seekBy(m.seekStep())
 		return m, nil
 
 	case key.Matches(msg, m.keyMap.SeekBackward):
-		if m.audioPlayer != nil {
-			m.audioPlayer.SeekRelative(-5 * time.Second)
-		} else {
-			m.playback.Position -= 5 * time.Second
-			if m.playback.Position < 0 {
-				m.playback.Position = 0
-			}
-		}
+		m.seekBy(-m.seekStep())
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.SeekFarForward):
+		m.seekBy(m.farSeekStep())
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.SeekFarBackward):
+		m.seekBy(-m.farSeekStep())
 		return m, nil
 
 	case key.Matches(msg, m.keyMap.VolumeUp):
-		m.volume += 0.1
-		if m.volume > 2.0 {
-			m.volume = 2.0
+		// Changing the volume unmutes, from the remembered level
+		m.muted = false
+		m.volume += m.volumeStep()
+		if m.volume > maxVolume {
+			m.volume = maxVolume
 		}
-		if m.audioPlayer != nil {
-			m.audioPlayer.SetVolume(m.volume)
-		}
-		return m, nil
+		m.applyVolume()
+		return m, m.showVolume()
 
 	case key.Matches(msg, m.keyMap.VolumeDown):
-		m.volume -= 0.1
+		m.muted = false
+		m.volume -= m.volumeStep()
 		if m.volume < 0.0 {
 			m.volume = 0.0
 		}
+		m.applyVolume()
+		return m, m.showVolume()
+
+	case key.Matches(msg, m.keyMap.Restart):
+		if m.currentTrack == nil {
+			return m, nil
+		}
 		if m.audioPlayer != nil {
-			m.audioPlayer.SetVolume(m.volume)
+			m.audioPlayer.Reset()
+		}
+		m.playback.Position = 0
+		m.playback.CurrentLoop = 0
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.FadeStop):
+		return m, m.fadeOutStop()
+
+	case key.Matches(msg, m.keyMap.Mute):
+		m.toggleMute()
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.EndFade):
+		m.toggleEndFade()
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.LoopProgress):
+		m.loopProgress = !m.loopProgress
+		m.notice = "Progress: whole track"
+		if m.loopProgress {
+			m.notice = "Progress: current loop"
 		}
+		m.noticeTime = time.Now()
 		return m, nil
 
 	case key.Matches(msg, m.keyMap.TabFocus):
@@ -681,9 +1306,7 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			var cmd tea.Cmd
 			m.browser, cmd = m.browser.Update(msg)
-			if cmd != nil {
-				return m, cmd
-			}
+			return m, tea.Batch(cmd, m.schedulePreview())
 		}
 	case FocusPlaylist:
 		// Check for playlist-specific actions first
@@ -724,13 +1347,61 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// If we removed the currently playing track, stop playback
 			if wasPlayingRemoved {
 				m.stopPlayback()
+				return m, nil
 			}
-			return m, nil
+			return m, m.requeueNextTrack()
 		case key.Matches(msg, playlistKeyMap.Clear):
 			// Stop playback before clearing since we're removing all tracks
 			m.stopPlayback()
 			m.playlist.Clear()
 			return m, nil
+		case key.Matches(msg, playlistKeyMap.Undo):
+			return m, m.undoPlaylist()
+		case key.Matches(msg, playlistKeyMap.RemoveAbove), key.Matches(msg, playlistKeyMap.RemoveBelow):
+			n := m.playlist.Len()
+			var removedPlaying bool
+			if key.Matches(msg, playlistKeyMap.RemoveAbove) {
+				removedPlaying = m.playlist.RemoveAbove()
+			} else {
+				removedPlaying = m.playlist.RemoveBelow()
+			}
+			if removed := n - m.playlist.Len(); removed > 0 {
+				m.notice = fmt.Sprintf("Removed %d tracks (%s to undo)", removed, playlistKeyMap.Undo.Help().Key)
+				m.noticeTime = time.Now()
+			}
+			// If we removed the currently playing track, stop playback
+			if removedPlaying {
+				m.stopPlayback()
+				return m, nil
+			}
+			return m, m.requeueNextTrack()
+		case key.Matches(msg, playlistKeyMap.PlayNext):
+			if m.playlist.MoveAfterCurrent() {
+				return m, m.requeueNextTrack()
+			}
+			return m, nil
+		case key.Matches(msg, playlistKeyMap.ToTop):
+			if m.playlist.MoveToTop() {
+				return m, m.requeueNextTrack()
+			}
+			return m, nil
+		case key.Matches(msg, playlistKeyMap.ToBottom):
+			if m.playlist.MoveToBottom() {
+				return m, m.requeueNextTrack()
+			}
+			return m, nil
+		case key.Matches(msg, playlistKeyMap.RemoveBroken):
+			removed := m.playlist.RemoveBroken()
+			m.notice = "No unplayable tracks"
+			if removed > 0 {
+				m.notice = fmt.Sprintf("Removed %d unplayable tracks (%s to undo)", removed, playlistKeyMap.Undo.Help().Key)
+			}
+			m.noticeTime = time.Now()
+			return m, m.requeueNextTrack()
+		case key.Matches(msg, playlistKeyMap.Sort):
+			m.notice = "Playlist sorted " + m.playlist.CycleSort().String()
+			m.noticeTime = time.Now()
+			return m, m.requeueNextTrack()
 		default:
 			// Forward navigation keys to playlist
 			var cmd tea.Cmd
@@ -744,6 +1415,25 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// undoPlaylist restores the playlist from before the last track removal
+// or clear.
+func (m *Model) undoPlaylist() tea.Cmd {
+	playing := ""
+	if m.currentTrack != nil {
+		playing = m.currentTrack.Path
+	}
+	if !m.playlist.Undo(playing) {
+		m.lastError = "Nothing to undo"
+		m.errorTime = time.Now()
+		return tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+			return ClearErrorMsg{}
+		})
+	}
+	m.notice = fmt.Sprintf("Restored playlist (%d tracks)", m.playlist.Len())
+	m.noticeTime = time.Now()
+	return m.requeueNextTrack()
+}
+
 // togglePlayPause toggles between playing and paused states.
 func (m Model) togglePlayPause() (tea.Model, tea.Cmd) {
 	if m.audioPlayer != nil {
@@ -813,23 +1503,114 @@ func (m *Model) startPlayingTrack(playlistIndex int) tea.Cmd {
 	}
 
 	// Set pending state (will be confirmed on success)
+	m.queuedIndex = -1
 	m.pendingPlayIndex = playlistIndex
 	m.pendingTrack = track
 	m.trackLoading = true
+	m.skipDir = 0
+	m.skipped = nil
+
+	return tea.Batch(
+		func() tea.Msg { return TrackLoadStartedMsg{} },
+		playTrack(m.audioPlayer, track.Path),
+	)
+}
+
+// advanceToTrack starts playing the track at playlistIndex as a step of
+// dir (1 for next, -1 for previous) through the playlist. If it fails to
+// load, tracks further along in the same direction are tried in turn.
+func (m *Model) advanceToTrack(playlistIndex, dir int) tea.Cmd {
+	cmd := m.startPlayingTrack(playlistIndex)
+	if cmd != nil {
+		m.skipDir = dir
+	}
+	return cmd
+}
+
+// skipFailedTrack handles a track that failed to load while advancing
+// through the playlist: it records the track as skipped and starts the
+// next one in the same direction. It returns nil if the load was not part
+// of an advance. Once no track is left to try, playback stops and the
+// error names the skipped files.
+func (m *Model) skipFailedTrack(err error) tea.Cmd {
+	dir := m.skipDir
+	if dir == 0 || m.pendingTrack == nil {
+		return nil
+	}
+	skipped := append(m.skipped, filepath.Base(m.pendingTrack.Path))
+	next := m.pendingPlayIndex + dir
+	m.cancelPendingTrack()
+	m.resumeAt = 0
+
+	if next >= 0 && next < m.playlist.Len() && len(skipped) < m.playlist.Len() {
+		cmd := m.startPlayingTrack(next)
+		if cmd != nil {
+			m.skipDir = dir
+			m.skipped = skipped
+			return cmd
+		}
+	}
+
+	// Nothing playable left in this direction
+	m.skipDir = 0
+	m.skipped = nil
+	m.stopPlayback()
+	m.lastError = fmt.Sprintf("No playable track left, skipped %s (%v)", strings.Join(skipped, ", "), err)
+	m.errorTime = time.Now()
+	return tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+		return ClearErrorMsg{}
+	})
+}
+
+// refreshTagLanguage shows the playing track's tags in the language now
+// preferred, from the metadata read when it started.
+func (m *Model) refreshTagLanguage() {
+	if m.trackMeta == nil || m.currentTrack == nil {
+		return
+	}
+	m.trackMeta.UseLanguage()
+	m.currentTrack.Title = defaultString(m.trackMeta.Title, m.currentTrack.Title)
+	m.currentTrack.Game = defaultString(m.trackMeta.Game, m.currentTrack.Game)
+	m.currentTrack.System = defaultString(m.trackMeta.System, m.currentTrack.System)
+	m.currentTrack.Composer = defaultString(m.trackMeta.Composer, m.currentTrack.Composer)
+}
 
-	return playTrack(m.audioPlayer, track.Path)
+// trackStarted commits the pending playback state once the player has
+// started a track and queues the one after it for gapless playback.
+func (m *Model) trackStarted(track *metadata.Track, chips []metadata.ChipInfo) tea.Cmd {
+	m.confirmTrackStarted()
+	m.trackMeta = track
+	m.fadeStopping = false // A new track cancels a fade-stop
+	if m.resumeAt > 0 {
+		// Resume an imported session where it left off
+		m.audioPlayer.Seek(m.resumeAt)
+		m.resumeAt = 0
+	}
+	if len(chips) > 0 {
+		m.trackChips = chips
+		m.chipPopup.SetChips(chips)
+	}
+	cmds := []tea.Cmd{saveHistory(m.history), savePlayStats(m.plays), m.windowTitle(), m.announceTrack(), m.queueNextTrack()}
+	if !m.config.FreshPlaylist {
+		cmds = append(cmds, m.savePlaylist())
+	}
+	return tea.Batch(cmds...)
 }
 
 // confirmTrackStarted commits the pending playback state after successful load.
 // Call this when playTrackResult indicates success.
 func (m *Model) confirmTrackStarted() {
 	if m.pendingPlayIndex >= 0 && m.pendingTrack != nil {
+		m.playlist.SetBroken(m.pendingPlayIndex, false) // The file is back
 		m.playlist.SetCurrentTrack(m.pendingPlayIndex)
 		m.currentTrack = m.pendingTrack
+		m.recordHistory(*m.pendingTrack)
 	}
 	m.trackLoading = false
 	m.pendingPlayIndex = -1
 	m.pendingTrack = nil
+	m.skipDir = 0
+	m.skipped = nil
 }
 
 // cancelPendingTrack discards the pending playback state after a failed load.
@@ -847,32 +1628,99 @@ func (m *Model) stopPlayback() {
 	}
 	m.playlist.ClearCurrent()
 	m.currentTrack = nil
+	m.trackMeta = nil
+	m.queuedIndex = -1
+	m.fadeStopping = false
 	m.playback.State = StateStopped
 	m.playback.Position = 0
 	m.playback.CurrentLoop = 0
+	m.notifyObserver()
 }
 
 // loadTrackMetadata returns a command that loads track metadata without
-// affecting the current playback state. It uses a separate player instance
-// to read metadata, so it can be called while music is playing.
-func loadTrackMetadata(path string) tea.Cmd {
+// affecting the current playback state. Readers don't use the playing
+// player, so it can be called while music is playing.
+func loadTrackMetadata(r metadata.Reader, path string) tea.Cmd {
 	return func() tea.Msg {
-		// Read metadata using a temporary player instance
-		track, err := player.ReadTrackMetadata(path)
+		track, err := r.ReadTrackMetadata(path)
 		if err != nil {
-			return ErrorMsg{Err: err}
+			return ErrorMsg{Err: err, Path: path}
 		}
 
-		// Convert player.Track to components.Track
+		// Convert metadata.Track to components.Track
 		return TrackMetadataLoadedMsg{
-			Track: Track{
-				Path:     track.Path,
-				Title:    defaultString(track.Title, filepath.Base(path)),
-				Game:     track.Game,
-				System:   track.System,
-				Composer: track.Composer,
-				Duration: track.Duration,
-			},
+			Track: metadataTrack(path, track),
+			Chips: track.Chips,
+		}
+	}
+}
+
+// loadDirTracks returns a command that reads metadata for files added in
+// bulk. Unreadable files are counted and skipped.
+func loadDirTracks(r metadata.Reader, paths []string) tea.Cmd {
+	return func() tea.Msg {
+		tracks, failed := readTracks(r, paths)
+		return DirTracksLoadedMsg{Tracks: tracks, Files: len(paths), Failed: failed}
+	}
+}
+
+// readTracks reads the metadata of each file, returning the tracks that
+// could be read and how many could not.
+func readTracks(r metadata.Reader, paths []string) (tracks []Track, failed int) {
+	for _, path := range paths {
+		track, err := r.ReadTrackMetadata(path)
+		if err != nil {
+			failed++
+			continue
+		}
+		tracks = append(tracks, metadataTrack(path, track))
+	}
+	return tracks, failed
+}
+
+// previewDelay is how long the file browser cursor must rest on a file
+// before its metadata is read for the preview.
+const previewDelay = 150 * time.Millisecond
+
+// schedulePreview starts a delayed metadata read for the file under the
+// file browser cursor if it changed, superseding any pending read.
+// Returns nil if previews are off or nothing changed.
+func (m *Model) schedulePreview() tea.Cmd {
+	if !m.config.PreviewMetadata || m.useLibrary {
+		return nil
+	}
+
+	var path string
+	if entry := m.browser.SelectedEntry(); entry != nil && !entry.IsDir {
+		path = entry.Path
+	}
+	if path == m.previewPath {
+		return nil
+	}
+
+	m.previewPath = path
+	m.previewSeq++
+	m.preview = nil
+	m.previewChips = nil
+	if path == "" {
+		return nil
+	}
+	seq := m.previewSeq
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg {
+		return PreviewTickMsg{Seq: seq}
+	})
+}
+
+// loadPreview returns a command that reads metadata for the preview.
+func loadPreview(r metadata.Reader, path string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		track, err := r.ReadTrackMetadata(path)
+		if err != nil {
+			return TrackPreviewMsg{Seq: seq, Err: err}
+		}
+		return TrackPreviewMsg{
+			Seq: seq,
+			Track: metadataTrack(path, track),
 			Chips: track.Chips,
 		}
 	}
@@ -880,24 +1728,16 @@ func loadTrackMetadata(path string) tea.Cmd {
 
 // loadTrackMetadataForPlay returns a command that loads track metadata and
 // signals that the track should be played immediately after adding.
-func loadTrackMetadataForPlay(path string) tea.Cmd {
+func loadTrackMetadataForPlay(r metadata.Reader, path string) tea.Cmd {
 	return func() tea.Msg {
-		// Read metadata using a temporary player instance
-		track, err := player.ReadTrackMetadata(path)
+		track, err := r.ReadTrackMetadata(path)
 		if err != nil {
-			return ErrorMsg{Err: err}
+			return ErrorMsg{Err: err, Path: path}
 		}
 
-		// Convert player.Track to components.Track
+		// Convert metadata.Track to components.Track
 		return TrackMetadataForPlayMsg{
-			Track: Track{
-				Path:     track.Path,
-				Title:    defaultString(track.Title, filepath.Base(path)),
-				Game:     track.Game,
-				System:   track.System,
-				Composer: track.Composer,
-				Duration: track.Duration,
-			},
+			Track: metadataTrack(path, track),
 			Chips: track.Chips,
 		}
 	}
@@ -917,7 +1757,7 @@ func playTrack(ap *player.AudioPlayer, path string) tea.Cmd {
 		}
 		// Return chip info after track is loaded and playing
 		if track := ap.Track(); track != nil {
-			return playTrackResult{chips: track.Chips}
+			return playTrackResult{chips: track.Chips, track: track}
 		}
 		return playTrackResult{}
 	}
@@ -926,7 +1766,37 @@ func playTrack(ap *player.AudioPlayer, path string) tea.Cmd {
 // playTrackResult bundles the result of a playTrack command.
 type playTrackResult struct {
 	err   error
-	chips []player.ChipInfo
+	chips []metadata.ChipInfo
+	track *metadata.Track // Full metadata of the loaded track
+}
+
+// windowTitle returns a command that sets the terminal window title to the
+// playing track as "Game - Title".
+func (m Model) windowTitle() tea.Cmd {
+	if m.currentTrack == nil {
+		return tea.SetWindowTitle("vgmtui")
+	}
+	title := m.currentTrack.Title
+	if game := m.currentTrack.GameName(m.gameLabel); game != "" {
+		title = game + " - " + title
+	}
+	return tea.SetWindowTitle(metadata.CleanTag(title))
+}
+
+// errorText describes an error for the footer. Errors loading a file name
+// it and say whether it could not be read or is not something libvgm plays.
+func errorText(err error, path string) string {
+	if path == "" {
+		return err.Error()
+	}
+	name := filepath.Base(path)
+	switch {
+	case errors.Is(err, player.ErrFileFormat):
+		return "Unsupported or corrupt file: " + name
+	case errors.Is(err, player.ErrFileOpen):
+		return "File not found or unreadable: " + name
+	}
+	return name + ": " + err.Error()
 }
 
 // defaultString returns s if non-empty, otherwise returns def.
@@ -938,12 +1808,11 @@ func defaultString(s, def string) string {
 }
 
 // loadLibTrackMetadata returns a command that loads track metadata from a library track.
-func loadLibTrackMetadata(t library.Track) tea.Cmd {
+func loadLibTrackMetadata(r metadata.Reader, t library.Track) tea.Cmd {
 	return func() tea.Msg {
-		// Read full metadata using a temporary player instance
-		track, err := player.ReadTrackMetadata(t.Path)
+		track, err := r.ReadTrackMetadata(t.Path)
 		if err != nil {
-			return ErrorMsg{Err: err}
+			return ErrorMsg{Err: err, Path: t.Path}
 		}
 
 		return TrackMetadataLoadedMsg{
@@ -951,6 +1820,7 @@ func loadLibTrackMetadata(t library.Track) tea.Cmd {
 				Path:     track.Path,
 				Title:    defaultString(track.Title, t.Title),
 				Game:     defaultString(track.Game, t.Game),
+				DirGame:  t.DirGame,
 				System:   defaultString(track.System, t.System),
 				Composer: defaultString(track.Composer, t.Composer),
 				Duration: track.Duration,
@@ -959,3 +1829,106 @@ func loadLibTrackMetadata(t library.Track) tea.Cmd {
 		}
 	}
 }
+
+// scopeFrames is the number of frames sampled for the oscilloscope
+// (about 23ms at 44.1kHz, half of which is displayed after triggering).
+const scopeFrames = 1024
+
+// updateScope refreshes the oscilloscope from the player's scope tap.
+// It does nothing while the oscilloscope is hidden.
+func (m *Model) updateScope() {
+	if !m.showScope {
+		return
+	}
+	if m.audioPlayer == nil || m.playback.State == StateStopped {
+		m.scope.SetSamples(nil)
+		return
+	}
+	buf := make([]int16, scopeFrames*2)
+	n := m.audioPlayer.Scope(buf)
+	m.scope.SetSamples(buf[:n*2])
+}
+
+// updateMeters feeds the VU meters one tick of levels from the player.
+// Outside of playback the meters are fed silence so they fall back.
+func (m *Model) updateMeters() {
+	if !m.showMeters {
+		return
+	}
+	if m.audioPlayer == nil ||
+		(m.playback.State != StatePlaying && m.playback.State != StateFading) {
+		m.vuMeter.Update(0, 0, 0, 0)
+		return
+	}
+	left, right := m.audioPlayer.Levels()
+	peakL, peakR := m.audioPlayer.Peaks()
+	m.vuMeter.Update(left, right, peakL, peakR)
+}
+
+// resize sizes all components to the current window dimensions.
+func (m *Model) resize() {
+	// Match the layout calculations from View()
+	footerHeight := 1
+	mainHeight := m.height - footerHeight
+
+	m.resizeOverlays()
+	if m.compactLayout() {
+		// One panel at a time, full width, above two lines of status
+		innerWidth := m.width - 2
+		innerHeight := mainHeight - 2 - 3 // status lines(2) + border(2) + title(1)
+		m.browser.SetSize(innerWidth, innerHeight)
+		if m.useLibrary {
+			m.libBrowser.SetSize(innerWidth, innerHeight)
+		}
+		m.playlist.SetSize(innerWidth, innerHeight)
+		m.progress.SetWidth(m.width)
+		return
+	}
+
+	// Panel widths
+	libraryWidth := m.width * m.libraryPercent / 100
+	rightWidth := m.width - libraryWidth
+
+	// Browser size: outer=libraryWidth x mainHeight, inner subtracts border(2) and title(1)
+	browserInnerWidth := libraryWidth - 2
+	browserInnerHeight := mainHeight - 3 // border(2) + title(1)
+	m.browser.SetSize(browserInnerWidth, browserInnerHeight)
+	if m.useLibrary {
+		m.libBrowser.SetSize(browserInnerWidth, browserInnerHeight)
+	}
+
+	// Right pane layout (from renderRightPane)
+	progressHeight := 4  // No title now
+	trackInfoHeight := 6
+	if m.showMeters {
+		progressHeight += 2
+	}
+	if m.showDiagnostics {
+		progressHeight++
+	}
+	playlistHeight := mainHeight - progressHeight - trackInfoHeight
+
+	// Playlist size: inner dimensions
+	playlistInnerWidth := rightWidth - 2
+	playlistInnerHeight := playlistHeight - 3 // border(2) + title(1)
+	m.playlist.SetSize(playlistInnerWidth, playlistInnerHeight)
+
+	// Progress bar width (inside progress panel)
+	progressInnerWidth := rightWidth - 4 // border + some padding
+	m.progress.SetWidth(progressInnerWidth)
+}
+
+// resizeOverlays sizes the popups to the terminal.
+func (m *Model) resizeOverlays() {
+	m.helpPopup.SetSize(m.width, m.height)
+	m.chipPopup.SetSize(m.width, m.height)
+	m.diffPopup.SetSize(m.width, m.height)
+	m.audioPopup.SetSize(m.width, m.height)
+	m.historyPopup.SetSize(m.width, m.height)
+	m.statsPopup.SetSize(m.width, m.height)
+	m.chipPicker.SetSize(m.width, m.height)
+	m.palette.SetSize(m.width, m.height)
+	m.keyBindings.SetSize(m.width, m.height)
+	m.dupPopup.SetSize(m.width, m.height)
+	m.tagEditor.SetSize(m.width, m.height)
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dewi-tim/vgmtui/internal/config"
+	"github.com/dewi-tim/vgmtui/internal/library"
+	"github.com/dewi-tim/vgmtui/internal/ui/components"
+)
+
+func TestGameLabelKey(t *testing.T) {
+	m := newTestModel(t)
+	m.playlist.AddTracks([]components.Track{
+		{Path: "/vgm/sonic1/01.vgm", Title: "Green Hill Zone", Game: "Sonic", DirGame: "s1-rip"},
+	})
+
+	steps := []struct {
+		label      library.GameLabel
+		shown, not string
+	}{
+		{library.GameLabelDir, "s1-rip", "Sonic"},
+		{library.GameLabelGD3, "Sonic", "s1-rip"},
+	}
+	for _, step := range steps {
+		m = press(m, m.keyMap.GameLabel)
+		if m.gameLabel != step.label {
+			t.Fatalf("game label = %v, want %v", m.gameLabel, step.label)
+		}
+		view := m.playlist.View()
+		if !strings.Contains(view, step.shown) || strings.Contains(view, step.not) {
+			t.Errorf("with %v labels the playlist shows:\n%s\nwant %q and not %q", step.label, view, step.shown, step.not)
+		}
+	}
+}
+
+func TestConfigSavesOnlyChanges(t *testing.T) {
+	m := newTestModel(t)
+	if err := config.Default().Save(); err != nil {
+		t.Fatal(err)
+	}
+	// Set for this run only, as by a command-line flag
+	m.config.Theme = "nord"
+	m.config.AudioBufferMs = 5
+
+	save := func(cmd tea.Cmd) config.Config {
+		t.Helper()
+		if cmd == nil {
+			t.Fatal("change not saved")
+		}
+		if msg := cmd(); msg != nil {
+			t.Fatalf("saving the config: %v", msg)
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if def := config.Default(); cfg.Theme != def.Theme || cfg.AudioBufferMs != def.AudioBufferMs {
+			t.Errorf("settings for this run saved: theme %q, buffer %dms", cfg.Theme, cfg.AudioBufferMs)
+		}
+		return cfg
+	}
+
+	next, cmd := m.Update(keyMsg(m.keyMap.WidenLibrary.Keys()[0]))
+	m = next.(Model)
+	if cfg := save(cmd); cfg.LibraryWidthPercent != m.libraryPercent {
+		t.Errorf("saved library width %d%%, want %d%%", cfg.LibraryWidthPercent, m.libraryPercent)
+	}
+	if cfg := save(m.rebind("cycle_theme", "ctrl+t")); !slices.Equal(cfg.Keys["cycle_theme"], []string{"ctrl+t"}) {
+		t.Errorf("saved keys %v after rebinding", cfg.Keys)
+	}
+	if cfg := save(m.rebind("cycle_theme", "")); len(cfg.Keys) != 0 {
+		t.Errorf("saved keys %v after resetting the binding", cfg.Keys)
+	}
+	if m.config.LibraryWidthPercent != m.libraryPercent || len(m.config.Keys) != 0 {
+		t.Errorf("model config not updated: width %d%%, keys %v", m.config.LibraryWidthPercent, m.config.Keys)
+	}
+}
+
+func TestUndoNoticeNamesKey(t *testing.T) {
+	m := newTestModel(t)
+	m.rebind("playlist.undo", "u")
+	m.playlist.AddTracks([]components.Track{{Path: "/vgm/a.vgm"}, {Path: "/vgm/b.vgm"}, {Path: "/vgm/c.vgm"}})
+	m.focus = FocusPlaylist
+	m.playlist.GoToIndex(2)
+
+	m = press(m, m.playlist.KeyMap().RemoveAbove)
+	if want := "Removed 2 tracks (u to undo)"; m.notice != want {
+		t.Errorf("notice after removing tracks = %q, want %q", m.notice, want)
+	}
+
+	m.playlist.SetBroken(0, true)
+	m = press(m, m.playlist.KeyMap().RemoveBroken)
+	if want := "Removed 1 unplayable tracks (u to undo)"; m.notice != want {
+		t.Errorf("notice after removing unplayable tracks = %q, want %q", m.notice, want)
+	}
+}
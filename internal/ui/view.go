@@ -6,15 +6,33 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+	"github.com/dewi-tim/vgmtui/internal/ui/components"
 )
 
 const (
-	// Minimum dimensions
+	// Minimum dimensions for the full layout
 	minWidth  = 60
 	minHeight = 15
 
-	// Panel proportions
-	libraryWidthPercent = 30
+	// Minimum dimensions for the compact single-column layout used below
+	// the full layout's minimum
+	compactMinWidth  = 30
+	compactMinHeight = 8
+
+	// Panel proportions: the library pane's default share of the width,
+	// the range it can be resized within and the step per key press
+	libraryWidthPercent    = 30
+	minLibraryWidthPercent = 15
+	maxLibraryWidthPercent = 70
+	libraryWidthStep       = 5
+
+	// Volume slider: the highest volume, the slider's bar width and how
+	// long it stays in the status line after a change
+	maxVolume         = 2.0
+	volumeSliderWidth = 10
+	volumeShowTime    = 1500 * time.Millisecond
 )
 
 // View renders the entire UI.
@@ -24,7 +42,7 @@ func (m Model) View() string {
 	}
 
 	// Handle small terminal
-	if m.width < minWidth || m.height < minHeight {
+	if m.width < compactMinWidth || m.height < compactMinHeight {
 		return m.renderTooSmall()
 	}
 
@@ -32,15 +50,20 @@ func (m Model) View() string {
 	footerHeight := 1
 	mainHeight := m.height - footerHeight
 
-	// Calculate panel widths
-	libraryWidth := m.width * libraryWidthPercent / 100
-	rightWidth := m.width - libraryWidth
+	var mainContent string
+	if m.compactLayout() {
+		mainContent = m.renderCompact(m.width, mainHeight)
+	} else {
+		// Calculate panel widths
+		libraryWidth := m.width * m.libraryPercent / 100
+		rightWidth := m.width - libraryWidth
 
-	// Build the main layout - both panels take full mainHeight
-	leftPanel := m.renderLibrary(libraryWidth, mainHeight)
-	rightPanel := m.renderRightPane(rightWidth, mainHeight)
+		// Build the main layout - both panels take full mainHeight
+		leftPanel := m.renderLibrary(libraryWidth, mainHeight)
+		rightPanel := m.renderRightPane(rightWidth, mainHeight)
 
-	mainContent := lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel)
+		mainContent = lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel)
+	}
 
 	// Ensure main content takes exactly mainHeight lines
 	mainContent = lipgloss.NewStyle().
@@ -58,18 +81,16 @@ func (m Model) View() string {
 
 	mainView := lipgloss.JoinVertical(lipgloss.Left, mainContent, footer)
 
-	// Render help overlay if visible
-	if m.helpPopup.Visible() {
-		return m.renderHelpOverlay(mainView)
+	if o := m.activeOverlay(); o != nil {
+		return m.renderOverlay(mainView, o.View())
 	}
 
 	return mainView
 }
 
-// renderHelpOverlay renders the help popup centered over the main view.
+// renderOverlay renders a popup centered over the main view.
 // We replace entire lines to avoid ANSI escape code corruption.
-func (m Model) renderHelpOverlay(mainView string) string {
-	popup := m.helpPopup.View()
+func (m Model) renderOverlay(mainView, popup string) string {
 	if popup == "" {
 		return mainView
 	}
@@ -124,10 +145,65 @@ func (m Model) renderHelpOverlay(mainView string) string {
 // renderTooSmall renders a message when the terminal is too small.
 func (m Model) renderTooSmall() string {
 	msg := fmt.Sprintf("Terminal too small\nNeed at least %dx%d\nCurrent: %dx%d",
-		minWidth, minHeight, m.width, m.height)
-	return lipgloss.NewStyle().
-		Foreground(ColorTextMuted).
-		Render(msg)
+		compactMinWidth, compactMinHeight, m.width, m.height)
+	return m.styles.TextMuted.Render(msg)
+}
+
+// compactLayout reports whether the terminal is below the full layout's
+// minimum size, so the compact single-column layout is used.
+func (m Model) compactLayout() bool {
+	return m.width < minWidth || m.height < minHeight
+}
+
+// renderCompact renders the single-column layout for small terminals: the
+// focused browser or playlist, then a now-playing line and the progress
+// bar. Track info and the other panels are hidden.
+func (m Model) renderCompact(width, height int) string {
+	panelHeight := height - 2 // Now-playing line and progress bar
+	var panel string
+	if m.focus == FocusPlaylist {
+		panel = m.renderPlaylist(width, panelHeight)
+	} else {
+		panel = m.renderLibrary(width, panelHeight)
+	}
+
+	statusStyle, statusIcon, _ := m.playbackStatus()
+	nowPlaying := m.styles.TextMuted.Render("Nothing playing")
+	if m.currentTrack != nil {
+		title := m.currentTrack.Title
+		if game := m.currentTrack.GameName(m.gameLabel); game != "" {
+			title += " - " + game
+		}
+		nowPlaying = m.styles.Text.Render(fitText(title, width-len(statusIcon)-1))
+	}
+	nowPlaying = statusStyle.Render(statusIcon) + " " + nowPlaying
+
+	elapsed, length, loopPoint := m.progressTimes()
+	m.progress.SetWidth(width)
+	m.progress.SetElapsed(elapsed)
+	m.progress.SetDuration(length)
+	m.progress.SetLoopPoint(loopPoint)
+
+	return lipgloss.JoinVertical(lipgloss.Left, panel, nowPlaying, m.progress.View())
+}
+
+// fitText makes metadata safe to show on one line of at most width cells:
+// line breaks and control characters become spaces, and anything longer
+// is cut with "...".
+func fitText(s string, width int) string {
+	return truncateWidth(metadata.CleanTag(s), width)
+}
+
+// truncateWidth shortens s to at most width cells, ending in "..." if cut.
+func truncateWidth(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && lipgloss.Width(string(r))+3 > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "..."
 }
 
 // renderLibrary renders the left library panel.
@@ -181,6 +257,12 @@ func (m Model) renderRightPane(width, height int) string {
 	// Fixed heights for bottom panels (like termusic's Constraint::Length)
 	progressHeight := 4  // Status line + progress bar + border(2), no title
 	trackInfoHeight := 6 // Track info with border
+	if m.showMeters {
+		progressHeight += 2 // Left and right meters
+	}
+	if m.showDiagnostics {
+		progressHeight++ // Audio diagnostics line
+	}
 
 	// Playlist takes remaining space (like termusic's Constraint::Min)
 	playlistHeight := height - progressHeight - trackInfoHeight
@@ -188,11 +270,105 @@ func (m Model) renderRightPane(width, height int) string {
 		playlistHeight = 3
 	}
 
-	playlist := m.renderPlaylist(width, playlistHeight)
+	middle := m.renderMiddlePane(width, playlistHeight)
 	trackInfo := m.renderTrackInfo(width, trackInfoHeight)
+	if m.showScope {
+		trackInfo = m.renderScope(width, trackInfoHeight)
+	}
 	progress := m.renderProgress(width, progressHeight)
 
-	return lipgloss.JoinVertical(lipgloss.Left, playlist, trackInfo, progress)
+	return lipgloss.JoinVertical(lipgloss.Left, middle, trackInfo, progress)
+}
+
+// renderMiddlePane renders the panel above the track info: the playlist,
+// or the full track info when toggled on.
+func (m Model) renderMiddlePane(width, height int) string {
+	if m.showFullInfo {
+		return m.renderFullTrackInfo(width, height)
+	}
+	return m.renderPlaylist(width, height)
+}
+
+// renderFullTrackInfo renders every known detail of the playing track:
+// all GD3 fields, the format, loop details and each chip with its
+// emulation core and clock.
+func (m Model) renderFullTrackInfo(width, height int) string {
+	const title = "Track Details"
+	meta := m.trackMeta
+	if meta == nil {
+		content := m.styles.TextMuted.Render("No track playing")
+		return m.styles.RenderPanel(title, content, false, width, height)
+	}
+
+	var b strings.Builder
+	const labelWidth = 10 // "Loop from:" is longest
+	valueWidth := max(width-2-labelWidth-1, 10)
+	addRow := func(label, value string) {
+		if value == "" {
+			value = "(Unknown)"
+		}
+		b.WriteString(fmt.Sprintf("%s %s\n",
+			m.styles.TextMuted.Render(fmt.Sprintf("%*s", labelWidth, label+":")),
+			m.styles.Text.Render(fitText(value, valueWidth))))
+	}
+
+	b.WriteString(fmt.Sprintf("%s %s\n",
+		m.styles.TextMuted.Render(fmt.Sprintf("%*s", labelWidth, "Track:")),
+		m.styles.TextBold.Render(fitText(defaultString(meta.Title, "(Unknown)"), valueWidth))))
+	addRow("Game", meta.Game)
+	addRow("System", meta.System)
+	addRow("Composer", meta.Composer)
+	addRow("Date", meta.Date)
+	addRow("VGM by", meta.VGMBy)
+	addRow("Format", meta.Format)
+	addRow("Length", formatMinSec(meta.Duration))
+	if meta.HasLoop {
+		addRow("Loop from", formatMinSec(meta.LoopPoint))
+	} else {
+		addRow("Loop from", "no loop")
+	}
+	addRow("File", meta.Path)
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.TextBold.Render("Chips"))
+	b.WriteString("\n")
+	if len(meta.Chips) == 0 {
+		b.WriteString(m.styles.TextMuted.Render("  (none reported)"))
+		b.WriteString("\n")
+	}
+	for _, chip := range meta.Chips {
+		line := fmt.Sprintf("  %-10s %-8s", chip.Name, chip.Core)
+		if chip.Clock > 0 {
+			line += " " + components.FormatClock(chip.Clock)
+		}
+		b.WriteString(m.styles.Text.Render(line))
+		b.WriteString("\n")
+	}
+
+	if meta.Notes != "" {
+		b.WriteString("\n")
+		b.WriteString(m.styles.TextBold.Render("Notes"))
+		b.WriteString("\n")
+		notes := lipgloss.NewStyle().Width(width - 4).Render(meta.Notes)
+		b.WriteString(m.styles.Text.Render(notes))
+	}
+
+	// Inner height after border (2) and title (1 line)
+	content := constrainContentHeight(b.String(), height-3)
+	return m.styles.RenderPanel(title, content, false, width, height)
+}
+
+// formatMinSec formats a duration as M:SS.
+func formatMinSec(d time.Duration) string {
+	total := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
+
+// renderScope renders the oscilloscope panel in place of the track info.
+func (m Model) renderScope(width, height int) string {
+	// Inner size after border (2) and title (1 line)
+	m.scope.SetSize(width-2, height-3)
+	return m.styles.RenderPanel("Oscilloscope", m.scope.View(), false, width, height)
 }
 
 // renderPlaylist renders the playlist panel.
@@ -208,107 +384,213 @@ func (m Model) renderPlaylist(width, height int) string {
 	return m.styles.RenderPanel(title, content, focused, width, height)
 }
 
-// renderTrackInfo renders the track information panel.
+// renderTrackInfo renders the track information panel. While browsing
+// files with previews on, it shows the file under the cursor instead.
 func (m Model) renderTrackInfo(width, height int) string {
+	if m.preview != nil && m.focus == FocusBrowser && !m.useLibrary {
+		content := m.trackDetails(m.preview, m.previewChips, width-2)
+		return m.styles.RenderPanel("Preview (not playing)", content, false, width, height)
+	}
+
+	if m.currentTrack == nil {
+		content := m.styles.TextMuted.Render("No track loaded") + "\n" +
+			m.styles.TextMuted.Render("Select a VGM file from the library")
+		return m.styles.RenderPanel("Track Info", content, false, width, height)
+	}
+
+	// Pass full outer dimensions - RenderPanel handles inner calculation
+	content := m.trackDetails(m.currentTrack, m.trackChips, width-2)
+	return m.styles.RenderPanel("Track Info", content, false, width, height)
+}
+
+// trackDetails renders the title, game, system, chips and composer lines
+// of the track info panel, each fitted to width cells.
+func (m Model) trackDetails(track *Track, chips []metadata.ChipInfo, width int) string {
 	content := strings.Builder{}
 
 	// Fixed label width for alignment
 	const labelWidth = 9 // "Composer:" is longest at 9 chars
+	valueWidth := max(width-labelWidth-1, 10)
 
-	if m.currentTrack != nil {
-		// Title
-		title := m.currentTrack.Title
-		if title == "" {
-			title = "(Unknown)"
-		}
-		content.WriteString(fmt.Sprintf("%s %s\n",
-			m.styles.TextMuted.Render(fmt.Sprintf("%*s", labelWidth, "Track:")),
-			m.styles.TextBold.Render(title)))
-
-		// Game
-		game := m.currentTrack.Game
-		if game == "" {
-			game = "(Unknown)"
-		}
-		content.WriteString(fmt.Sprintf("%s %s\n",
-			m.styles.TextMuted.Render(fmt.Sprintf("%*s", labelWidth, "Game:")),
-			m.styles.Text.Render(game)))
-
-		// System and Chips on same line
-		system := m.currentTrack.System
-		if system == "" {
-			system = "(Unknown)"
-		}
-		chipList := m.formatChipList()
-		content.WriteString(fmt.Sprintf("%s %-12s %s %s\n",
-			m.styles.TextMuted.Render(fmt.Sprintf("%*s", labelWidth, "System:")),
-			m.styles.Text.Render(system),
-			m.styles.TextMuted.Render("Chips:"),
-			m.styles.Text.Render(chipList)))
-
-		// Composer
-		composer := m.currentTrack.Composer
-		if composer == "" {
-			composer = "(Unknown)"
-		}
-		content.WriteString(fmt.Sprintf("%s %s",
-			m.styles.TextMuted.Render(fmt.Sprintf("%*s", labelWidth, "Composer:")),
-			m.styles.Text.Render(composer)))
-	} else {
-		content.WriteString(m.styles.TextMuted.Render("No track loaded"))
-		content.WriteString("\n")
-		content.WriteString(m.styles.TextMuted.Render("Select a VGM file from the library"))
+	// Title
+	title := track.Title
+	if title == "" {
+		title = "(Unknown)"
 	}
+	content.WriteString(fmt.Sprintf("%s %s\n",
+		m.styles.TextMuted.Render(fmt.Sprintf("%*s", labelWidth, "Track:")),
+		m.styles.TextBold.Render(fitText(title, valueWidth))))
+
+	// Game
+	game := track.GameName(m.gameLabel)
+	if game == "" {
+		game = "(Unknown)"
+	}
+	content.WriteString(fmt.Sprintf("%s %s\n",
+		m.styles.TextMuted.Render(fmt.Sprintf("%*s", labelWidth, "Game:")),
+		m.styles.Text.Render(fitText(game, valueWidth))))
+
+	// System and Chips on same line
+	system := track.System
+	if system == "" {
+		system = "(Unknown)"
+	}
+	system = fitText(system, valueWidth)
+	chipList := truncateWidth(m.formatChipList(chips), max(valueWidth-max(lipgloss.Width(system), 12)-8, 10))
+	content.WriteString(fmt.Sprintf("%s %-12s %s %s\n",
+		m.styles.TextMuted.Render(fmt.Sprintf("%*s", labelWidth, "System:")),
+		m.styles.Text.Render(system),
+		m.styles.TextMuted.Render("Chips:"),
+		m.styles.Text.Render(chipList)))
+
+	// Composer
+	composer := track.Composer
+	if composer == "" {
+		composer = "(Unknown)"
+	}
+	content.WriteString(fmt.Sprintf("%s %s",
+		m.styles.TextMuted.Render(fmt.Sprintf("%*s", labelWidth, "Composer:")),
+		m.styles.Text.Render(fitText(composer, valueWidth))))
 
-	// Pass full outer dimensions - RenderPanel handles inner calculation
-	return m.styles.RenderPanel("Track Info", content.String(), false, width, height)
+	return content.String()
+}
+
+// chipAbbreviations maps common sound chip names to short family names
+// used by the compact chip list.
+var chipAbbreviations = map[string]string{
+	"YM2151":    "OPM",
+	"YM2203":    "OPN",
+	"YM2413":    "OPLL",
+	"YM2608":    "OPNA",
+	"YM2610":    "OPNB",
+	"YM2610B":   "OPNB-B",
+	"YM2612":    "OPN2",
+	"YM3438":    "OPN2C",
+	"YM3526":    "OPL",
+	"YM3812":    "OPL2",
+	"Y8950":     "MSX-AUDIO",
+	"YMF262":    "OPL3",
+	"YMF278B":   "OPL4",
+	"YMF271":    "OPX",
+	"YMZ280B":   "YMZ",
+	"SN76489":   "DCSG",
+	"SN76496":   "DCSG",
+	"AY8910":    "AY",
+	"AY-3-8910": "AY",
+	"RF5C68":    "RF5C",
+	"RF5C164":   "RF5C",
+	"SegaPCM":   "SPCM",
+	"HuC6280":   "PCE",
+	"OKIM6258":  "M6258",
+	"OKIM6295":  "M6295",
+	"K051649":   "SCC",
+	"NES APU":   "APU",
+	"GB DMG":    "DMG",
+}
+
+// abbreviateChip returns the short name for a chip, or the name unchanged
+// if it has no known abbreviation.
+func abbreviateChip(name string) string {
+	if abbr, ok := chipAbbreviations[name]; ok {
+		return abbr
+	}
+	return name
 }
 
 // formatChipList formats the chip info into a readable string.
-func (m Model) formatChipList() string {
-	if len(m.trackChips) == 0 {
+// In compact mode, known chips are abbreviated and repeats are collapsed
+// into a count (e.g. "OPN2, DCSG x2").
+func (m Model) formatChipList(chips []metadata.ChipInfo) string {
+	if len(chips) == 0 {
 		return "(none)"
 	}
 
-	chips := make([]string, 0, len(m.trackChips))
-	for _, chip := range m.trackChips {
-		chips = append(chips, chip.Name)
+	names := make([]string, 0, len(chips))
+	for _, chip := range chips {
+		names = append(names, chip.Name)
 	}
-	return strings.Join(chips, ", ")
+	if !m.compactChips {
+		return strings.Join(names, ", ")
+	}
+	return strings.Join(compactChipNames(names), ", ")
 }
 
-// renderProgress renders the progress bar and playback status.
-func (m Model) renderProgress(width, height int) string {
-	// Status indicator
-	var statusStyle lipgloss.Style
-	var statusText string
-	var statusIcon string
+// compactChipNames abbreviates chip names and collapses duplicates,
+// preserving first-seen order.
+func compactChipNames(names []string) []string {
+	counts := make(map[string]int, len(names))
+	order := make([]string, 0, len(names))
+	for _, name := range names {
+		abbr := abbreviateChip(name)
+		if counts[abbr] == 0 {
+			order = append(order, abbr)
+		}
+		counts[abbr]++
+	}
 
+	result := make([]string, len(order))
+	for i, abbr := range order {
+		if counts[abbr] > 1 {
+			result[i] = fmt.Sprintf("%s x%d", abbr, counts[abbr])
+		} else {
+			result[i] = abbr
+		}
+	}
+	return result
+}
+
+// playbackStatus returns the style, icon and text describing the
+// playback state.
+func (m Model) playbackStatus() (style lipgloss.Style, icon, text string) {
 	switch m.playback.State {
-	case StatePlaying:
-		statusStyle = m.styles.StatusPlaying
-		statusText = "Playing"
-		statusIcon = ">"
 	case StatePaused:
-		statusStyle = m.styles.StatusPaused
-		statusText = "Paused"
-		statusIcon = "||"
+		return m.styles.StatusPaused, "||", "Paused"
 	case StateStopped:
-		statusStyle = m.styles.StatusStopped
-		statusText = "Stopped"
-		statusIcon = "[]"
-	case StateFading:
-		statusStyle = m.styles.StatusPlaying
-		statusText = "Playing"
-		statusIcon = ">"
+		return m.styles.StatusStopped, "[]", "Stopped"
+	default: // Playing or fading out
+		return m.styles.StatusPlaying, ">", "Playing"
 	}
+}
 
-	// Loop info (show "Fading..." during fade-out instead of loop count)
+// renderProgress renders the progress bar and playback status.
+func (m Model) renderProgress(width, height int) string {
+	// Status indicator
+	statusStyle, statusIcon, statusText := m.playbackStatus()
+
+	// Loop info (show "Fading..." during fade-out instead of loop count,
+	// and nothing for tracks that don't loop)
 	loopInfo := ""
-	if m.playback.State == StateFading {
+	switch {
+	case m.playback.State == StateFading:
 		loopInfo = " | Fading..."
-	} else if m.playback.TotalLoops > 0 {
-		loopInfo = fmt.Sprintf(" | Loop %d/%d", m.playback.CurrentLoop+1, m.playback.TotalLoops)
+	case !m.playback.HasLoop:
+	case m.playback.TotalLoops == 0:
+		infinity := "∞"
+		if m.ascii {
+			infinity = "inf"
+		}
+		loopInfo = fmt.Sprintf(" | Loop %d/%s", m.playback.CurrentLoop+1, infinity)
+	default:
+		end := "fade"
+		if m.endCut {
+			end = "cut"
+		}
+		loopInfo = fmt.Sprintf(" | Loop %d/%d, then %s", m.playback.CurrentLoop+1, m.playback.TotalLoops, end)
+	}
+	if m.speed != 1 {
+		loopInfo += fmt.Sprintf(" | %gx", m.speed)
+	}
+	if percent := int(m.volume*100 + 0.5); percent != 100 && !m.muted {
+		loopInfo += fmt.Sprintf(" | Vol %d%%", percent)
+	}
+	switch m.playlist.ShuffleMode() {
+	case components.ShuffleRandom:
+		loopInfo += " | Shuffle"
+	case components.ShuffleWeighted:
+		loopInfo += " | Smart shuffle"
+	}
+	if m.radio {
+		loopInfo += " | Radio"
 	}
 
 	// Status line
@@ -316,40 +598,118 @@ func (m Model) renderProgress(width, height int) string {
 		statusStyle.Render(statusIcon),
 		statusStyle.Render(statusText),
 		m.styles.TextMuted.Render(loopInfo))
+	if m.trackLoading {
+		statusLine = m.loadSpinner.View() + " " + m.styles.StatusPlaying.Render("Loading...")
+	}
+	if time.Since(m.volumeTime) < volumeShowTime {
+		statusLine += "  " + m.progress.LevelView("Vol", m.volume, maxVolume, volumeSliderWidth)
+	}
 
 	// Progress bar - use full inner width (subtract borders only)
 	innerWidth := width - 2
 	if innerWidth < 10 {
 		innerWidth = 10
 	}
+	elapsed, length, loopPoint := m.progressTimes()
 	m.progress.SetWidth(innerWidth)
-	m.progress.SetElapsed(m.playback.Position)
-	m.progress.SetDuration(m.playback.Duration)
+	m.progress.SetElapsed(elapsed)
+	m.progress.SetDuration(length)
+	m.progress.SetLoopPoint(loopPoint)
 	progressBar := m.progress.View()
 
 	// Build content without title - just status and progress bar
 	content := lipgloss.JoinVertical(lipgloss.Left, statusLine, progressBar)
+	if m.showMeters {
+		m.vuMeter.SetWidth(innerWidth)
+		content = lipgloss.JoinVertical(lipgloss.Left, content, m.vuMeter.View())
+	}
+	if m.showDiagnostics {
+		line := truncateWidth(m.diagnostics(), innerWidth)
+		content = lipgloss.JoinVertical(lipgloss.Left, content, m.styles.TextMuted.Render(line))
+	}
 
 	// Render with border but no title
 	return m.styles.RenderProgressPanel(content, width, height)
 }
 
+// loopPoint returns where the playing track's loop starts, or 0 if it
+// doesn't loop.
+func (m Model) loopPoint() time.Duration {
+	if !m.playback.HasLoop || m.trackMeta == nil {
+		return 0
+	}
+	return m.trackMeta.LoopPoint
+}
+
+// progressTimes returns the elapsed time, length and loop point shown on
+// the progress bar: those of the whole track, or in per-loop mode those of
+// the pass through the loop now playing, the first pass including the
+// intro.
+func (m Model) progressTimes() (elapsed, length, loopPoint time.Duration) {
+	meta := m.trackMeta
+	if !m.loopProgress || !m.playback.HasLoop || meta == nil || meta.LoopLength <= 0 {
+		return m.playback.Position, m.playback.Duration, m.loopPoint()
+	}
+	if m.playback.CurrentLoop == 0 {
+		return m.playback.Position, meta.LoopPoint + meta.LoopLength, meta.LoopPoint
+	}
+	start := meta.LoopPoint + time.Duration(m.playback.CurrentLoop)*meta.LoopLength
+	elapsed = max(0, min(m.playback.Position-start, meta.LoopLength))
+	return elapsed, meta.LoopLength, 0
+}
+
+// diagnostics describes the audio output for tuning buffer sizes: driver,
+// format, buffer size and count, and the latency the driver reports.
+func (m Model) diagnostics() string {
+	if m.audioPlayer == nil {
+		return "No audio player"
+	}
+	c := m.audioPlayer.AudioConfig()
+	return fmt.Sprintf("%s %d Hz %d-bit %dch | buffers %d x %s | latency %s",
+		c.Driver, c.SampleRate, c.Bits, c.Channels,
+		c.BufferCount, c.BufferTime, c.Latency)
+}
+
 // renderFooter renders the help/key hints footer.
 func (m Model) renderFooter() string {
 	var content strings.Builder
 
+	if m.noAudio {
+		content.WriteString(m.styles.FooterError.Render("No audio device - display only"))
+		content.WriteString("  ")
+	}
+
 	// Show error if recent (within 5 seconds)
 	if m.lastError != "" && time.Since(m.errorTime) < 5*time.Second {
-		errorStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5555")).
-			Bold(true)
-		content.WriteString(errorStyle.Render("Error: " + m.lastError))
+		content.WriteString(m.styles.FooterError.Render("Error: " + m.lastError))
 		content.WriteString("  ")
 	}
 
 	// Show contextual help based on focus
-	helpStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
-	keyStyle := lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
+	helpStyle := m.styles.FooterDesc
+	keyStyle := m.styles.FooterKey
+
+	if m.notice != "" && time.Since(m.noticeTime) < 5*time.Second {
+		content.WriteString(keyStyle.Render(m.notice))
+		content.WriteString("  ")
+	}
+
+	if m.muted {
+		content.WriteString(m.styles.FooterError.Render("MUTED"))
+		content.WriteString("  ")
+	}
+
+	// Show the time left on the sleep timer
+	if left := m.sleepRemaining(); left > 0 {
+		content.WriteString(keyStyle.Render("Sleep " + formatMinSec(left+time.Second-1)))
+		content.WriteString("  ")
+	}
+
+	// Show progress of recursive directory adds
+	if m.addingFiles > 0 {
+		content.WriteString(keyStyle.Render(fmt.Sprintf("Adding %d files...", m.addingFiles)))
+		content.WriteString("  ")
+	}
 
 	// Determine what to call the left panel based on mode
 	leftPanelName := "browser"
@@ -369,6 +729,10 @@ func (m Model) renderFooter() string {
 		} else {
 			content.WriteString(keyStyle.Render("Enter"))
 			content.WriteString(helpStyle.Render(":add "))
+			content.WriteString(keyStyle.Render("m"))
+			content.WriteString(helpStyle.Render(":mark "))
+			content.WriteString(keyStyle.Render("A"))
+			content.WriteString(helpStyle.Render(":add dir "))
 			content.WriteString(keyStyle.Render("."))
 			content.WriteString(helpStyle.Render(":hidden "))
 			content.WriteString(keyStyle.Render("Tab"))
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dewi-tim/vgmtui/internal/metadata"
+	"github.com/dewi-tim/vgmtui/internal/ui/components"
+)
+
+func TestAbbreviateChip(t *testing.T) {
+	tests := map[string]string{
+		"YM2612":  "OPN2",
+		"YM2151":  "OPM",
+		"SN76489": "DCSG",
+		"NES APU": "APU",
+		"QSound":  "QSound", // No abbreviation: unchanged
+		"":        "",
+	}
+	for name, want := range tests {
+		if got := abbreviateChip(name); got != want {
+			t.Errorf("abbreviateChip(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFormatChipList(t *testing.T) {
+	chips := func(names ...string) []metadata.ChipInfo {
+		infos := make([]metadata.ChipInfo, len(names))
+		for i, name := range names {
+			infos[i] = metadata.ChipInfo{Name: name}
+		}
+		return infos
+	}
+	tests := []struct {
+		chips         []metadata.ChipInfo
+		full, compact string
+	}{
+		{nil, "(none)", "(none)"},
+		{chips("YM2612", "SN76489"), "YM2612, SN76489", "OPN2, DCSG"},
+		{chips("YM2151", "SegaPCM", "YM2151"), "YM2151, SegaPCM, YM2151", "OPM x2, SPCM"},
+		{chips("SN76489", "SN76496", "QSound"), "SN76489, SN76496, QSound", "DCSG x2, QSound"},
+	}
+	for _, tt := range tests {
+		if got := (Model{}).formatChipList(tt.chips); got != tt.full {
+			t.Errorf("full list of %v = %q, want %q", tt.chips, got, tt.full)
+		}
+		if got := (Model{compactChips: true}).formatChipList(tt.chips); got != tt.compact {
+			t.Errorf("compact list of %v = %q, want %q", tt.chips, got, tt.compact)
+		}
+	}
+}
+
+func TestCompactChipsToggle(t *testing.T) {
+	m := newTestModel(t)
+	if m.compactChips {
+		t.Fatal("compact chips on by default")
+	}
+	m = press(m, m.keyMap.CompactChips)
+	if !m.compactChips {
+		t.Fatal("compact chips key did not turn compact chips on")
+	}
+	m = press(m, m.keyMap.CompactChips)
+	if m.compactChips {
+		t.Fatal("compact chips key did not turn compact chips off again")
+	}
+}
+
+func TestFullInfoSwapsPlaylist(t *testing.T) {
+	m := newTestModel(t)
+	m.playlist.AddTrack(components.Track{Path: "/vgm/01.vgm", Title: "Green Hill Zone"})
+	meta := &metadata.Track{
+		Title: "Green Hill Zone", Game: "Sonic the Hedgehog", Composer: "Masato Nakamura",
+		Format: "VGM 1.50", Duration: 90 * time.Second, HasLoop: true, LoopPoint: 12 * time.Second,
+		Chips: []metadata.ChipInfo{{Name: "YM2612", Core: "GPGX", Clock: 7670453}},
+	}
+	const width, height = 80, 30
+
+	tests := []struct {
+		meta *metadata.Track
+		want []string // Shown in the full info panel
+	}{
+		{nil, []string{"Track Details", "No track playing"}},
+		{meta, []string{"Track Details", "Sonic the Hedgehog", "Masato Nakamura", "VGM 1.50", "0:12", "YM2612", "GPGX", "7.670 MHz"}},
+	}
+	for _, tt := range tests {
+		m.trackMeta = tt.meta
+		playlist := m.renderRightPane(width, height)
+		if !strings.Contains(playlist, "Playlist [1]") || strings.Contains(playlist, "Track Details") {
+			t.Fatalf("right pane before the swap:\n%s", playlist)
+		}
+
+		m = press(m, m.keyMap.FullInfo)
+		info := m.renderRightPane(width, height)
+		for _, s := range tt.want {
+			if !strings.Contains(info, s) {
+				t.Errorf("full info panel lacks %q:\n%s", s, info)
+			}
+		}
+		if strings.Contains(info, "Playlist [") {
+			t.Errorf("playlist still shown with full info on:\n%s", info)
+		}
+		// The panels swap in place, leaving the rest of the pane alone
+		if lipgloss.Height(info) != lipgloss.Height(playlist) {
+			t.Errorf("right pane is %d lines with full info, %d with the playlist", lipgloss.Height(info), lipgloss.Height(playlist))
+		}
+
+		m = press(m, m.keyMap.FullInfo)
+		if got := m.renderRightPane(width, height); got != playlist {
+			t.Errorf("toggling full info off did not restore the playlist:\n%s", got)
+		}
+	}
+}
@@ -12,6 +12,7 @@
 #include <vector>
 #include <string>
 #include <map>
+#include <atomic>
 
 // libvgm headers
 #include <stdtype.h>
@@ -61,20 +62,77 @@ struct VgmPlayer {
     uint32_t loopCount;
     uint32_t fadeSamples;
     uint32_t endSilenceSamples;
+    std::atomic<uint32_t> fadeInMs;     // Fade-in at track start (0 = none)
+    std::atomic<double> speed;          // Playback speed (1.0 = normal)
 
     // Cached metadata
     std::map<std::string, std::string> tags;
     std::string formatStr;
     std::vector<std::string> chipNames;
     std::vector<std::string> chipCores;
+    std::vector<uint32_t> chipClocks;
+    std::vector<uint32_t> chipIDs;      // Device IDs for muting
+    std::vector<uint8_t> chipMuted;
+
+    // Scope tap: ring buffer of recently rendered stereo frames.
+    // Written only by the render thread; scopePos is published with release
+    // ordering so readers never need the render mutex.
+    std::atomic<int16_t> scope[VGM_SCOPE_FRAMES * 2];
+    std::atomic<uint32_t> scopePos;
 
     // Empty string for returning
     std::string emptyStr;
 
     VgmPlayer() : dataLoader(nullptr), sampleRate(44100), loopCount(2),
-                  fadeSamples(0), endSilenceSamples(0) {}
+                  fadeSamples(0), endSilenceSamples(0), fadeInMs(0), speed(1.0), scopePos(0) {
+        for (auto& s : scope) s.store(0, std::memory_order_relaxed);
+    }
 };
 
+// Helper: Append rendered stereo 16-bit audio to the scope tap
+static void scopeWrite(VgmPlayer* p, const void* data, uint32_t bytes) {
+    const int16_t* smpl = (const int16_t*)data;
+    uint32_t frames = bytes / (2 * sizeof(int16_t));
+    uint32_t pos = p->scopePos.load(std::memory_order_relaxed);
+
+    // Only the tail of an oversized buffer can be kept
+    if (frames > VGM_SCOPE_FRAMES) {
+        smpl += (frames - VGM_SCOPE_FRAMES) * 2;
+        pos += frames - VGM_SCOPE_FRAMES;
+        frames = VGM_SCOPE_FRAMES;
+    }
+
+    for (uint32_t i = 0; i < frames; i++) {
+        uint32_t idx = ((pos + i) % VGM_SCOPE_FRAMES) * 2;
+        p->scope[idx].store(smpl[i * 2], std::memory_order_relaxed);
+        p->scope[idx + 1].store(smpl[i * 2 + 1], std::memory_order_relaxed);
+    }
+    p->scopePos.store(pos + frames, std::memory_order_release);
+}
+
+// Helper: Scale rendered stereo 16-bit audio by the linear fade-in gain.
+// The ramp runs from 0 at the start of the track to 1 after fadeInMs of
+// playback; pos is the file position (in seconds) of the first frame, which
+// runs ahead of playback time by the playback speed.
+static void fadeInApply(VgmPlayer* p, double pos, void* data, uint32_t bytes) {
+    uint32_t fadeInMs = p->fadeInMs.load(std::memory_order_relaxed);
+    if (fadeInMs == 0) return;
+
+    double fadeIn = fadeInMs / 1000.0;
+    double start = pos / p->speed.load(std::memory_order_relaxed);
+    if (start >= fadeIn) return;
+
+    int16_t* smpl = (int16_t*)data;
+    uint32_t frames = bytes / (2 * sizeof(int16_t));
+    for (uint32_t i = 0; i < frames; i++) {
+        double gain = (start + (double)i / p->sampleRate) / fadeIn;
+        if (gain >= 1.0) break;
+        if (gain < 0.0) gain = 0.0;
+        smpl[i * 2] = (int16_t)(smpl[i * 2] * gain);
+        smpl[i * 2 + 1] = (int16_t)(smpl[i * 2 + 1] * gain);
+    }
+}
+
 // Helper: Convert milliseconds to samples
 static inline uint32_t msToSamples(uint32_t ms, uint32_t sampleRate) {
     return (uint32_t)(((uint64_t)ms * sampleRate + 500) / 1000);
@@ -160,6 +218,11 @@ void vgm_player_set_fade_time(VgmPlayer* p, uint32_t ms) {
     p->player.SetFadeSamples(p->fadeSamples);
 }
 
+void vgm_player_set_fade_in(VgmPlayer* p, uint32_t ms) {
+    if (!p) return;
+    p->fadeInMs.store(ms, std::memory_order_relaxed);
+}
+
 void vgm_player_set_end_silence(VgmPlayer* p, uint32_t ms) {
     if (!p) return;
     p->endSilenceSamples = msToSamples(ms, p->sampleRate);
@@ -176,6 +239,25 @@ void vgm_player_set_volume(VgmPlayer* p, double vol) {
 void vgm_player_set_speed(VgmPlayer* p, double speed) {
     if (!p || speed <= 0.0) return;
     p->player.SetPlaybackSpeed(speed);
+    p->speed.store(speed, std::memory_order_relaxed);
+}
+
+uint32_t vgm_player_get_loop_count(VgmPlayer* p) {
+    return p ? p->loopCount : 0;
+}
+
+uint32_t vgm_player_get_fade_time(VgmPlayer* p) {
+    if (!p || p->sampleRate == 0) return 0;
+    return (uint32_t)((uint64_t)p->fadeSamples * 1000 / p->sampleRate);
+}
+
+uint32_t vgm_player_get_fade_in(VgmPlayer* p) {
+    return p ? p->fadeInMs.load(std::memory_order_relaxed) : 0;
+}
+
+uint32_t vgm_player_get_end_silence(VgmPlayer* p) {
+    if (!p || p->sampleRate == 0) return 0;
+    return (uint32_t)((uint64_t)p->endSilenceSamples * 1000 / p->sampleRate);
 }
 
 /*
@@ -260,9 +342,21 @@ static void generateFormatString(VgmPlayer* p) {
 }
 
 // Helper to enumerate sound chips
+// Helper: Mute or unmute a whole device, including any linked device
+static void setDeviceMuted(PlayerBase* player, uint32_t id, bool muted) {
+    PLR_MUTE_OPTS muteOpts;
+    muteOpts.disable = muted ? 0xFF : 0x00;
+    muteOpts.chnMute[0] = muted ? ~(UINT32)0 : 0;
+    muteOpts.chnMute[1] = muted ? ~(UINT32)0 : 0;
+    player->SetDeviceMuting(id, muteOpts);
+}
+
 static void enumerateChips(VgmPlayer* p) {
     p->chipNames.clear();
     p->chipCores.clear();
+    p->chipClocks.clear();
+    p->chipIDs.clear();
+    p->chipMuted.clear();
 
     PlayerBase* player = p->player.GetPlayer();
     if (!player) return;
@@ -279,6 +373,14 @@ static void enumerateChips(VgmPlayer* p) {
 
         // Get core name from FCC
         p->chipCores.push_back(FCC2Str(di.core));
+
+        // Get clock, masking off the VGM header's dual-chip/variant flag bits
+        p->chipClocks.push_back(di.devCfg ? (di.devCfg->clock & 0x3FFFFFFF) : 0);
+
+        // Players keep muting options across files, so start every chip unmuted
+        p->chipIDs.push_back(di.id);
+        p->chipMuted.push_back(0);
+        setDeviceMuted(player, di.id, false);
     }
 }
 
@@ -349,6 +451,9 @@ void vgm_player_unload(VgmPlayer* p) {
     p->formatStr.clear();
     p->chipNames.clear();
     p->chipCores.clear();
+    p->chipClocks.clear();
+    p->chipIDs.clear();
+    p->chipMuted.clear();
 }
 
 /*
@@ -406,11 +511,27 @@ uint32_t vgm_player_render(VgmPlayer* p, uint32_t frames, int16_t* buffer) {
 
     // Render returns bytes rendered
     uint32_t bytesRendered = p->player.Render(bufSize, buffer);
+    scopeWrite(p, buffer, bytesRendered);
 
     // Convert back to frames
     return bytesRendered / (2 * sizeof(int16_t));
 }
 
+uint32_t vgm_player_get_scope(VgmPlayer* p, int16_t* buffer, uint32_t frames) {
+    if (!p || !buffer || frames == 0) return 0;
+    if (frames > VGM_SCOPE_FRAMES) frames = VGM_SCOPE_FRAMES;
+
+    // Copy the `frames` frames preceding the published write position
+    uint32_t end = p->scopePos.load(std::memory_order_acquire);
+    uint32_t start = end - frames;
+    for (uint32_t i = 0; i < frames; i++) {
+        uint32_t idx = ((start + i) % VGM_SCOPE_FRAMES) * 2;
+        buffer[i * 2] = p->scope[idx].load(std::memory_order_relaxed);
+        buffer[i * 2 + 1] = p->scope[idx + 1].load(std::memory_order_relaxed);
+    }
+    return frames;
+}
+
 /*
  * State queries
  */
@@ -459,6 +580,17 @@ double vgm_player_get_loop_point(VgmPlayer* p) {
     return p->player.GetLoopTime();
 }
 
+double vgm_player_get_loop_length(VgmPlayer* p) {
+    if (!p) return 0.0;
+
+    PlayerBase* player = p->player.GetPlayer();
+    if (!player) return 0.0;
+
+    UINT32 loopTicks = player->GetLoopTicks();
+    if (loopTicks == 0) return 0.0;
+    return player->Tick2Second(loopTicks);
+}
+
 uint32_t vgm_player_get_sample_rate(VgmPlayer* p) {
     if (!p) return 0;
     return p->player.GetSampleRate();
@@ -515,6 +647,11 @@ const char* vgm_player_get_notes(VgmPlayer* p) {
     return getTag(p, "COMMENT");
 }
 
+const char* vgm_player_get_tag(VgmPlayer* p, const char* name) {
+    if (!name) return "";
+    return getTag(p, name);
+}
+
 const char* vgm_player_get_format(VgmPlayer* p) {
     if (!p) return "";
     return p->formatStr.c_str();
@@ -539,6 +676,28 @@ const char* vgm_player_get_chip_core(VgmPlayer* p, uint32_t index) {
     return p->chipCores[index].c_str();
 }
 
+uint32_t vgm_player_get_chip_clock(VgmPlayer* p, uint32_t index) {
+    if (!p || index >= p->chipClocks.size()) return 0;
+    return p->chipClocks[index];
+}
+
+int vgm_player_set_chip_muted(VgmPlayer* p, uint32_t index, int muted) {
+    if (!p) return VGM_ERR_NULLPTR;
+    if (index >= p->chipIDs.size()) return VGM_ERR_STATE;
+
+    PlayerBase* player = p->player.GetPlayer();
+    if (!player) return VGM_ERR_STATE;
+
+    setDeviceMuted(player, p->chipIDs[index], muted != 0);
+    p->chipMuted[index] = muted ? 1 : 0;
+    return VGM_OK;
+}
+
+int vgm_player_get_chip_muted(VgmPlayer* p, uint32_t index) {
+    if (!p || index >= p->chipMuted.size()) return 0;
+    return p->chipMuted[index];
+}
+
 /*
  * =============================================================================
  * Audio Driver Implementation
@@ -554,6 +713,9 @@ struct VgmAudioDriver {
     void* drvData;              // Audio driver instance from AudioDrv_Init
     uint32_t driverID;          // Driver ID used to create this instance
     VgmPlayer* boundPlayer;     // Player bound to this driver
+    VgmPlayer* queuedPlayer;    // Started player to switch to when boundPlayer finishes
+    std::atomic<uint32_t> switches; // Number of switches to a queued player
+    std::atomic<uint32_t> fills;    // Number of buffers the device has asked for
     OS_MUTEX* renderMtx;        // Mutex for thread-safe rendering
     volatile uint8_t paused;    // Pause state flag (read atomically in callback)
 
@@ -565,6 +727,7 @@ struct VgmAudioDriver {
     uint32_t numBuffers;
 
     VgmAudioDriver() : drvData(nullptr), driverID(0), boundPlayer(nullptr),
+                       queuedPlayer(nullptr), switches(0), fills(0),
                        renderMtx(nullptr), paused(0),
                        sampleRate(44100), numChannels(2), numBitsPerSmpl(16),
                        usecPerBuf(10000), numBuffers(4) {}
@@ -573,9 +736,27 @@ struct VgmAudioDriver {
 // Global state
 static bool audioSystemInitialized = false;
 
+// Helper: Render from a player into the output buffer, applying the fade-in
+// and feeding the scope tap. Must be called with the render mutex held.
+static UINT32 renderBound(VgmAudioDriver* drv, VgmPlayer* p, UINT32 bufSize, void* data) {
+    // Position before rendering, where the buffer starts
+    double pos = p->player.GetCurTime(PLAYTIME_LOOP_INCL | PLAYTIME_TIME_FILE);
+    UINT32 renderedBytes = p->player.Render(bufSize, data);
+    // The fade-in and scope tap expect the player's stereo 16-bit layout
+    if (drv->numChannels == 2 && drv->numBitsPerSmpl == 16) {
+        fadeInApply(p, pos, data, renderedBytes);
+        scopeWrite(p, data, renderedBytes);
+    }
+    return renderedBytes;
+}
+
 // FillBuffer callback - called from audio driver's thread
 static UINT32 AudioFillBuffer(void* drvStruct, void* userParam, UINT32 bufSize, void* data) {
     VgmAudioDriver* drv = (VgmAudioDriver*)userParam;
+    if (drv) {
+        // Lets the Go side notice a device that stopped pulling audio
+        drv->fills.fetch_add(1, std::memory_order_relaxed);
+    }
 
     // Early out if paused or no player bound
     if (!drv || drv->paused || !drv->boundPlayer) {
@@ -588,7 +769,17 @@ static UINT32 AudioFillBuffer(void* drvStruct, void* userParam, UINT32 bufSize,
     // Lock the mutex and render
     if (OSMutex_Lock(drv->renderMtx) == 0) {
         if (drv->boundPlayer) {
-            renderedBytes = drv->boundPlayer->player.Render(bufSize, data);
+            renderedBytes = renderBound(drv, drv->boundPlayer, bufSize, data);
+
+            // Gapless: once the bound player finishes, carry on with the
+            // queued one in the same buffer
+            if (drv->queuedPlayer && (drv->boundPlayer->player.GetState() & PLAYSTATE_FIN)) {
+                drv->boundPlayer = drv->queuedPlayer;
+                drv->queuedPlayer = nullptr;
+                drv->switches.fetch_add(1, std::memory_order_release);
+                renderedBytes += renderBound(drv, drv->boundPlayer, bufSize - renderedBytes,
+                                             (uint8_t*)data + renderedBytes);
+            }
         }
         OSMutex_Unlock(drv->renderMtx);
     }
@@ -741,6 +932,30 @@ void vgm_audio_driver_set_buffer_count(VgmAudioDriver* drv, uint32_t count) {
     drv->numBuffers = count;
 }
 
+uint32_t vgm_audio_driver_get_id(VgmAudioDriver* drv) {
+    return drv ? drv->driverID : 0;
+}
+
+uint32_t vgm_audio_driver_get_sample_rate(VgmAudioDriver* drv) {
+    return drv ? drv->sampleRate : 0;
+}
+
+uint8_t vgm_audio_driver_get_channels(VgmAudioDriver* drv) {
+    return drv ? drv->numChannels : 0;
+}
+
+uint8_t vgm_audio_driver_get_bits(VgmAudioDriver* drv) {
+    return drv ? drv->numBitsPerSmpl : 0;
+}
+
+uint32_t vgm_audio_driver_get_buffer_time(VgmAudioDriver* drv) {
+    return drv ? drv->usecPerBuf : 0;
+}
+
+uint32_t vgm_audio_driver_get_buffer_count(VgmAudioDriver* drv) {
+    return drv ? drv->numBuffers : 0;
+}
+
 /*
  * Audio driver control
  */
@@ -831,9 +1046,28 @@ void vgm_audio_driver_unbind_player(VgmAudioDriver* drv) {
 
     OSMutex_Lock(drv->renderMtx);
     drv->boundPlayer = nullptr;
+    drv->queuedPlayer = nullptr;
+    OSMutex_Unlock(drv->renderMtx);
+}
+
+void vgm_audio_driver_queue_player(VgmAudioDriver* drv, VgmPlayer* player) {
+    if (!drv) return;
+
+    OSMutex_Lock(drv->renderMtx);
+    drv->queuedPlayer = player;
     OSMutex_Unlock(drv->renderMtx);
 }
 
+uint32_t vgm_audio_driver_get_switches(VgmAudioDriver* drv) {
+    if (!drv) return 0;
+    return drv->switches.load(std::memory_order_acquire);
+}
+
+uint32_t vgm_audio_driver_get_fills(VgmAudioDriver* drv) {
+    if (!drv) return 0;
+    return drv->fills.load(std::memory_order_relaxed);
+}
+
 /*
  * Thread-safe player operations
  */
@@ -862,3 +1096,11 @@ void vgm_audio_safe_fade_out(VgmAudioDriver* drv) {
     drv->boundPlayer->player.FadeOut();
     OSMutex_Unlock(drv->renderMtx);
 }
+
+void vgm_audio_safe_set_chip_muted(VgmAudioDriver* drv, uint32_t index, int muted) {
+    if (!drv || !drv->boundPlayer) return;
+
+    OSMutex_Lock(drv->renderMtx);
+    vgm_player_set_chip_muted(drv->boundPlayer, index, muted);
+    OSMutex_Unlock(drv->renderMtx);
+}
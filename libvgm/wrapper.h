@@ -54,6 +54,11 @@ void vgm_player_set_loop_count(VgmPlayer* p, uint32_t count);
 /* Set the fade-out time in milliseconds (default: 4000) */
 void vgm_player_set_fade_time(VgmPlayer* p, uint32_t ms);
 
+/* Set the fade-in time at track start in milliseconds (default: 0 = none).
+ * Applied to playback through the audio driver callback; vgm_player_render
+ * output is left unscaled. */
+void vgm_player_set_fade_in(VgmPlayer* p, uint32_t ms);
+
 /* Set the end silence time in milliseconds (default: 1000) */
 void vgm_player_set_end_silence(VgmPlayer* p, uint32_t ms);
 
@@ -63,6 +68,12 @@ void vgm_player_set_volume(VgmPlayer* p, double vol);
 /* Set playback speed (1.0 = normal, 0.5 = half, 2.0 = double) */
 void vgm_player_set_speed(VgmPlayer* p, double speed);
 
+/* Get the configured loop count, fade-out, fade-in and end silence times. */
+uint32_t vgm_player_get_loop_count(VgmPlayer* p);
+uint32_t vgm_player_get_fade_time(VgmPlayer* p);
+uint32_t vgm_player_get_fade_in(VgmPlayer* p);
+uint32_t vgm_player_get_end_silence(VgmPlayer* p);
+
 /*
  * File operations
  */
@@ -104,6 +115,19 @@ void vgm_player_seek(VgmPlayer* p, double seconds);
  */
 uint32_t vgm_player_render(VgmPlayer* p, uint32_t frames, int16_t* buffer);
 
+/* Number of stereo frames kept in the scope tap ring buffer. */
+#define VGM_SCOPE_FRAMES    4096
+
+/*
+ * Copy the most recently rendered audio from the scope tap.
+ * The tap is fed by every render path (including the audio driver callback)
+ * and is lock-free: it never blocks rendering, at worst the copy may tear
+ * against a concurrent render, which is harmless for visualization.
+ * buffer: receives up to `frames` stereo frames (L, R, L, R, ...), oldest first
+ * Returns: number of frames copied (at most VGM_SCOPE_FRAMES)
+ */
+uint32_t vgm_player_get_scope(VgmPlayer* p, int16_t* buffer, uint32_t frames);
+
 /*
  * State queries
  */
@@ -132,6 +156,9 @@ int vgm_player_has_loop(VgmPlayer* p);
 /* Get the loop point position in seconds. Returns 0 if no loop. */
 double vgm_player_get_loop_point(VgmPlayer* p);
 
+/* Get the length of one pass through the loop in seconds. Returns 0 if no loop. */
+double vgm_player_get_loop_length(VgmPlayer* p);
+
 /* Get the configured sample rate. */
 uint32_t vgm_player_get_sample_rate(VgmPlayer* p);
 
@@ -162,6 +189,13 @@ const char* vgm_player_get_vgm_by(VgmPlayer* p);
 /* Get notes/comments (GD3: COMMENT) */
 const char* vgm_player_get_notes(VgmPlayer* p);
 
+/*
+ * Get a tag by its libvgm name exactly as stored, without falling back
+ * between languages (e.g. "TITLE" for English, "TITLE-JPN" for the
+ * original Japanese).
+ */
+const char* vgm_player_get_tag(VgmPlayer* p, const char* name);
+
 /* Get file format string (e.g., "VGM 1.71", "S98 v3") */
 const char* vgm_player_get_format(VgmPlayer* p);
 
@@ -178,6 +212,20 @@ const char* vgm_player_get_chip_name(VgmPlayer* p, uint32_t index);
 /* Get the emulation core name for a chip by index. Returns "" if invalid. */
 const char* vgm_player_get_chip_core(VgmPlayer* p, uint32_t index);
 
+/* Get the clock rate in Hz for a chip by index. Returns 0 if unknown/invalid. */
+uint32_t vgm_player_get_chip_clock(VgmPlayer* p, uint32_t index);
+
+/*
+ * Mute or unmute a sound chip by index. All chips start unmuted when a file
+ * is loaded. Not synchronized with rendering - during playback use
+ * vgm_audio_safe_set_chip_muted instead.
+ * Returns VGM_OK, or VGM_ERR_STATE if the index is invalid.
+ */
+int vgm_player_set_chip_muted(VgmPlayer* p, uint32_t index, int muted);
+
+/* Check if a sound chip is muted. Returns 1 if muted, 0 otherwise. */
+int vgm_player_get_chip_muted(VgmPlayer* p, uint32_t index);
+
 /*
  * =============================================================================
  * Audio Driver API
@@ -256,6 +304,15 @@ void vgm_audio_driver_set_buffer_time(VgmAudioDriver* drv, uint32_t usec);
 /* Set number of buffers (default: 4). */
 void vgm_audio_driver_set_buffer_count(VgmAudioDriver* drv, uint32_t count);
 
+/* Get the configured driver ID, sample rate, channels, bits per sample,
+ * buffer time (microseconds) and buffer count. */
+uint32_t vgm_audio_driver_get_id(VgmAudioDriver* drv);
+uint32_t vgm_audio_driver_get_sample_rate(VgmAudioDriver* drv);
+uint8_t vgm_audio_driver_get_channels(VgmAudioDriver* drv);
+uint8_t vgm_audio_driver_get_bits(VgmAudioDriver* drv);
+uint32_t vgm_audio_driver_get_buffer_time(VgmAudioDriver* drv);
+uint32_t vgm_audio_driver_get_buffer_count(VgmAudioDriver* drv);
+
 /*
  * Audio driver control
  */
@@ -282,9 +339,25 @@ uint32_t vgm_audio_driver_get_latency(VgmAudioDriver* drv);
 /* Bind a player to the audio driver. Sets up the internal render callback. */
 int vgm_audio_driver_bind_player(VgmAudioDriver* drv, VgmPlayer* player);
 
-/* Unbind the player from the audio driver. */
+/* Unbind the player from the audio driver. Also drops any queued player. */
 void vgm_audio_driver_unbind_player(VgmAudioDriver* drv);
 
+/*
+ * Queue a started player to take over from the bound player as soon as it
+ * finishes, within the same audio buffer (gapless playback). The queued
+ * player becomes the bound player. Pass NULL to clear the queue.
+ */
+void vgm_audio_driver_queue_player(VgmAudioDriver* drv, VgmPlayer* player);
+
+/* Number of times the driver has switched to a queued player. Lock-free. */
+uint32_t vgm_audio_driver_get_switches(VgmAudioDriver* drv);
+
+/*
+ * Number of buffers the audio device has asked the driver to fill. It stops
+ * advancing if the device goes away while playing. Lock-free.
+ */
+uint32_t vgm_audio_driver_get_fills(VgmAudioDriver* drv);
+
 /*
  * Thread-safe player operations (acquires render mutex)
  */
@@ -298,6 +371,9 @@ void vgm_audio_safe_reset(VgmAudioDriver* drv);
 /* Trigger fade-out - thread-safe version that acquires render mutex. */
 void vgm_audio_safe_fade_out(VgmAudioDriver* drv);
 
+/* Mute or unmute a chip - thread-safe version that acquires render mutex. */
+void vgm_audio_safe_set_chip_muted(VgmAudioDriver* drv, uint32_t index, int muted);
+
 #ifdef __cplusplus
 }
 #endif